@@ -1,13 +1,34 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/project/library/config"
 	"github.com/project/library/internal/app"
+	"github.com/project/library/internal/cli"
 	log "github.com/sirupsen/logrus"
 	"go.uber.org/zap"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			runCommand("import catalog", os.Args[2:], func(ctx context.Context, args []string) error {
+				return cli.Import(ctx, args, os.Stdin, os.Stdout)
+			})
+			return
+		case "export":
+			runCommand("export catalog", os.Args[2:], func(ctx context.Context, args []string) error {
+				return cli.Export(ctx, args, os.Stdout)
+			})
+			return
+		}
+	}
+
 	cfg, err := config.NewConfig()
 
 	if err != nil {
@@ -24,3 +45,13 @@ func main() {
 
 	app.Run(logger, cfg)
 }
+
+func runCommand(name string, args []string, command func(ctx context.Context, args []string) error) {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if err := command(ctx, args); err != nil {
+		cancel()
+		log.Fatalf("can not %s: %s", name, err)
+	}
+}
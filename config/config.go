@@ -3,8 +3,10 @@ package config
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -13,11 +15,21 @@ type (
 		GRPC
 		PG
 		Outbox
+		Notification
+		Recommendation
+		Blob
+		Batch
+		Idempotency
+		Watch
+		Trash
+		Auth
 	}
 
 	GRPC struct {
 		Port        string `env:"GRPC_PORT"`
 		GatewayPort string `env:"GRPC_GATEWAY_PORT"`
+		// GatewayAllowedOrigins are the origins of other sites allowed to open the catalog WebSocket
+		GatewayAllowedOrigins []string `env:"GRPC_GATEWAY_ALLOWED_ORIGINS"`
 	}
 
 	PG struct {
@@ -38,7 +50,83 @@ type (
 		InProgressTTLMS time.Duration `env:"OUTBOX_IN_PROGRESS_TTL_MS"`
 		BookSendURL     string        `env:"OUTBOX_BOOK_SEND_URL"`
 		AuthorSendURL   string        `env:"OUTBOX_AUTHOR_SEND_URL"`
+		ReviewSendURL   string        `env:"OUTBOX_REVIEW_SEND_URL"`
 	}
+
+	Notification struct {
+		Enabled       bool          `env:"NOTIFICATION_ENABLED"`
+		BatchSize     int           `env:"NOTIFICATION_BATCH_SIZE"`
+		WaitTimeMS    time.Duration `env:"NOTIFICATION_WAIT_TIME_MS"`
+		DaysBeforeDue int           `env:"NOTIFICATION_DAYS_BEFORE_DUE"`
+		TemplatesDir  string        `env:"NOTIFICATION_TEMPLATES_DIR"`
+		SMTPHost      string        `env:"NOTIFICATION_SMTP_HOST"`
+		SMTPPort      string        `env:"NOTIFICATION_SMTP_PORT"`
+		SMTPUser      string        `env:"NOTIFICATION_SMTP_USER"`
+		SMTPPassword  string        `env:"NOTIFICATION_SMTP_PASSWORD"`
+		SMTPFrom      string        `env:"NOTIFICATION_SMTP_FROM"`
+	}
+
+	Recommendation struct {
+		Enabled bool          `env:"RECOMMENDATION_ENABLED"`
+		TopN    int           `env:"RECOMMENDATION_TOP_N"`
+		RunAt   time.Duration `env:"RECOMMENDATION_RUN_AT"`
+	}
+
+	Blob struct {
+		Backend     string `env:"BLOB_BACKEND"`
+		LocalDir    string `env:"BLOB_LOCAL_DIR"`
+		S3Endpoint  string `env:"BLOB_S3_ENDPOINT"`
+		S3Region    string `env:"BLOB_S3_REGION"`
+		S3Bucket    string `env:"BLOB_S3_BUCKET"`
+		S3AccessKey string `env:"BLOB_S3_ACCESS_KEY"`
+		S3SecretKey string `env:"BLOB_S3_SECRET_KEY"`
+	}
+
+	Batch struct {
+		MaxIDs int `env:"BATCH_MAX_IDS"`
+	}
+
+	Idempotency struct {
+		TTLMS time.Duration `env:"IDEMPOTENCY_TTL_MS"`
+	}
+
+	Watch struct {
+		PollIntervalMS time.Duration `env:"WATCH_POLL_INTERVAL_MS"`
+	}
+
+	Trash struct {
+		RetentionMS     time.Duration `env:"TRASH_RETENTION_MS"`
+		PurgeIntervalMS time.Duration `env:"TRASH_PURGE_INTERVAL_MS"`
+	}
+
+	Auth struct {
+		Enabled       bool   `env:"AUTH_ENABLED"`
+		JWKSFile      string `env:"AUTH_JWKS_FILE"`
+		JWTHMACSecret string `env:"AUTH_JWT_HMAC_SECRET"`
+		JWTIssuer     string `env:"AUTH_JWT_ISSUER"`
+		JWTAudience   string `env:"AUTH_JWT_AUDIENCE"`
+		// APIKeys maps the name of a key owner to the key
+		APIKeys map[string]string `env:"AUTH_API_KEYS"`
+		// Admins are the principals allowed to read and restore the trash
+		Admins []string `env:"AUTH_ADMINS"`
+	}
+)
+
+const (
+	BlobBackendLocal = "local"
+	BlobBackendS3    = "s3"
+
+	defaultBlobLocalDir = "data/blobs"
+	defaultBlobS3Region = "us-east-1"
+
+	defaultBatchMaxIDs = 100
+
+	defaultIdempotencyTTL = 24 * time.Hour
+
+	defaultWatchPollInterval = time.Second
+
+	defaultTrashRetention     = 30 * 24 * time.Hour
+	defaultTrashPurgeInterval = time.Hour
 )
 
 func NewConfig() (*Config, error) {
@@ -63,6 +151,12 @@ func NewConfig() (*Config, error) {
 	)
 
 	var err error
+	cfg.GRPC.GatewayAllowedOrigins, err = parseOrigins(os.Getenv("GRPC_GATEWAY_ALLOWED_ORIGINS"))
+
+	if err != nil {
+		return nil, err
+	}
+
 	cfg.Outbox.Enabled, err = strconv.ParseBool(os.Getenv("OUTBOX_ENABLED"))
 
 	if err != nil {
@@ -96,11 +190,334 @@ func NewConfig() (*Config, error) {
 
 		cfg.Outbox.BookSendURL = os.Getenv("OUTBOX_BOOK_SEND_URL")
 		cfg.Outbox.AuthorSendURL = os.Getenv("OUTBOX_AUTHOR_SEND_URL")
+		cfg.Outbox.ReviewSendURL = os.Getenv("OUTBOX_REVIEW_SEND_URL")
+	}
+
+	if err = parseNotification(cfg); err != nil {
+		return nil, err
+	}
+
+	if err = parseRecommendation(cfg); err != nil {
+		return nil, err
+	}
+
+	if err = parseBlob(cfg); err != nil {
+		return nil, err
+	}
+
+	if err = parseBatch(cfg); err != nil {
+		return nil, err
+	}
+
+	if err = parseIdempotency(cfg); err != nil {
+		return nil, err
+	}
+
+	if err = parseWatch(cfg); err != nil {
+		return nil, err
+	}
+
+	if err = parseTrash(cfg); err != nil {
+		return nil, err
+	}
+
+	if err = parseAuth(cfg); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
 }
 
+// parseOrigins reads a comma separated list of origins such as https://library.example.com.
+func parseOrigins(value string) ([]string, error) {
+	origins := make([]string, 0)
+
+	for _, origin := range strings.Split(value, ",") {
+		origin = strings.TrimSpace(origin)
+
+		if origin == "" {
+			continue
+		}
+
+		parsed, err := url.Parse(origin)
+
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" || parsed.Path != "" {
+			return nil, fmt.Errorf("GRPC_GATEWAY_ALLOWED_ORIGINS must be a list of scheme://host origins, got %q", origin)
+		}
+
+		origins = append(origins, origin)
+	}
+
+	return origins, nil
+}
+
+func parseNotification(cfg *Config) error {
+	enabled := os.Getenv("NOTIFICATION_ENABLED")
+
+	if enabled == "" {
+		return nil
+	}
+
+	var err error
+	cfg.Notification.Enabled, err = strconv.ParseBool(enabled)
+
+	if err != nil || !cfg.Notification.Enabled {
+		return err
+	}
+
+	cfg.Notification.BatchSize, err = parseInt(os.Getenv("NOTIFICATION_BATCH_SIZE"))
+
+	if err != nil {
+		return err
+	}
+
+	cfg.Notification.WaitTimeMS, err = parseTime(os.Getenv("NOTIFICATION_WAIT_TIME_MS"))
+
+	if err != nil {
+		return err
+	}
+
+	cfg.Notification.DaysBeforeDue, err = parseInt(os.Getenv("NOTIFICATION_DAYS_BEFORE_DUE"))
+
+	if err != nil {
+		return err
+	}
+
+	cfg.Notification.TemplatesDir = os.Getenv("NOTIFICATION_TEMPLATES_DIR")
+	cfg.Notification.SMTPHost = os.Getenv("NOTIFICATION_SMTP_HOST")
+	cfg.Notification.SMTPPort = os.Getenv("NOTIFICATION_SMTP_PORT")
+	cfg.Notification.SMTPUser = os.Getenv("NOTIFICATION_SMTP_USER")
+	cfg.Notification.SMTPPassword = os.Getenv("NOTIFICATION_SMTP_PASSWORD")
+	cfg.Notification.SMTPFrom = os.Getenv("NOTIFICATION_SMTP_FROM")
+
+	return nil
+}
+
+func parseRecommendation(cfg *Config) error {
+	enabled := os.Getenv("RECOMMENDATION_ENABLED")
+
+	if enabled == "" {
+		return nil
+	}
+
+	var err error
+	cfg.Recommendation.Enabled, err = strconv.ParseBool(enabled)
+
+	if err != nil || !cfg.Recommendation.Enabled {
+		return err
+	}
+
+	cfg.Recommendation.TopN, err = parseInt(os.Getenv("RECOMMENDATION_TOP_N"))
+
+	if err != nil {
+		return err
+	}
+
+	cfg.Recommendation.RunAt, err = parseClock(os.Getenv("RECOMMENDATION_RUN_AT"))
+
+	return err
+}
+
+// parseBlob picks the attachment storage, files are kept on the local disk by default.
+func parseBlob(cfg *Config) error {
+	cfg.Blob.Backend = os.Getenv("BLOB_BACKEND")
+
+	switch cfg.Blob.Backend {
+	case "", BlobBackendLocal:
+		cfg.Blob.Backend = BlobBackendLocal
+		cfg.Blob.LocalDir = os.Getenv("BLOB_LOCAL_DIR")
+
+		if cfg.Blob.LocalDir == "" {
+			cfg.Blob.LocalDir = defaultBlobLocalDir
+		}
+	case BlobBackendS3:
+		cfg.Blob.S3Endpoint = os.Getenv("BLOB_S3_ENDPOINT")
+		cfg.Blob.S3Region = os.Getenv("BLOB_S3_REGION")
+		cfg.Blob.S3Bucket = os.Getenv("BLOB_S3_BUCKET")
+		cfg.Blob.S3AccessKey = os.Getenv("BLOB_S3_ACCESS_KEY")
+		cfg.Blob.S3SecretKey = os.Getenv("BLOB_S3_SECRET_KEY")
+
+		if cfg.Blob.S3Endpoint == "" || cfg.Blob.S3Bucket == "" {
+			return fmt.Errorf("BLOB_S3_ENDPOINT and BLOB_S3_BUCKET are required for the s3 blob backend")
+		}
+
+		if cfg.Blob.S3Region == "" {
+			cfg.Blob.S3Region = defaultBlobS3Region
+		}
+	default:
+		return fmt.Errorf("unsupported blob backend: %q", cfg.Blob.Backend)
+	}
+
+	return nil
+}
+
+// parseBatch reads how many ids a batch get accepts, 100 by default.
+func parseBatch(cfg *Config) error {
+	maxIDs := os.Getenv("BATCH_MAX_IDS")
+
+	if maxIDs == "" {
+		cfg.Batch.MaxIDs = defaultBatchMaxIDs
+		return nil
+	}
+
+	var err error
+	cfg.Batch.MaxIDs, err = parseInt(maxIDs)
+
+	if err != nil {
+		return err
+	}
+
+	if cfg.Batch.MaxIDs <= 0 {
+		return fmt.Errorf("BATCH_MAX_IDS must be positive, got %d", cfg.Batch.MaxIDs)
+	}
+
+	return nil
+}
+
+// parseIdempotency reads how long a stored idempotency key is replayed, a day by default.
+func parseIdempotency(cfg *Config) error {
+	ttl := os.Getenv("IDEMPOTENCY_TTL_MS")
+
+	if ttl == "" {
+		cfg.Idempotency.TTLMS = defaultIdempotencyTTL
+		return nil
+	}
+
+	var err error
+	cfg.Idempotency.TTLMS, err = parseTime(ttl)
+
+	if err != nil {
+		return err
+	}
+
+	if cfg.Idempotency.TTLMS <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_TTL_MS must be positive, got %d", cfg.Idempotency.TTLMS.Milliseconds())
+	}
+
+	return nil
+}
+
+// parseWatch reads how often a catalog watch looks for new changes, once a second by default.
+func parseWatch(cfg *Config) error {
+	interval := os.Getenv("WATCH_POLL_INTERVAL_MS")
+
+	if interval == "" {
+		cfg.Watch.PollIntervalMS = defaultWatchPollInterval
+		return nil
+	}
+
+	var err error
+	cfg.Watch.PollIntervalMS, err = parseTime(interval)
+
+	if err != nil {
+		return err
+	}
+
+	if cfg.Watch.PollIntervalMS <= 0 {
+		return fmt.Errorf("WATCH_POLL_INTERVAL_MS must be positive, got %d", cfg.Watch.PollIntervalMS.Milliseconds())
+	}
+
+	return nil
+}
+
+// parseTrash reads how long deleted books and authors stay in the trash, 30 days by default,
+// and how often the expired ones are purged, once an hour by default.
+func parseTrash(cfg *Config) error {
+	cfg.Trash.RetentionMS = defaultTrashRetention
+	cfg.Trash.PurgeIntervalMS = defaultTrashPurgeInterval
+
+	var err error
+	if retention := os.Getenv("TRASH_RETENTION_MS"); retention != "" {
+		cfg.Trash.RetentionMS, err = parseTime(retention)
+
+		if err != nil {
+			return err
+		}
+
+		if cfg.Trash.RetentionMS <= 0 {
+			return fmt.Errorf("TRASH_RETENTION_MS must be positive, got %d", cfg.Trash.RetentionMS.Milliseconds())
+		}
+	}
+
+	if interval := os.Getenv("TRASH_PURGE_INTERVAL_MS"); interval != "" {
+		cfg.Trash.PurgeIntervalMS, err = parseTime(interval)
+
+		if err != nil {
+			return err
+		}
+
+		if cfg.Trash.PurgeIntervalMS <= 0 {
+			return fmt.Errorf("TRASH_PURGE_INTERVAL_MS must be positive, got %d", cfg.Trash.PurgeIntervalMS.Milliseconds())
+		}
+	}
+
+	return nil
+}
+
+// parseAuth reads how the callers are authenticated. API keys are given as "name:key,name:key",
+// the name is the principal of the requests made with the key.
+func parseAuth(cfg *Config) error {
+	enabled := os.Getenv("AUTH_ENABLED")
+
+	if enabled == "" {
+		return nil
+	}
+
+	var err error
+	cfg.Auth.Enabled, err = strconv.ParseBool(enabled)
+
+	if err != nil || !cfg.Auth.Enabled {
+		return err
+	}
+
+	cfg.Auth.JWKSFile = os.Getenv("AUTH_JWKS_FILE")
+	cfg.Auth.JWTHMACSecret = os.Getenv("AUTH_JWT_HMAC_SECRET")
+	cfg.Auth.JWTIssuer = os.Getenv("AUTH_JWT_ISSUER")
+	cfg.Auth.JWTAudience = os.Getenv("AUTH_JWT_AUDIENCE")
+	cfg.Auth.APIKeys = make(map[string]string)
+
+	if keys := os.Getenv("AUTH_API_KEYS"); keys != "" {
+		for _, pair := range strings.Split(keys, ",") {
+			name, key, found := strings.Cut(strings.TrimSpace(pair), ":")
+
+			if !found || name == "" || key == "" {
+				return fmt.Errorf("AUTH_API_KEYS must be a list of name:key pairs, got %q", pair)
+			}
+
+			if _, ok := cfg.Auth.APIKeys[name]; ok {
+				return fmt.Errorf("AUTH_API_KEYS has several keys named %q", name)
+			}
+
+			cfg.Auth.APIKeys[name] = key
+		}
+	}
+
+	cfg.Auth.Admins = make([]string, 0)
+
+	for _, admin := range strings.Split(os.Getenv("AUTH_ADMINS"), ",") {
+		if admin = strings.TrimSpace(admin); admin != "" {
+			cfg.Auth.Admins = append(cfg.Auth.Admins, admin)
+		}
+	}
+
+	if cfg.Auth.JWKSFile == "" && cfg.Auth.JWTHMACSecret == "" && len(cfg.Auth.APIKeys) == 0 {
+		return fmt.Errorf("AUTH_JWKS_FILE, AUTH_JWT_HMAC_SECRET or AUTH_API_KEYS is required when AUTH_ENABLED is set")
+	}
+
+	return nil
+}
+
+// parseClock parses a "15:04" wall clock time into the offset from midnight.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+
+	if err != nil {
+		return time.Duration(0), err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
 func parseTime(s string) (time.Duration, error) {
 	t, err := parseInt(s)
 
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -60,3 +61,239 @@ func TestNewConfig(t *testing.T) {
 	compareGrpcVars(t, result.GRPC, config.GRPC)
 	comparePGVars(t, result.PG, config.PG)
 }
+
+func TestNewConfigAllowedOrigins(t *testing.T) {
+	for i := range len(fields) {
+		t.Setenv(fields[i][0], fields[i][1])
+	}
+	t.Setenv("OUTBOX_ENABLED", "false")
+
+	result, err := NewConfig()
+	require.NoError(t, err)
+	require.Empty(t, result.GRPC.GatewayAllowedOrigins)
+
+	t.Setenv("GRPC_GATEWAY_ALLOWED_ORIGINS", "https://library.example.com, http://localhost:3000")
+
+	result, err = NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://library.example.com", "http://localhost:3000"}, result.GRPC.GatewayAllowedOrigins)
+
+	t.Setenv("GRPC_GATEWAY_ALLOWED_ORIGINS", "library.example.com")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+}
+
+func TestNewConfigNotification(t *testing.T) {
+	for i := range len(fields) {
+		t.Setenv(fields[i][0], fields[i][1])
+	}
+	t.Setenv("OUTBOX_ENABLED", "false")
+	t.Setenv("NOTIFICATION_ENABLED", "true")
+	t.Setenv("NOTIFICATION_BATCH_SIZE", "10")
+	t.Setenv("NOTIFICATION_WAIT_TIME_MS", "1000")
+	t.Setenv("NOTIFICATION_DAYS_BEFORE_DUE", "3")
+	t.Setenv("NOTIFICATION_SMTP_HOST", "127.0.0.1")
+	t.Setenv("NOTIFICATION_SMTP_PORT", "1025")
+
+	result, err := NewConfig()
+	require.NoError(t, err)
+	require.True(t, result.Notification.Enabled)
+	require.Equal(t, 10, result.Notification.BatchSize)
+	require.Equal(t, time.Second, result.Notification.WaitTimeMS)
+	require.Equal(t, 3, result.Notification.DaysBeforeDue)
+	require.Equal(t, "127.0.0.1", result.Notification.SMTPHost)
+
+	t.Setenv("NOTIFICATION_DAYS_BEFORE_DUE", "three")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+}
+
+func TestNewConfigRecommendation(t *testing.T) {
+	for i := range len(fields) {
+		t.Setenv(fields[i][0], fields[i][1])
+	}
+	t.Setenv("OUTBOX_ENABLED", "false")
+	t.Setenv("RECOMMENDATION_ENABLED", "true")
+	t.Setenv("RECOMMENDATION_TOP_N", "20")
+	t.Setenv("RECOMMENDATION_RUN_AT", "03:30")
+
+	result, err := NewConfig()
+	require.NoError(t, err)
+	require.True(t, result.Recommendation.Enabled)
+	require.Equal(t, 20, result.Recommendation.TopN)
+	require.Equal(t, 3*time.Hour+30*time.Minute, result.Recommendation.RunAt)
+
+	t.Setenv("RECOMMENDATION_RUN_AT", "25:00")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+}
+
+func TestNewConfigBlob(t *testing.T) {
+	for i := range len(fields) {
+		t.Setenv(fields[i][0], fields[i][1])
+	}
+	t.Setenv("OUTBOX_ENABLED", "false")
+
+	result, err := NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, BlobBackendLocal, result.Blob.Backend)
+	require.Equal(t, "data/blobs", result.Blob.LocalDir)
+
+	t.Setenv("BLOB_BACKEND", "s3")
+	t.Setenv("BLOB_S3_ENDPOINT", "http://127.0.0.1:9000")
+	t.Setenv("BLOB_S3_BUCKET", "library")
+
+	result, err = NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, "library", result.Blob.S3Bucket)
+	require.Equal(t, "us-east-1", result.Blob.S3Region)
+
+	t.Setenv("BLOB_S3_BUCKET", "")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+
+	t.Setenv("BLOB_BACKEND", "ftp")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+}
+
+func TestNewConfigBatch(t *testing.T) {
+	for i := range len(fields) {
+		t.Setenv(fields[i][0], fields[i][1])
+	}
+	t.Setenv("OUTBOX_ENABLED", "false")
+
+	result, err := NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, 100, result.Batch.MaxIDs)
+
+	t.Setenv("BATCH_MAX_IDS", "50")
+
+	result, err = NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, 50, result.Batch.MaxIDs)
+
+	t.Setenv("BATCH_MAX_IDS", "0")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+}
+
+func TestNewConfigIdempotency(t *testing.T) {
+	for i := range len(fields) {
+		t.Setenv(fields[i][0], fields[i][1])
+	}
+	t.Setenv("OUTBOX_ENABLED", "false")
+
+	result, err := NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour, result.Idempotency.TTLMS)
+
+	t.Setenv("IDEMPOTENCY_TTL_MS", "60000")
+
+	result, err = NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, result.Idempotency.TTLMS)
+
+	t.Setenv("IDEMPOTENCY_TTL_MS", "-1")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+}
+
+func TestNewConfigWatch(t *testing.T) {
+	for i := range len(fields) {
+		t.Setenv(fields[i][0], fields[i][1])
+	}
+	t.Setenv("OUTBOX_ENABLED", "false")
+
+	result, err := NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, time.Second, result.Watch.PollIntervalMS)
+
+	t.Setenv("WATCH_POLL_INTERVAL_MS", "250")
+
+	result, err = NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, 250*time.Millisecond, result.Watch.PollIntervalMS)
+
+	t.Setenv("WATCH_POLL_INTERVAL_MS", "0")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+}
+
+func TestNewConfigTrash(t *testing.T) {
+	for i := range len(fields) {
+		t.Setenv(fields[i][0], fields[i][1])
+	}
+	t.Setenv("OUTBOX_ENABLED", "false")
+
+	result, err := NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, 30*24*time.Hour, result.Trash.RetentionMS)
+	require.Equal(t, time.Hour, result.Trash.PurgeIntervalMS)
+
+	t.Setenv("TRASH_RETENTION_MS", "60000")
+	t.Setenv("TRASH_PURGE_INTERVAL_MS", "1000")
+
+	result, err = NewConfig()
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, result.Trash.RetentionMS)
+	require.Equal(t, time.Second, result.Trash.PurgeIntervalMS)
+
+	t.Setenv("TRASH_RETENTION_MS", "0")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+
+	t.Setenv("TRASH_RETENTION_MS", "60000")
+	t.Setenv("TRASH_PURGE_INTERVAL_MS", "-1")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+}
+
+func TestNewConfigAuth(t *testing.T) {
+	for i := range len(fields) {
+		t.Setenv(fields[i][0], fields[i][1])
+	}
+	t.Setenv("OUTBOX_ENABLED", "false")
+
+	result, err := NewConfig()
+	require.NoError(t, err)
+	require.False(t, result.Auth.Enabled)
+
+	t.Setenv("AUTH_ENABLED", "true")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+
+	t.Setenv("AUTH_JWT_HMAC_SECRET", "secret")
+	t.Setenv("AUTH_JWT_ISSUER", "https://issuer.example.com")
+	t.Setenv("AUTH_API_KEYS", "ci:ci-key, importer:importer:key")
+	t.Setenv("AUTH_ADMINS", "alice, ci")
+
+	result, err = NewConfig()
+	require.NoError(t, err)
+	require.True(t, result.Auth.Enabled)
+	require.Equal(t, "secret", result.Auth.JWTHMACSecret)
+	require.Equal(t, "https://issuer.example.com", result.Auth.JWTIssuer)
+	require.Equal(t, map[string]string{"ci": "ci-key", "importer": "importer:key"}, result.Auth.APIKeys)
+	require.Equal(t, []string{"alice", "ci"}, result.Auth.Admins)
+
+	t.Setenv("AUTH_API_KEYS", "ci")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+
+	t.Setenv("AUTH_API_KEYS", "ci:one,ci:two")
+
+	_, err = NewConfig()
+	require.Error(t, err)
+}
@@ -0,0 +1,59 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TYPE notification_status as ENUM ('SCHEDULED', 'ENQUEUED');
+
+CREATE TABLE notification
+(
+    id         UUID PRIMARY KEY    DEFAULT uuid_generate_v4(),
+    patron_id  UUID                NOT NULL,
+    book_id    UUID                NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+    kind       INT                 NOT NULL,
+    due_at     TIMESTAMP,
+    send_at    TIMESTAMP           NOT NULL,
+    status     notification_status NOT NULL DEFAULT 'SCHEDULED',
+    created_at TIMESTAMP           DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP           DEFAULT now() NOT NULL
+);
+
+CREATE INDEX index_notification_send_at ON notification (send_at) WHERE status = 'SCHEDULED';
+
+CREATE TABLE notification_preferences
+(
+    patron_id   UUID PRIMARY KEY,
+    channels    INT[]                          NOT NULL,
+    email       TEXT                           NOT NULL DEFAULT '',
+    webhook_url TEXT                           NOT NULL DEFAULT '',
+    created_at  TIMESTAMP        DEFAULT now() NOT NULL,
+    updated_at  TIMESTAMP        DEFAULT now() NOT NULL
+);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_notification_timestamp() RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE OR REPLACE TRIGGER trigger_update_notification_timestamp
+    BEFORE UPDATE
+    ON notification
+    FOR EACH ROW
+EXECUTE FUNCTION update_notification_timestamp();
+
+CREATE OR REPLACE TRIGGER trigger_update_notification_preferences_timestamp
+    BEFORE UPDATE
+    ON notification_preferences
+    FOR EACH ROW
+EXECUTE FUNCTION update_notification_timestamp();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trigger_update_notification_preferences_timestamp ON notification_preferences;
+DROP TRIGGER IF EXISTS trigger_update_notification_timestamp ON notification;
+DROP FUNCTION IF EXISTS update_notification_timestamp;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notification;
+DROP TYPE IF EXISTS notification_status;
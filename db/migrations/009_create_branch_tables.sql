@@ -0,0 +1,97 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE branch
+(
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name       TEXT                           NOT NULL,
+    address    TEXT             DEFAULT ''    NOT NULL,
+    created_at TIMESTAMP        DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP        DEFAULT now() NOT NULL
+);
+
+CREATE TABLE patron
+(
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name           TEXT                           NOT NULL,
+    home_branch_id UUID                           NOT NULL REFERENCES branch (id),
+    created_at     TIMESTAMP        DEFAULT now() NOT NULL,
+    updated_at     TIMESTAMP        DEFAULT now() NOT NULL
+);
+
+CREATE TYPE copy_status as ENUM ('AVAILABLE', 'IN_TRANSIT');
+
+CREATE TABLE book_copy
+(
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    book_id    UUID                           NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+    branch_id  UUID                           NOT NULL REFERENCES branch (id),
+    status     copy_status      DEFAULT 'AVAILABLE' NOT NULL,
+    created_at TIMESTAMP        DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP        DEFAULT now() NOT NULL
+);
+
+CREATE INDEX index_book_copy_book_id_branch_id ON book_copy (book_id, branch_id);
+
+CREATE TYPE transfer_status as ENUM ('IN_TRANSIT', 'RECEIVED');
+
+CREATE TABLE transfer
+(
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    copy_id        UUID                           NOT NULL REFERENCES book_copy (id) ON DELETE CASCADE,
+    from_branch_id UUID                           NOT NULL REFERENCES branch (id),
+    to_branch_id   UUID                           NOT NULL REFERENCES branch (id),
+    status         transfer_status  DEFAULT 'IN_TRANSIT' NOT NULL,
+    received_at    TIMESTAMP,
+    created_at     TIMESTAMP        DEFAULT now() NOT NULL,
+    updated_at     TIMESTAMP        DEFAULT now() NOT NULL
+);
+
+CREATE INDEX index_transfer_copy_id ON transfer (copy_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_branch_timestamp() RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE OR REPLACE TRIGGER trigger_update_branch_timestamp
+    BEFORE UPDATE
+    ON branch
+    FOR EACH ROW
+EXECUTE FUNCTION update_branch_timestamp();
+
+CREATE OR REPLACE TRIGGER trigger_update_patron_timestamp
+    BEFORE UPDATE
+    ON patron
+    FOR EACH ROW
+EXECUTE FUNCTION update_branch_timestamp();
+
+CREATE OR REPLACE TRIGGER trigger_update_book_copy_timestamp
+    BEFORE UPDATE
+    ON book_copy
+    FOR EACH ROW
+EXECUTE FUNCTION update_branch_timestamp();
+
+CREATE OR REPLACE TRIGGER trigger_update_transfer_timestamp
+    BEFORE UPDATE
+    ON transfer
+    FOR EACH ROW
+EXECUTE FUNCTION update_branch_timestamp();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trigger_update_transfer_timestamp ON transfer;
+DROP TRIGGER IF EXISTS trigger_update_book_copy_timestamp ON book_copy;
+DROP TRIGGER IF EXISTS trigger_update_patron_timestamp ON patron;
+DROP TRIGGER IF EXISTS trigger_update_branch_timestamp ON branch;
+DROP FUNCTION IF EXISTS update_branch_timestamp;
+DROP TABLE IF EXISTS transfer;
+DROP TYPE IF EXISTS transfer_status;
+DROP TABLE IF EXISTS book_copy;
+DROP TYPE IF EXISTS copy_status;
+DROP TABLE IF EXISTS patron;
+DROP TABLE IF EXISTS branch;
@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+ALTER TABLE book
+    ADD COLUMN rating_sum   INT DEFAULT 0 NOT NULL,
+    ADD COLUMN rating_count INT DEFAULT 0 NOT NULL;
+
+CREATE TYPE review_status as ENUM ('PENDING', 'APPROVED', 'REJECTED');
+
+CREATE TABLE review
+(
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    book_id    UUID                           NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+    patron_id  UUID                           NOT NULL REFERENCES patron (id) ON DELETE CASCADE,
+    rating     SMALLINT                       NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    text       TEXT             DEFAULT ''    NOT NULL,
+    status     review_status    DEFAULT 'PENDING' NOT NULL,
+    created_at TIMESTAMP        DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP        DEFAULT now() NOT NULL,
+    UNIQUE (book_id, patron_id)
+);
+
+CREATE INDEX index_review_book_id_created_at ON review (book_id, created_at, id);
+CREATE INDEX index_review_patron_id_created_at ON review (patron_id, created_at, id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_review_timestamp() RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE OR REPLACE TRIGGER trigger_update_review_timestamp
+    BEFORE UPDATE
+    ON review
+    FOR EACH ROW
+EXECUTE FUNCTION update_review_timestamp();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trigger_update_review_timestamp ON review;
+DROP FUNCTION IF EXISTS update_review_timestamp;
+DROP TABLE IF EXISTS review;
+DROP TYPE IF EXISTS review_status;
+ALTER TABLE book
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS rating_sum;
@@ -0,0 +1,61 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TYPE collection_visibility as ENUM ('PRIVATE', 'PUBLIC');
+
+CREATE TABLE collection
+(
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner_id    UUID                  NOT NULL REFERENCES patron (id) ON DELETE CASCADE,
+    name        TEXT                  NOT NULL,
+    description TEXT                  DEFAULT '' NOT NULL,
+    visibility  collection_visibility NOT NULL,
+    created_at  TIMESTAMP             DEFAULT now() NOT NULL,
+    updated_at  TIMESTAMP             DEFAULT now() NOT NULL
+);
+
+CREATE INDEX index_collection_owner_id ON collection (owner_id);
+CREATE INDEX index_collection_created_at ON collection (created_at, id);
+
+CREATE TABLE collection_item
+(
+    collection_id UUID NOT NULL REFERENCES collection (id) ON DELETE CASCADE,
+    book_id       UUID NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+    position      INT  NOT NULL,
+    note          TEXT DEFAULT '' NOT NULL,
+    PRIMARY KEY (collection_id, book_id),
+    UNIQUE (collection_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE TABLE collection_share
+(
+    collection_id UUID NOT NULL REFERENCES collection (id) ON DELETE CASCADE,
+    patron_id     UUID NOT NULL REFERENCES patron (id) ON DELETE CASCADE,
+    PRIMARY KEY (collection_id, patron_id)
+);
+
+CREATE INDEX index_collection_share_patron_id ON collection_share (patron_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_collection_timestamp() RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE OR REPLACE TRIGGER trigger_update_collection_timestamp
+    BEFORE UPDATE
+    ON collection
+    FOR EACH ROW
+EXECUTE FUNCTION update_collection_timestamp();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trigger_update_collection_timestamp ON collection;
+DROP FUNCTION IF EXISTS update_collection_timestamp;
+DROP TABLE IF EXISTS collection_share;
+DROP TABLE IF EXISTS collection_item;
+DROP TABLE IF EXISTS collection;
+DROP TYPE IF EXISTS collection_visibility;
@@ -0,0 +1,47 @@
+-- +goose Up
+-- ADD VALUE runs in the migration transaction, which needs PostgreSQL 12 or newer;
+-- the new value is not used until the transaction is committed.
+ALTER TYPE copy_status ADD VALUE IF NOT EXISTS 'CHECKED_OUT';
+
+CREATE TABLE book_subject
+(
+    book_id UUID NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+    subject TEXT NOT NULL,
+    PRIMARY KEY (book_id, subject)
+);
+
+CREATE INDEX index_book_subject_subject ON book_subject (subject);
+
+CREATE TABLE loan
+(
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    copy_id     UUID      NOT NULL REFERENCES book_copy (id) ON DELETE CASCADE,
+    book_id     UUID      NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+    patron_id   UUID      NOT NULL REFERENCES patron (id) ON DELETE CASCADE,
+    borrowed_at TIMESTAMP DEFAULT now() NOT NULL,
+    returned_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX index_loan_copy_id_active ON loan (copy_id) WHERE returned_at IS NULL;
+CREATE INDEX index_loan_patron_id_book_id ON loan (patron_id, book_id);
+
+CREATE TABLE book_recommendation
+(
+    book_id             UUID             NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+    rank                INT              NOT NULL,
+    recommended_book_id UUID             NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+    score               DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (book_id, rank)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS book_recommendation;
+DROP TABLE IF EXISTS loan;
+DROP TABLE IF EXISTS book_subject;
+UPDATE book_copy SET status = 'AVAILABLE' WHERE status = 'CHECKED_OUT';
+ALTER TYPE copy_status RENAME TO copy_status_old;
+CREATE TYPE copy_status as ENUM ('AVAILABLE', 'IN_TRANSIT');
+ALTER TABLE book_copy ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE book_copy ALTER COLUMN status TYPE copy_status USING status::text::copy_status;
+ALTER TABLE book_copy ALTER COLUMN status SET DEFAULT 'AVAILABLE';
+DROP TYPE copy_status_old;
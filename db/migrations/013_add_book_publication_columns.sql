@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE book
+    ADD COLUMN isbn             TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN publisher        TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN publication_year INT  DEFAULT 0  NOT NULL;
+
+-- +goose Down
+ALTER TABLE book
+    DROP COLUMN IF EXISTS publication_year,
+    DROP COLUMN IF EXISTS publisher,
+    DROP COLUMN IF EXISTS isbn;
@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TYPE attachment_kind as ENUM ('COVER', 'FILE');
+
+CREATE TABLE book_attachment
+(
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    book_id       UUID                        NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+    kind          attachment_kind             NOT NULL,
+    name          TEXT                        NOT NULL,
+    content_type  TEXT                        NOT NULL,
+    size          BIGINT                      NOT NULL,
+    blob_key      TEXT                        NOT NULL,
+    thumbnail_key TEXT          DEFAULT ''    NOT NULL,
+    created_at    TIMESTAMP     DEFAULT now() NOT NULL
+);
+
+CREATE INDEX index_book_attachment_book_id ON book_attachment (book_id);
+CREATE UNIQUE INDEX index_book_attachment_cover ON book_attachment (book_id) WHERE kind = 'COVER';
+
+-- Blobs of deleted attachments, including the ones removed together with their book,
+-- are deleted by the outbox worker. Kind 5 is repository.OutboxKindBlob.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION delete_book_attachment_blobs() RETURNS TRIGGER AS
+$$
+BEGIN
+    INSERT INTO outbox (idempotency_key, data, status, kind)
+    VALUES ('blob_' || OLD.id,
+            jsonb_build_object('keys', array_remove(ARRAY [OLD.blob_key, OLD.thumbnail_key], '')),
+            'CREATED',
+            5)
+    ON CONFLICT (idempotency_key) DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE OR REPLACE TRIGGER trigger_delete_book_attachment_blobs
+    AFTER DELETE
+    ON book_attachment
+    FOR EACH ROW
+EXECUTE FUNCTION delete_book_attachment_blobs();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trigger_delete_book_attachment_blobs ON book_attachment;
+DROP FUNCTION IF EXISTS delete_book_attachment_blobs;
+DROP TABLE IF EXISTS book_attachment;
+DROP TYPE IF EXISTS attachment_kind;
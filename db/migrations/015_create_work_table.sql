@@ -0,0 +1,47 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- A work groups the editions of one title: translations, printings and so on.
+-- Every book row is an edition, a book without a work is an edition of its own.
+CREATE TABLE work
+(
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name       TEXT                    NOT NULL,
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP DEFAULT now() NOT NULL
+);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_work_timestamp() RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE OR REPLACE TRIGGER trigger_update_work_timestamp
+    BEFORE UPDATE
+    ON work
+    FOR EACH ROW
+EXECUTE FUNCTION update_work_timestamp();
+
+ALTER TABLE book
+    ADD COLUMN work_id    UUID REFERENCES work (id) ON DELETE SET NULL,
+    ADD COLUMN language   TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN translator TEXT DEFAULT '' NOT NULL;
+
+CREATE INDEX index_book_work_id ON book (work_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS index_book_work_id;
+
+ALTER TABLE book
+    DROP COLUMN IF EXISTS translator,
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS work_id;
+
+DROP TRIGGER IF EXISTS trigger_update_work_timestamp ON work;
+DROP FUNCTION IF EXISTS update_work_timestamp;
+DROP TABLE IF EXISTS work;
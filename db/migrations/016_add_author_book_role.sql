@@ -0,0 +1,19 @@
+-- +goose Up
+-- A person may take several parts in one book, e.g. write it and illustrate it,
+-- so the role is a part of the primary key. Existing links are authorships.
+ALTER TABLE author_book
+    ADD COLUMN role TEXT DEFAULT 'AUTHOR' NOT NULL;
+
+ALTER TABLE author_book
+    DROP CONSTRAINT author_book_pkey,
+    ADD PRIMARY KEY (author_id, book_id, role);
+
+-- +goose Down
+DELETE FROM author_book WHERE role <> 'AUTHOR';
+
+ALTER TABLE author_book
+    DROP CONSTRAINT author_book_pkey,
+    ADD PRIMARY KEY (author_id, book_id);
+
+ALTER TABLE author_book
+    DROP COLUMN IF EXISTS role;
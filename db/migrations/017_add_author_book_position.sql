@@ -0,0 +1,18 @@
+-- +goose Up
+-- The position keeps the order in which contributors were given, the first author goes first in citations.
+ALTER TABLE author_book
+    ADD COLUMN position INTEGER DEFAULT 0 NOT NULL;
+
+-- The order of existing links was never stored, authors are put first and the rest follow by id.
+UPDATE author_book
+SET position = numbered.position
+FROM (SELECT author_id, book_id, role,
+             row_number() OVER (PARTITION BY book_id ORDER BY role <> 'AUTHOR', author_id) - 1 AS position
+      FROM author_book) numbered
+WHERE author_book.author_id = numbered.author_id
+  AND author_book.book_id = numbered.book_id
+  AND author_book.role = numbered.role;
+
+-- +goose Down
+ALTER TABLE author_book
+    DROP COLUMN IF EXISTS position;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Responses of create requests made with an Idempotency-Key, a retry within the ttl gets the stored response.
+CREATE TABLE idempotency_key
+(
+    method       TEXT                    NOT NULL,
+    key          TEXT                    NOT NULL,
+    request_hash TEXT                    NOT NULL,
+    response     BYTEA                   NOT NULL,
+    created_at   TIMESTAMP DEFAULT now() NOT NULL,
+    expires_at   TIMESTAMP               NOT NULL,
+    PRIMARY KEY (method, key)
+);
+
+CREATE INDEX index_idempotency_key_expires_at ON idempotency_key (expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS index_idempotency_key_expires_at;
+DROP TABLE IF EXISTS idempotency_key;
@@ -0,0 +1,92 @@
+-- +goose Up
+-- Creates, updates and deletes of books and authors, written by triggers. A change gets its sequence
+-- only when every transaction that could write an earlier change is over, so sequences grow in
+-- commit order and a client resuming after a sequence misses nothing.
+CREATE TABLE catalog_change
+(
+    id         BIGSERIAL PRIMARY KEY,
+    sequence   BIGINT UNIQUE,
+    entity     TEXT                                  NOT NULL,
+    kind       TEXT                                  NOT NULL,
+    entity_id  UUID                                  NOT NULL,
+    txid       XID8      DEFAULT pg_current_xact_id() NOT NULL,
+    created_at TIMESTAMP DEFAULT now()               NOT NULL
+);
+
+CREATE INDEX index_catalog_change_unsequenced ON catalog_change (entity_id) WHERE sequence IS NULL;
+
+-- A client reads the current state of a changed entity, so one update per transaction is enough.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION add_catalog_change(change_entity TEXT, change_kind TEXT, change_entity_id UUID) RETURNS VOID AS
+$$
+BEGIN
+    IF change_kind = 'UPDATED' AND EXISTS (SELECT 1
+                                           FROM catalog_change
+                                           WHERE entity_id = change_entity_id
+                                             AND sequence IS NULL
+                                             AND txid = pg_current_xact_id()) THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO catalog_change (entity, kind, entity_id) VALUES (change_entity, change_kind, change_entity_id);
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_catalog_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM add_catalog_change(TG_ARGV[0], 'CREATED', NEW.id);
+    ELSIF TG_OP = 'UPDATE' THEN
+        PERFORM add_catalog_change(TG_ARGV[0], 'UPDATED', NEW.id);
+    ELSE
+        PERFORM add_catalog_change(TG_ARGV[0], 'DELETED', OLD.id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Links are a part of the book, the links removed together with their book are not an update.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_author_book_change() RETURNS TRIGGER AS
+$$
+DECLARE
+    changed_book_id UUID := CASE WHEN TG_OP = 'DELETE' THEN OLD.book_id ELSE NEW.book_id END;
+BEGIN
+    IF EXISTS (SELECT 1 FROM book WHERE id = changed_book_id) THEN
+        PERFORM add_catalog_change('BOOK', 'UPDATED', changed_book_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE OR REPLACE TRIGGER trigger_record_book_change
+    AFTER INSERT OR UPDATE OR DELETE
+    ON book
+    FOR EACH ROW
+EXECUTE FUNCTION record_catalog_change('BOOK');
+
+CREATE OR REPLACE TRIGGER trigger_record_author_change
+    AFTER INSERT OR UPDATE OR DELETE
+    ON author
+    FOR EACH ROW
+EXECUTE FUNCTION record_catalog_change('AUTHOR');
+
+CREATE OR REPLACE TRIGGER trigger_record_author_book_change
+    AFTER INSERT OR UPDATE OR DELETE
+    ON author_book
+    FOR EACH ROW
+EXECUTE FUNCTION record_author_book_change();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trigger_record_author_book_change ON author_book;
+DROP TRIGGER IF EXISTS trigger_record_author_change ON author;
+DROP TRIGGER IF EXISTS trigger_record_book_change ON book;
+DROP FUNCTION IF EXISTS record_author_book_change;
+DROP FUNCTION IF EXISTS record_catalog_change;
+DROP FUNCTION IF EXISTS add_catalog_change;
+DROP TABLE IF EXISTS catalog_change;
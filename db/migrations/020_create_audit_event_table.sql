@@ -0,0 +1,169 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- Every insert, update and delete of the library tables with the actor of the request,
+-- see repository.SetActor. Rows of link tables are recorded under the id of their parent.
+-- Triggers rather than the use cases record the events, so every write path (batches, imports,
+-- purges, background jobs) is covered in the transaction of the change itself.
+CREATE TABLE audit_event
+(
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor      TEXT                              NOT NULL,
+    entity     TEXT                              NOT NULL,
+    entity_id  TEXT                              NOT NULL,
+    action     TEXT                              NOT NULL,
+    before     JSONB,
+    after      JSONB,
+    created_at TIMESTAMP DEFAULT clock_timestamp() NOT NULL
+);
+
+CREATE INDEX index_audit_event_entity ON audit_event (entity, entity_id, created_at);
+CREATE INDEX index_audit_event_actor ON audit_event (actor, created_at);
+CREATE INDEX index_audit_event_created_at ON audit_event (created_at, id);
+
+-- An update keeps only the changed columns, an update of nothing but updated_at is not recorded.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_audit_event() RETURNS TRIGGER AS
+$$
+DECLARE
+    old_row   JSONB := CASE WHEN TG_OP = 'INSERT' THEN NULL ELSE to_jsonb(OLD) END;
+    new_row   JSONB := CASE WHEN TG_OP = 'DELETE' THEN NULL ELSE to_jsonb(NEW) END;
+    row_id    TEXT  := coalesce(new_row, old_row) ->> TG_ARGV[1];
+    operation TEXT  := CASE TG_OP WHEN 'INSERT' THEN 'CREATED' WHEN 'UPDATE' THEN 'UPDATED' ELSE 'DELETED' END;
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        SELECT jsonb_object_agg(before_column.key, before_column.value),
+               jsonb_object_agg(before_column.key, after_column.value)
+        INTO old_row, new_row
+        FROM jsonb_each(old_row) AS before_column
+                 JOIN jsonb_each(new_row) AS after_column ON after_column.key = before_column.key
+        WHERE before_column.value IS DISTINCT FROM after_column.value
+          AND before_column.key <> 'updated_at';
+
+        IF old_row IS NULL THEN
+            RETURN NULL;
+        END IF;
+    END IF;
+
+    INSERT INTO audit_event (actor, entity, entity_id, action, before, after)
+    VALUES (coalesce(current_setting('library.actor', true), ''), TG_ARGV[0], row_id, operation, old_row, new_row);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE OR REPLACE TRIGGER trigger_audit_author
+    AFTER INSERT OR UPDATE OR DELETE
+    ON author
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('author', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_book
+    AFTER INSERT OR UPDATE OR DELETE
+    ON book
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('book', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_author_book
+    AFTER INSERT OR UPDATE OR DELETE
+    ON author_book
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('author_book', 'book_id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_work
+    AFTER INSERT OR UPDATE OR DELETE
+    ON work
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('work', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_book_subject
+    AFTER INSERT OR UPDATE OR DELETE
+    ON book_subject
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('book_subject', 'book_id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_book_attachment
+    AFTER INSERT OR UPDATE OR DELETE
+    ON book_attachment
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('book_attachment', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_branch
+    AFTER INSERT OR UPDATE OR DELETE
+    ON branch
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('branch', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_patron
+    AFTER INSERT OR UPDATE OR DELETE
+    ON patron
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('patron', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_book_copy
+    AFTER INSERT OR UPDATE OR DELETE
+    ON book_copy
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('book_copy', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_transfer
+    AFTER INSERT OR UPDATE OR DELETE
+    ON transfer
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('transfer', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_loan
+    AFTER INSERT OR UPDATE OR DELETE
+    ON loan
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('loan', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_review
+    AFTER INSERT OR UPDATE OR DELETE
+    ON review
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('review', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_collection
+    AFTER INSERT OR UPDATE OR DELETE
+    ON collection
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('collection', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_collection_item
+    AFTER INSERT OR UPDATE OR DELETE
+    ON collection_item
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('collection_item', 'collection_id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_collection_share
+    AFTER INSERT OR UPDATE OR DELETE
+    ON collection_share
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('collection_share', 'collection_id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_notification_preferences
+    AFTER INSERT OR UPDATE OR DELETE
+    ON notification_preferences
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('notification_preferences', 'patron_id');
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trigger_audit_author ON author;
+DROP TRIGGER IF EXISTS trigger_audit_book ON book;
+DROP TRIGGER IF EXISTS trigger_audit_author_book ON author_book;
+DROP TRIGGER IF EXISTS trigger_audit_work ON work;
+DROP TRIGGER IF EXISTS trigger_audit_book_subject ON book_subject;
+DROP TRIGGER IF EXISTS trigger_audit_book_attachment ON book_attachment;
+DROP TRIGGER IF EXISTS trigger_audit_branch ON branch;
+DROP TRIGGER IF EXISTS trigger_audit_patron ON patron;
+DROP TRIGGER IF EXISTS trigger_audit_book_copy ON book_copy;
+DROP TRIGGER IF EXISTS trigger_audit_transfer ON transfer;
+DROP TRIGGER IF EXISTS trigger_audit_loan ON loan;
+DROP TRIGGER IF EXISTS trigger_audit_review ON review;
+DROP TRIGGER IF EXISTS trigger_audit_collection ON collection;
+DROP TRIGGER IF EXISTS trigger_audit_collection_item ON collection_item;
+DROP TRIGGER IF EXISTS trigger_audit_collection_share ON collection_share;
+DROP TRIGGER IF EXISTS trigger_audit_notification_preferences ON notification_preferences;
+DROP FUNCTION IF EXISTS record_audit_event;
+DROP TABLE IF EXISTS audit_event;
@@ -0,0 +1,158 @@
+-- +goose Up
+-- Every state a book, an author and a book contributor had, valid in [valid_from, valid_to).
+-- The current state has no valid_to, a deleted row has none. All the changes of a transaction
+-- share its start time, so a transaction never leaves a state visible in between.
+CREATE TABLE book_history
+(
+    book_id          UUID      NOT NULL,
+    name             TEXT      NOT NULL,
+    isbn             TEXT      NOT NULL,
+    publisher        TEXT      NOT NULL,
+    publication_year INT       NOT NULL,
+    work_id          UUID,
+    language         TEXT      NOT NULL,
+    translator       TEXT      NOT NULL,
+    valid_from       TIMESTAMP NOT NULL,
+    valid_to         TIMESTAMP,
+    PRIMARY KEY (book_id, valid_from)
+);
+
+CREATE TABLE author_history
+(
+    author_id  UUID      NOT NULL,
+    name       TEXT      NOT NULL,
+    valid_from TIMESTAMP NOT NULL,
+    valid_to   TIMESTAMP,
+    PRIMARY KEY (author_id, valid_from)
+);
+
+CREATE TABLE author_book_history
+(
+    author_id  UUID      NOT NULL,
+    book_id    UUID      NOT NULL,
+    role       TEXT      NOT NULL,
+    position   INTEGER   NOT NULL,
+    valid_from TIMESTAMP NOT NULL,
+    valid_to   TIMESTAMP,
+    PRIMARY KEY (book_id, author_id, role, valid_from)
+);
+
+-- Earlier changes were never recorded, the rows that exist now are taken as they were since their creation.
+INSERT INTO book_history (book_id, name, isbn, publisher, publication_year, work_id, language, translator, valid_from)
+SELECT id, name, isbn, publisher, publication_year, work_id, language, translator, created_at
+FROM book;
+
+INSERT INTO author_history (author_id, name, valid_from)
+SELECT id, name, created_at
+FROM author;
+
+INSERT INTO author_book_history (author_id, book_id, role, position, valid_from)
+SELECT author_book.author_id, author_book.book_id, author_book.role, author_book.position, book.created_at
+FROM author_book
+         JOIN book ON book.id = author_book.book_id;
+
+-- A change closes the current state and opens a new one. A state opened by the same transaction
+-- is dropped instead of closed, it was never visible to anyone else.
+-- Only the catalog columns are versioned, ratings and updated_at do not open a new state.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_book_history() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE' AND (OLD.name, OLD.isbn, OLD.publisher, OLD.publication_year, OLD.work_id, OLD.language, OLD.translator)
+        IS NOT DISTINCT FROM (NEW.name, NEW.isbn, NEW.publisher, NEW.publication_year, NEW.work_id, NEW.language, NEW.translator) THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP <> 'INSERT' THEN
+        DELETE FROM book_history WHERE book_id = OLD.id AND valid_to IS NULL AND valid_from = now();
+        UPDATE book_history SET valid_to = now() WHERE book_id = OLD.id AND valid_to IS NULL;
+    END IF;
+
+    IF TG_OP <> 'DELETE' THEN
+        INSERT INTO book_history (book_id, name, isbn, publisher, publication_year, work_id, language, translator, valid_from)
+        VALUES (NEW.id, NEW.name, NEW.isbn, NEW.publisher, NEW.publication_year, NEW.work_id, NEW.language, NEW.translator, now());
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_author_history() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.name IS NOT DISTINCT FROM NEW.name THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP <> 'INSERT' THEN
+        DELETE FROM author_history WHERE author_id = OLD.id AND valid_to IS NULL AND valid_from = now();
+        UPDATE author_history SET valid_to = now() WHERE author_id = OLD.id AND valid_to IS NULL;
+    END IF;
+
+    IF TG_OP <> 'DELETE' THEN
+        INSERT INTO author_history (author_id, name, valid_from)
+        VALUES (NEW.id, NEW.name, now());
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_author_book_history() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE' AND (OLD.author_id, OLD.book_id, OLD.role, OLD.position)
+        IS NOT DISTINCT FROM (NEW.author_id, NEW.book_id, NEW.role, NEW.position) THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP <> 'INSERT' THEN
+        DELETE FROM author_book_history
+        WHERE book_id = OLD.book_id AND author_id = OLD.author_id AND role = OLD.role
+          AND valid_to IS NULL AND valid_from = now();
+        UPDATE author_book_history SET valid_to = now()
+        WHERE book_id = OLD.book_id AND author_id = OLD.author_id AND role = OLD.role AND valid_to IS NULL;
+    END IF;
+
+    IF TG_OP <> 'DELETE' THEN
+        INSERT INTO author_book_history (author_id, book_id, role, position, valid_from)
+        VALUES (NEW.author_id, NEW.book_id, NEW.role, NEW.position, now());
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE OR REPLACE TRIGGER trigger_book_history
+    AFTER INSERT OR UPDATE OR DELETE
+    ON book
+    FOR EACH ROW
+EXECUTE FUNCTION record_book_history();
+
+CREATE OR REPLACE TRIGGER trigger_author_history
+    AFTER INSERT OR UPDATE OR DELETE
+    ON author
+    FOR EACH ROW
+EXECUTE FUNCTION record_author_history();
+
+CREATE OR REPLACE TRIGGER trigger_author_book_history
+    AFTER INSERT OR UPDATE OR DELETE
+    ON author_book
+    FOR EACH ROW
+EXECUTE FUNCTION record_author_book_history();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trigger_author_book_history ON author_book;
+DROP TRIGGER IF EXISTS trigger_author_history ON author;
+DROP TRIGGER IF EXISTS trigger_book_history ON book;
+DROP FUNCTION IF EXISTS record_author_book_history;
+DROP FUNCTION IF EXISTS record_author_history;
+DROP FUNCTION IF EXISTS record_book_history;
+DROP TABLE IF EXISTS author_book_history;
+DROP TABLE IF EXISTS author_history;
+DROP TABLE IF EXISTS book_history;
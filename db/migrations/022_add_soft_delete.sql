@@ -0,0 +1,223 @@
+-- +goose Up
+-- A deleted book or author stays in the trash until it is restored or purged after the retention period.
+-- Reads skip deleted rows, links to a deleted author are hidden from its books until the author is restored.
+ALTER TABLE book
+    ADD COLUMN deleted_at TIMESTAMP;
+
+ALTER TABLE author
+    ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX index_book_deleted_at ON book (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX index_author_deleted_at ON author (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- The links reads go through, a book keeps the links to a deleted author but does not show them.
+CREATE VIEW live_author_book AS
+SELECT author_book.author_id, author_book.book_id, author_book.role, author_book.position
+FROM author_book
+WHERE author_book.author_id IN (SELECT id FROM author WHERE deleted_at IS NULL);
+
+-- For the change feed a deleted row is gone and a restored one is created again,
+-- a purged row was already reported when it was deleted.
+-- Deleting or restoring an author changes the authors of its books.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_catalog_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM add_catalog_change(TG_ARGV[0], 'CREATED', NEW.id);
+    ELSIF TG_OP = 'DELETE' THEN
+        IF OLD.deleted_at IS NULL THEN
+            PERFORM add_catalog_change(TG_ARGV[0], 'DELETED', OLD.id);
+        END IF;
+    ELSIF (OLD.deleted_at IS NULL) <> (NEW.deleted_at IS NULL) THEN
+        PERFORM add_catalog_change(TG_ARGV[0], CASE WHEN NEW.deleted_at IS NULL THEN 'CREATED' ELSE 'DELETED' END, NEW.id);
+
+        IF TG_ARGV[0] = 'AUTHOR' THEN
+            PERFORM add_catalog_change('BOOK', 'UPDATED', book.id)
+            FROM book
+            WHERE book.deleted_at IS NULL
+              AND book.id IN (SELECT book_id FROM author_book WHERE author_id = NEW.id);
+        END IF;
+    ELSIF NEW.deleted_at IS NULL THEN
+        PERFORM add_catalog_change(TG_ARGV[0], 'UPDATED', NEW.id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_author_book_change() RETURNS TRIGGER AS
+$$
+DECLARE
+    changed_book_id   UUID := CASE WHEN TG_OP = 'DELETE' THEN OLD.book_id ELSE NEW.book_id END;
+    changed_author_id UUID := CASE WHEN TG_OP = 'DELETE' THEN OLD.author_id ELSE NEW.author_id END;
+BEGIN
+    IF EXISTS (SELECT 1 FROM book WHERE id = changed_book_id AND deleted_at IS NULL)
+        AND EXISTS (SELECT 1 FROM author WHERE id = changed_author_id AND deleted_at IS NULL) THEN
+        PERFORM add_catalog_change('BOOK', 'UPDATED', changed_book_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- The history has no states of deleted rows: a delete closes the current state and a restore opens a new one.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_book_history() RETURNS TRIGGER AS
+$$
+DECLARE
+    old_live BOOLEAN := CASE WHEN TG_OP = 'INSERT' THEN FALSE ELSE OLD.deleted_at IS NULL END;
+    new_live BOOLEAN := CASE WHEN TG_OP = 'DELETE' THEN FALSE ELSE NEW.deleted_at IS NULL END;
+BEGIN
+    IF old_live AND new_live
+        AND (OLD.name, OLD.isbn, OLD.publisher, OLD.publication_year, OLD.work_id, OLD.language, OLD.translator)
+        IS NOT DISTINCT FROM (NEW.name, NEW.isbn, NEW.publisher, NEW.publication_year, NEW.work_id, NEW.language, NEW.translator) THEN
+        RETURN NULL;
+    END IF;
+
+    IF old_live THEN
+        DELETE FROM book_history WHERE book_id = OLD.id AND valid_to IS NULL AND valid_from = now();
+        UPDATE book_history SET valid_to = now() WHERE book_id = OLD.id AND valid_to IS NULL;
+    END IF;
+
+    IF new_live THEN
+        INSERT INTO book_history (book_id, name, isbn, publisher, publication_year, work_id, language, translator, valid_from)
+        VALUES (NEW.id, NEW.name, NEW.isbn, NEW.publisher, NEW.publication_year, NEW.work_id, NEW.language, NEW.translator, now());
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- The links of a deleted author are hidden, so their states are closed with the author and opened again on restore.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_author_history() RETURNS TRIGGER AS
+$$
+DECLARE
+    old_live BOOLEAN := CASE WHEN TG_OP = 'INSERT' THEN FALSE ELSE OLD.deleted_at IS NULL END;
+    new_live BOOLEAN := CASE WHEN TG_OP = 'DELETE' THEN FALSE ELSE NEW.deleted_at IS NULL END;
+BEGIN
+    IF old_live AND new_live AND OLD.name IS NOT DISTINCT FROM NEW.name THEN
+        RETURN NULL;
+    END IF;
+
+    IF old_live THEN
+        DELETE FROM author_history WHERE author_id = OLD.id AND valid_to IS NULL AND valid_from = now();
+        UPDATE author_history SET valid_to = now() WHERE author_id = OLD.id AND valid_to IS NULL;
+    END IF;
+
+    IF old_live AND NOT new_live THEN
+        DELETE FROM author_book_history WHERE author_id = OLD.id AND valid_to IS NULL AND valid_from = now();
+        UPDATE author_book_history SET valid_to = now() WHERE author_id = OLD.id AND valid_to IS NULL;
+    END IF;
+
+    IF new_live THEN
+        INSERT INTO author_history (author_id, name, valid_from)
+        VALUES (NEW.id, NEW.name, now());
+    END IF;
+
+    IF new_live AND NOT old_live AND TG_OP = 'UPDATE' THEN
+        INSERT INTO author_book_history (author_id, book_id, role, position, valid_from)
+        SELECT author_id, book_id, role, position, now()
+        FROM author_book
+        WHERE author_id = NEW.id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+DELETE FROM book WHERE deleted_at IS NOT NULL;
+DELETE FROM author WHERE deleted_at IS NOT NULL;
+
+DROP VIEW IF EXISTS live_author_book;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_author_history() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.name IS NOT DISTINCT FROM NEW.name THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP <> 'INSERT' THEN
+        DELETE FROM author_history WHERE author_id = OLD.id AND valid_to IS NULL AND valid_from = now();
+        UPDATE author_history SET valid_to = now() WHERE author_id = OLD.id AND valid_to IS NULL;
+    END IF;
+
+    IF TG_OP <> 'DELETE' THEN
+        INSERT INTO author_history (author_id, name, valid_from)
+        VALUES (NEW.id, NEW.name, now());
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_book_history() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE' AND (OLD.name, OLD.isbn, OLD.publisher, OLD.publication_year, OLD.work_id, OLD.language, OLD.translator)
+        IS NOT DISTINCT FROM (NEW.name, NEW.isbn, NEW.publisher, NEW.publication_year, NEW.work_id, NEW.language, NEW.translator) THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP <> 'INSERT' THEN
+        DELETE FROM book_history WHERE book_id = OLD.id AND valid_to IS NULL AND valid_from = now();
+        UPDATE book_history SET valid_to = now() WHERE book_id = OLD.id AND valid_to IS NULL;
+    END IF;
+
+    IF TG_OP <> 'DELETE' THEN
+        INSERT INTO book_history (book_id, name, isbn, publisher, publication_year, work_id, language, translator, valid_from)
+        VALUES (NEW.id, NEW.name, NEW.isbn, NEW.publisher, NEW.publication_year, NEW.work_id, NEW.language, NEW.translator, now());
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_author_book_change() RETURNS TRIGGER AS
+$$
+DECLARE
+    changed_book_id UUID := CASE WHEN TG_OP = 'DELETE' THEN OLD.book_id ELSE NEW.book_id END;
+BEGIN
+    IF EXISTS (SELECT 1 FROM book WHERE id = changed_book_id) THEN
+        PERFORM add_catalog_change('BOOK', 'UPDATED', changed_book_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_catalog_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM add_catalog_change(TG_ARGV[0], 'CREATED', NEW.id);
+    ELSIF TG_OP = 'UPDATE' THEN
+        PERFORM add_catalog_change(TG_ARGV[0], 'UPDATED', NEW.id);
+    ELSE
+        PERFORM add_catalog_change(TG_ARGV[0], 'DELETED', OLD.id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS index_author_deleted_at;
+DROP INDEX IF EXISTS index_book_deleted_at;
+
+ALTER TABLE author
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE book
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Keys are chosen by clients, so they are scoped by the caller: two callers sending the same key
+-- must not get the responses of each other. Requests without an actor share the empty one.
+ALTER TABLE idempotency_key
+    ADD COLUMN actor TEXT DEFAULT '' NOT NULL;
+
+ALTER TABLE idempotency_key
+    DROP CONSTRAINT idempotency_key_pkey,
+    ADD PRIMARY KEY (actor, method, key);
+
+-- +goose Down
+-- The stored responses only live for the ttl, the ones that would collide are dropped.
+DELETE FROM idempotency_key WHERE actor <> '';
+
+ALTER TABLE idempotency_key
+    DROP CONSTRAINT idempotency_key_pkey,
+    ADD PRIMARY KEY (method, key);
+
+ALTER TABLE idempotency_key
+    DROP COLUMN IF EXISTS actor;
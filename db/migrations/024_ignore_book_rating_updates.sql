@@ -0,0 +1,84 @@
+-- +goose Up
+-- Rating aggregates live on the book row, but changing them is not a catalog change:
+-- it must not reach the change feed, the audit log or the book timestamp.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION book_rating_only_changed(old_row book, new_row book) RETURNS BOOLEAN AS
+$$
+SELECT (old_row.rating_sum, old_row.rating_count) IS DISTINCT FROM (new_row.rating_sum, new_row.rating_count)
+   AND to_jsonb(old_row) - ARRAY ['rating_sum', 'rating_count', 'updated_at']
+     = to_jsonb(new_row) - ARRAY ['rating_sum', 'rating_count', 'updated_at'];
+$$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS trigger_update_book_timestamp ON book;
+DROP TRIGGER IF EXISTS trigger_record_book_change ON book;
+DROP TRIGGER IF EXISTS trigger_audit_book ON book;
+
+CREATE OR REPLACE TRIGGER trigger_update_book_timestamp
+    BEFORE UPDATE
+    ON book
+    FOR EACH ROW
+    WHEN (NOT book_rating_only_changed(OLD, NEW))
+EXECUTE FUNCTION update_book_timestamp();
+
+CREATE OR REPLACE TRIGGER trigger_record_book_change
+    AFTER INSERT OR DELETE
+    ON book
+    FOR EACH ROW
+EXECUTE FUNCTION record_catalog_change('BOOK');
+
+CREATE OR REPLACE TRIGGER trigger_record_book_update
+    AFTER UPDATE
+    ON book
+    FOR EACH ROW
+    WHEN (NOT book_rating_only_changed(OLD, NEW))
+EXECUTE FUNCTION record_catalog_change('BOOK');
+
+CREATE OR REPLACE TRIGGER trigger_audit_book
+    AFTER INSERT OR DELETE
+    ON book
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('book', 'id');
+
+CREATE OR REPLACE TRIGGER trigger_audit_book_update
+    AFTER UPDATE
+    ON book
+    FOR EACH ROW
+    WHEN (NOT book_rating_only_changed(OLD, NEW))
+EXECUTE FUNCTION record_audit_event('book', 'id');
+
+-- Only approved reviews count in the rating from now on.
+UPDATE book
+SET rating_sum   = (SELECT coalesce(sum(rating), 0) FROM review WHERE review.book_id = book.id AND review.status = 'APPROVED'),
+    rating_count = (SELECT count(*) FROM review WHERE review.book_id = book.id AND review.status = 'APPROVED');
+
+-- +goose Down
+UPDATE book
+SET rating_sum   = (SELECT coalesce(sum(rating), 0) FROM review WHERE review.book_id = book.id AND review.status <> 'REJECTED'),
+    rating_count = (SELECT count(*) FROM review WHERE review.book_id = book.id AND review.status <> 'REJECTED');
+
+DROP TRIGGER IF EXISTS trigger_audit_book_update ON book;
+DROP TRIGGER IF EXISTS trigger_audit_book ON book;
+DROP TRIGGER IF EXISTS trigger_record_book_update ON book;
+DROP TRIGGER IF EXISTS trigger_record_book_change ON book;
+DROP TRIGGER IF EXISTS trigger_update_book_timestamp ON book;
+
+CREATE OR REPLACE TRIGGER trigger_update_book_timestamp
+    BEFORE UPDATE
+    ON book
+    FOR EACH ROW
+EXECUTE FUNCTION update_book_timestamp();
+
+CREATE OR REPLACE TRIGGER trigger_record_book_change
+    AFTER INSERT OR UPDATE OR DELETE
+    ON book
+    FOR EACH ROW
+EXECUTE FUNCTION record_catalog_change('BOOK');
+
+CREATE OR REPLACE TRIGGER trigger_audit_book
+    AFTER INSERT OR UPDATE OR DELETE
+    ON book
+    FOR EACH ROW
+EXECUTE FUNCTION record_audit_event('book', 'id');
+
+DROP FUNCTION IF EXISTS book_rating_only_changed;
@@ -0,0 +1,183 @@
+-- +goose Up
+-- Books whose recommendations have to be recomputed, written by triggers and consumed by
+-- repository.RefreshRecommendations, so a refresh only touches the books affected since the last one.
+-- There is no foreign key: the triggers also fire while a purged book is being removed.
+CREATE TABLE recommendation_stale
+(
+    book_id UUID PRIMARY KEY
+);
+
+-- every book is stale once, the first refresh after the migration rebuilds everything
+INSERT INTO recommendation_stale (book_id)
+SELECT id
+FROM book;
+
+CREATE INDEX index_loan_book_id_patron_id ON loan (book_id, patron_id);
+
+-- The neighbours of a book are the books sharing an author, a subject or a borrower with it.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION mark_recommendation_neighbours_stale(changed_book_id UUID) RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO recommendation_stale (book_id)
+    SELECT changed_book_id
+    UNION
+    SELECT other.book_id
+    FROM author_book origin
+             JOIN author_book other ON other.author_id = origin.author_id
+    WHERE origin.book_id = changed_book_id
+    UNION
+    SELECT other.book_id
+    FROM book_subject origin
+             JOIN book_subject other ON other.subject = origin.subject
+    WHERE origin.book_id = changed_book_id
+    UNION
+    SELECT other.book_id
+    FROM loan origin
+             JOIN loan other ON other.patron_id = origin.patron_id
+    WHERE origin.book_id = changed_book_id
+    ON CONFLICT DO NOTHING;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Only the first loan of a book by a patron makes it co-borrowed with the other books of the patron.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION mark_loan_recommendations_stale() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF EXISTS (SELECT 1 FROM loan WHERE patron_id = NEW.patron_id AND book_id = NEW.book_id AND id <> NEW.id) THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO recommendation_stale (book_id)
+    SELECT DISTINCT book_id
+    FROM loan
+    WHERE patron_id = NEW.patron_id
+    ON CONFLICT DO NOTHING;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION mark_subject_recommendations_stale() RETURNS TRIGGER AS
+$$
+DECLARE
+    changed_book_id UUID := CASE WHEN TG_OP = 'DELETE' THEN OLD.book_id ELSE NEW.book_id END;
+    changed_subject TEXT := CASE WHEN TG_OP = 'DELETE' THEN OLD.subject ELSE NEW.subject END;
+BEGIN
+    INSERT INTO recommendation_stale (book_id)
+    SELECT changed_book_id
+    UNION
+    SELECT book_id
+    FROM book_subject
+    WHERE subject = changed_subject
+    ON CONFLICT DO NOTHING;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Both the old and the new author of a link lose or gain a shared book.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION mark_author_book_recommendations_stale() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        INSERT INTO recommendation_stale (book_id)
+        SELECT OLD.book_id
+        UNION
+        SELECT book_id
+        FROM author_book
+        WHERE author_id = OLD.author_id
+        ON CONFLICT DO NOTHING;
+    END IF;
+
+    IF TG_OP <> 'DELETE' THEN
+        INSERT INTO recommendation_stale (book_id)
+        SELECT NEW.book_id
+        UNION
+        SELECT book_id
+        FROM author_book
+        WHERE author_id = NEW.author_id
+        ON CONFLICT DO NOTHING;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- A deleted or restored author leaves or rejoins the shared authors of its books.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION mark_author_recommendations_stale() RETURNS TRIGGER AS
+$$
+BEGIN
+    INSERT INTO recommendation_stale (book_id)
+    SELECT book_id
+    FROM author_book
+    WHERE author_id = NEW.id
+    ON CONFLICT DO NOTHING;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- A deleted book drops out of the recommendations of its neighbours, a restored one comes back.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION mark_book_recommendations_stale() RETURNS TRIGGER AS
+$$
+BEGIN
+    PERFORM mark_recommendation_neighbours_stale(NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE OR REPLACE TRIGGER trigger_loan_recommendations_stale
+    AFTER INSERT
+    ON loan
+    FOR EACH ROW
+EXECUTE FUNCTION mark_loan_recommendations_stale();
+
+CREATE OR REPLACE TRIGGER trigger_book_subject_recommendations_stale
+    AFTER INSERT OR DELETE
+    ON book_subject
+    FOR EACH ROW
+EXECUTE FUNCTION mark_subject_recommendations_stale();
+
+CREATE OR REPLACE TRIGGER trigger_author_book_recommendations_stale
+    AFTER INSERT OR UPDATE OR DELETE
+    ON author_book
+    FOR EACH ROW
+EXECUTE FUNCTION mark_author_book_recommendations_stale();
+
+CREATE OR REPLACE TRIGGER trigger_author_recommendations_stale
+    AFTER UPDATE OF deleted_at
+    ON author
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+EXECUTE FUNCTION mark_author_recommendations_stale();
+
+CREATE OR REPLACE TRIGGER trigger_book_recommendations_stale
+    AFTER UPDATE OF deleted_at
+    ON book
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+EXECUTE FUNCTION mark_book_recommendations_stale();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trigger_book_recommendations_stale ON book;
+DROP TRIGGER IF EXISTS trigger_author_recommendations_stale ON author;
+DROP TRIGGER IF EXISTS trigger_author_book_recommendations_stale ON author_book;
+DROP TRIGGER IF EXISTS trigger_book_subject_recommendations_stale ON book_subject;
+DROP TRIGGER IF EXISTS trigger_loan_recommendations_stale ON loan;
+DROP FUNCTION IF EXISTS mark_book_recommendations_stale;
+DROP FUNCTION IF EXISTS mark_author_recommendations_stale;
+DROP FUNCTION IF EXISTS mark_author_book_recommendations_stale;
+DROP FUNCTION IF EXISTS mark_subject_recommendations_stale;
+DROP FUNCTION IF EXISTS mark_loan_recommendations_stale;
+DROP FUNCTION IF EXISTS mark_recommendation_neighbours_stale;
+DROP INDEX IF EXISTS index_loan_book_id_patron_id;
+DROP TABLE IF EXISTS recommendation_stale;
@@ -20,8 +20,12 @@ import (
 	generated "github.com/project/library/generated/api/library"
 	"github.com/project/library/internal/controller"
 	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/gateway"
+	"github.com/project/library/internal/usecase/auth"
 	"github.com/project/library/internal/usecase/library"
+	"github.com/project/library/internal/usecase/notification"
 	"github.com/project/library/internal/usecase/outbox"
+	"github.com/project/library/internal/usecase/recommendation"
 	"github.com/project/library/internal/usecase/repository"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -46,7 +50,15 @@ func Run(logger *zap.Logger, cfg *config.Config) {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	dbPool, err := pgxpool.New(ctx, cfg.PG.URL)
+	poolConfig, err := pgxpool.ParseConfig(cfg.PG.URL)
+
+	if err != nil {
+		logger.Error("can not parse postgres url", zap.Error(err))
+		return
+	}
+
+	poolConfig.BeforeAcquire = repository.SetActor
+	dbPool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 
 	if err != nil {
 		logger.Error("can not create pgxpool", zap.Error(err))
@@ -59,31 +71,80 @@ func Run(logger *zap.Logger, cfg *config.Config) {
 
 	repo := repository.NewPostgresRepository(dbPool)
 	outboxRepository := repository.NewOutboxRepository(dbPool)
+	notificationRepository := repository.NewNotificationRepository(dbPool)
+	branchRepository := repository.NewBranchRepository(dbPool)
+	reviewRepository := repository.NewReviewRepository(dbPool)
+	collectionRepository := repository.NewCollectionRepository(dbPool)
+	recommendationRepository := repository.NewRecommendationRepository(dbPool)
+	attachmentRepository := repository.NewAttachmentRepository(dbPool)
+	idempotencyRepository := repository.NewIdempotencyRepository(dbPool)
+	changeRepository := repository.NewChangeRepository(dbPool)
+	auditRepository := repository.NewAuditRepository(dbPool)
+	historyRepository := repository.NewHistoryRepository(dbPool)
 
 	transactor := repository.NewTransactor(dbPool)
-	runOutbox(ctx, cfg, logger, outboxRepository, transactor)
+	client := newHTTPClient(nil)
+	blobStore := newBlobStore(cfg, client)
+
+	notifier, err := runNotifications(ctx, cfg, logger, notificationRepository, outboxRepository, repo, transactor)
+
+	if err != nil {
+		logger.Error("can not start notifications", zap.Error(err))
+		return
+	}
 
-	useCases := library.New(logger, repo, repo, outboxRepository, transactor)
+	runOutbox(ctx, cfg, logger, client, outboxRepository, transactor, notifier, blobStore)
+	runRecommendations(ctx, cfg, logger, recommendationRepository, transactor)
+	go runIdempotencyCleanup(ctx, cfg, logger, idempotencyRepository)
+	go runTrashPurge(ctx, cfg, logger, repo)
+
+	useCases := library.New(
+		logger,
+		repo,
+		repo,
+		outboxRepository,
+		notificationRepository,
+		branchRepository,
+		reviewRepository,
+		collectionRepository,
+		recommendationRepository,
+		repo,
+		attachmentRepository,
+		blobStore,
+		repo,
+		idempotencyRepository,
+		changeRepository,
+		auditRepository,
+		historyRepository,
+		repo,
+		transactor,
+		cfg.Notification.DaysBeforeDue,
+		cfg.Batch.MaxIDs,
+		cfg.Idempotency.TTLMS,
+		cfg.Watch.PollIntervalMS,
+	)
+
+	ctrl := controller.New(logger, useCases, useCases, useCases, useCases, useCases, useCases, useCases, useCases, useCases, useCases)
+
+	authenticator, err := newAuthenticator(cfg)
 
-	ctrl := controller.New(logger, useCases, useCases)
+	if err != nil {
+		logger.Error("can not create authenticator", zap.Error(err))
+		return
+	}
 
-	go runRest(ctx, cfg, logger)
-	go runGrpc(cfg, logger, ctrl)
+	go runRest(ctx, cfg, logger, gateway.NewOPDS(logger, repo, repo, authenticator))
+	go runGrpc(cfg, logger, ctrl, authenticator)
 
 	<-ctx.Done()
 	time.Sleep(gracefulShutdownTimeout)
 }
 
-func runOutbox(
-	ctx context.Context,
-	cfg *config.Config,
-	logger *zap.Logger,
-	outboxRepository repository.OutboxRepository,
-	transactor repository.Transactor,
-) {
+func newHTTPClient(control func(network, address string, conn syscall.RawConn) error) *http.Client {
 	dialer := &net.Dialer{
 		Timeout:   dialerTimeout,
 		KeepAlive: dialerKeepAlive,
+		Control:   control,
 	}
 
 	transport := &http.Transport{
@@ -100,7 +161,81 @@ func runOutbox(
 	client := new(http.Client)
 	client.Transport = transport
 
-	globalHandler := globalOutboxHandler(client, cfg)
+	return client
+}
+
+func newBlobStore(cfg *config.Config, client *http.Client) repository.BlobStore {
+	if cfg.Blob.Backend == config.BlobBackendS3 {
+		return repository.NewS3BlobStore(
+			client,
+			cfg.Blob.S3Endpoint,
+			cfg.Blob.S3Region,
+			cfg.Blob.S3Bucket,
+			cfg.Blob.S3AccessKey,
+			cfg.Blob.S3SecretKey,
+		)
+	}
+
+	return repository.NewLocalBlobStore(cfg.Blob.LocalDir)
+}
+
+func runNotifications(
+	ctx context.Context,
+	cfg *config.Config,
+	logger *zap.Logger,
+	notificationRepository repository.NotificationRepository,
+	outboxRepository repository.OutboxRepository,
+	bookRepository repository.BookRepository,
+	transactor repository.Transactor,
+) (notification.Notifier, error) {
+	templates, err := notification.LoadTemplates(cfg.Notification.TemplatesDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	channels := map[entity.NotificationChannel]notification.Channel{
+		entity.NotificationChannelWebhook: notification.NewWebhookChannel(newHTTPClient(notification.RefusePrivateAddress)),
+	}
+
+	if cfg.Notification.SMTPHost != "" {
+		channels[entity.NotificationChannelEmail] = notification.NewEmailChannel(
+			cfg.Notification.SMTPHost,
+			cfg.Notification.SMTPPort,
+			cfg.Notification.SMTPUser,
+			cfg.Notification.SMTPPassword,
+			cfg.Notification.SMTPFrom,
+		)
+	}
+
+	notifier := notification.New(
+		logger,
+		notificationRepository,
+		outboxRepository,
+		bookRepository,
+		transactor,
+		templates,
+		channels,
+	)
+
+	if cfg.Notification.Enabled {
+		_ = notifier.Start(ctx, cfg.Notification.BatchSize, cfg.Notification.WaitTimeMS)
+	}
+
+	return notifier, nil
+}
+
+func runOutbox(
+	ctx context.Context,
+	cfg *config.Config,
+	logger *zap.Logger,
+	client *http.Client,
+	outboxRepository repository.OutboxRepository,
+	transactor repository.Transactor,
+	notifier notification.Notifier,
+	blobStore repository.BlobStore,
+) {
+	globalHandler := globalOutboxHandler(client, cfg, notifier, blobStore)
 	outboxService := outbox.New(logger, outboxRepository, globalHandler, cfg, transactor)
 
 	_ = outboxService.Start(
@@ -112,9 +247,87 @@ func runOutbox(
 	)
 }
 
+func runRecommendations(
+	ctx context.Context,
+	cfg *config.Config,
+	logger *zap.Logger,
+	recommendationRepository repository.RecommendationRepository,
+	transactor repository.Transactor,
+) {
+	if !cfg.Recommendation.Enabled {
+		return
+	}
+
+	refresher := recommendation.New(
+		logger,
+		recommendationRepository,
+		transactor,
+		cfg.Recommendation.TopN,
+		recommendation.DefaultWeights,
+	)
+
+	_ = refresher.Start(ctx, cfg.Recommendation.RunAt)
+}
+
+// runIdempotencyCleanup deletes expired idempotency keys once per ttl, the keys are not replayed after it anyway.
+func runIdempotencyCleanup(
+	ctx context.Context,
+	cfg *config.Config,
+	logger *zap.Logger,
+	idempotencyRepository repository.IdempotencyRepository,
+) {
+	ticker := time.NewTicker(cfg.Idempotency.TTLMS)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := idempotencyRepository.DeleteExpiredIdempotencyRecords(ctx)
+
+			if err != nil {
+				logger.Error("cannot delete expired idempotency keys", zap.Error(err))
+				continue
+			}
+
+			logger.Info("expired idempotency keys deleted", zap.Int("count", deleted))
+		}
+	}
+}
+
+// runTrashPurge removes the books and authors that stayed in the trash longer than the retention period.
+func runTrashPurge(
+	ctx context.Context,
+	cfg *config.Config,
+	logger *zap.Logger,
+	trashRepository repository.TrashRepository,
+) {
+	ticker := time.NewTicker(cfg.Trash.PurgeIntervalMS)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := trashRepository.PurgeDeleted(ctx, cfg.Trash.RetentionMS)
+
+			if err != nil {
+				logger.Error("cannot purge trash", zap.Error(err))
+				continue
+			}
+
+			logger.Info("trash purged", zap.Int("count", purged))
+		}
+	}
+}
+
 func globalOutboxHandler(
 	client *http.Client,
 	cfg *config.Config,
+	notifier notification.Notifier,
+	blobStore repository.BlobStore,
 ) outbox.GlobalHandler {
 	return func(kind repository.OutboxKind) (outbox.KindHandler, error) {
 		switch kind {
@@ -122,6 +335,12 @@ func globalOutboxHandler(
 			return bookOutboxHandler(client, cfg.Outbox.BookSendURL), nil
 		case repository.OutboxKindAuthor:
 			return authorOutboxHandler(client, cfg.Outbox.AuthorSendURL), nil
+		case repository.OutboxKindNotification:
+			return notifier.Handle, nil
+		case repository.OutboxKindReview:
+			return reviewOutboxHandler(client, cfg.Outbox.ReviewSendURL), nil
+		case repository.OutboxKindBlob:
+			return blobOutboxHandler(blobStore), nil
 		default:
 			return nil, fmt.Errorf("unsupported outbox kind: %d", kind)
 		}
@@ -182,8 +401,56 @@ func authorOutboxHandler(client *http.Client, url string) outbox.KindHandler {
 	}
 }
 
-func runRest(ctx context.Context, cfg *config.Config, logger *zap.Logger) {
-	mux := grpcruntime.NewServeMux()
+func reviewOutboxHandler(client *http.Client, url string) outbox.KindHandler {
+	return func(_ context.Context, data []byte) error {
+		review := entity.Review{}
+		err := json.Unmarshal(data, &review)
+
+		if err != nil {
+			return fmt.Errorf("cannot deserialize data in review outbox handler: %w", err)
+		}
+
+		resp, err := client.Post(url, "text/plain", bytes.NewReader([]byte(review.ID)))
+
+		if err != nil {
+			return fmt.Errorf("cannot send request: %w", err)
+		}
+
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		return nil
+	}
+}
+
+// blobOutboxHandler deletes blobs of removed attachments, the messages are written by a trigger on book_attachment.
+func blobOutboxHandler(blobStore repository.BlobStore) outbox.KindHandler {
+	return func(ctx context.Context, data []byte) error {
+		var message struct {
+			Keys []string `json:"keys"`
+		}
+
+		if err := json.Unmarshal(data, &message); err != nil {
+			return fmt.Errorf("cannot deserialize data in blob outbox handler: %w", err)
+		}
+
+		for _, key := range message.Keys {
+			if err := blobStore.Delete(ctx, key); err != nil {
+				return fmt.Errorf("cannot delete blob %q: %w", key, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func runRest(ctx context.Context, cfg *config.Config, logger *zap.Logger, opds *gateway.OPDS) {
+	mux := grpcruntime.NewServeMux(grpcruntime.WithIncomingHeaderMatcher(gateway.IncomingHeaderMatcher))
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
 	address := "localhost:" + cfg.GRPC.Port
@@ -194,6 +461,52 @@ func runRest(ctx context.Context, cfg *config.Config, logger *zap.Logger) {
 		os.Exit(-1)
 	}
 
+	conn, err := grpc.NewClient(address, opts...)
+
+	if err != nil {
+		logger.Error("can not create grpc client", zap.Error(err))
+		os.Exit(-1)
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	client := generated.NewLibraryClient(conn)
+	err = mux.HandlePath(http.MethodGet, gateway.CatalogDownloadPath, gateway.CatalogDownload(client))
+
+	if err != nil {
+		logger.Error("can not register catalog download", zap.Error(err))
+		os.Exit(-1)
+	}
+
+	err = mux.HandlePath(http.MethodGet, gateway.AttachmentDownloadPath, gateway.AttachmentDownload(client))
+
+	if err != nil {
+		logger.Error("can not register attachment download", zap.Error(err))
+		os.Exit(-1)
+	}
+
+	err = mux.HandlePath(http.MethodGet, gateway.CatalogEventsPath, gateway.CatalogEvents(client))
+
+	if err != nil {
+		logger.Error("can not register catalog events", zap.Error(err))
+		os.Exit(-1)
+	}
+
+	webSocket := gateway.CatalogEventsWebSocket(client, cfg.GRPC.GatewayAllowedOrigins)
+	err = mux.HandlePath(http.MethodGet, gateway.CatalogEventsWebSocketPath, webSocket)
+
+	if err != nil {
+		logger.Error("can not register catalog events websocket", zap.Error(err))
+		os.Exit(-1)
+	}
+
+	if err = opds.Register(mux); err != nil {
+		logger.Error("can not register opds catalog", zap.Error(err))
+		os.Exit(-1)
+	}
+
 	gatewayPort := ":" + cfg.GRPC.GatewayPort
 	logger.Info("gateway listening at port", zap.String("port", gatewayPort))
 
@@ -202,7 +515,31 @@ func runRest(ctx context.Context, cfg *config.Config, logger *zap.Logger) {
 	}
 }
 
-func runGrpc(cfg *config.Config, logger *zap.Logger, libraryService generated.LibraryServer) {
+// newAuthenticator returns nil when authentication is off.
+func newAuthenticator(cfg *config.Config) (controller.Authenticator, error) {
+	if !cfg.Auth.Enabled {
+		return nil, nil
+	}
+
+	var jwks []byte
+	if cfg.Auth.JWKSFile != "" {
+		var err error
+		jwks, err = os.ReadFile(cfg.Auth.JWKSFile)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return auth.New(cfg.Auth.JWTHMACSecret, jwks, cfg.Auth.JWTIssuer, cfg.Auth.JWTAudience, cfg.Auth.APIKeys, cfg.Auth.Admins)
+}
+
+func runGrpc(
+	cfg *config.Config,
+	logger *zap.Logger,
+	libraryService generated.LibraryServer,
+	authenticator controller.Authenticator,
+) {
 	port := ":" + cfg.GRPC.Port
 	lis, err := net.Listen("tcp", port)
 
@@ -211,7 +548,20 @@ func runGrpc(cfg *config.Config, logger *zap.Logger, libraryService generated.Li
 		os.Exit(-1)
 	}
 
-	s := grpc.NewServer()
+	unaryInterceptors := []grpc.UnaryServerInterceptor{controller.ActorUnaryInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{controller.ActorStreamInterceptor}
+
+	if authenticator != nil {
+		unaryInterceptors = append(unaryInterceptors, controller.AuthUnaryInterceptor(authenticator))
+		streamInterceptors = append(streamInterceptors, controller.AuthStreamInterceptor(authenticator))
+	} else {
+		logger.Warn("authentication is disabled, every caller is trusted")
+	}
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
 	reflection.Register(s)
 
 	generated.RegisterLibraryServer(s, libraryService)
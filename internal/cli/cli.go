@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	generated "github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/usecase/catalog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func dial(addr string) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to %s: %w", addr, err)
+	}
+
+	return conn, nil
+}
+
+func defaultGRPCPort() string {
+	if port := os.Getenv("GRPC_PORT"); port != "" {
+		return port
+	}
+
+	return "9090"
+}
+
+func parseCatalogFormat(format string, path string) (generated.CatalogFormat, error) {
+	if format == "" {
+		format = filepath.Ext(path)
+	}
+
+	catalogFormat, err := catalog.FormatByName(format)
+
+	if err != nil {
+		return generated.CatalogFormat_CATALOG_FORMAT_UNSPECIFIED, fmt.Errorf("unknown catalog format %q, use -format", format)
+	}
+
+	return generated.CatalogFormat(catalogFormat), nil
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	generated "github.com/project/library/generated/api/library"
+)
+
+// Export runs the "export" subcommand: it downloads the given books as MARC records
+// or, without book ids, the whole catalog narrowed by filters, to a file or to stdout.
+func Export(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+
+	addr := flags.String("addr", "localhost:"+defaultGRPCPort(), "library grpc address")
+	format := flags.String("format", "", "export format: csv, jsonl, bibtex, ris, marc21 or marcxml, guessed from the output extension by default")
+	output := flags.String("o", "-", "output file, - for stdout")
+
+	filter := &generated.ExportCatalogRequest{}
+	flags.StringVar(&filter.AuthorId, "author-id", "", "export only books of the author")
+	flags.StringVar(&filter.NameQuery, "name", "", "export only books whose name contains the text")
+	flags.StringVar(&filter.Subject, "subject", "", "export only books with the subject")
+	yearFrom := flags.Int("year-from", 0, "export only books published in or after the year")
+	yearTo := flags.Int("year-to", 0, "export only books published in or before the year")
+	flags.BoolVar(&filter.CollapseWorks, "collapse-works", false, "export one edition per work")
+
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stdout, "usage: library export [flags] [book_id...]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *format == "" && *output == "-" {
+		*format = "csv"
+
+		if flags.NArg() > 0 {
+			*format = "marc21"
+		}
+	}
+
+	catalogFormat, err := parseCatalogFormat(*format, *output)
+
+	if err != nil {
+		return err
+	}
+
+	out := stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = file.Close()
+		}()
+
+		out = file
+	}
+
+	conn, err := dial(*addr)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	client := generated.NewLibraryClient(conn)
+
+	if flags.NArg() > 0 {
+		return exportMarc(ctx, client, catalogFormat, flags.Args(), out)
+	}
+
+	filter.Format = catalogFormat
+	filter.PublicationYearFrom = int32(*yearFrom)
+	filter.PublicationYearTo = int32(*yearTo)
+
+	return exportCatalog(ctx, client, filter, out)
+}
+
+func exportMarc(
+	ctx context.Context,
+	client generated.LibraryClient,
+	format generated.CatalogFormat,
+	bookIDs []string,
+	out io.Writer,
+) error {
+	stream, err := client.ExportMarc(ctx, &generated.ExportMarcRequest{
+		BookIds: bookIDs,
+		Format:  format,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return receiveChunks(stream.Recv, out)
+}
+
+func exportCatalog(ctx context.Context, client generated.LibraryClient, req *generated.ExportCatalogRequest, out io.Writer) error {
+	stream, err := client.ExportCatalog(ctx, req)
+
+	if err != nil {
+		return err
+	}
+
+	return receiveChunks(stream.Recv, out)
+}
+
+type chunk interface {
+	GetData() []byte
+}
+
+func receiveChunks[T chunk](recv func() (T, error), out io.Writer) error {
+	for {
+		resp, err := recv()
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if _, err = out.Write(resp.GetData()); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	generated "github.com/project/library/generated/api/library"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type exportClient struct {
+	generated.LibraryClient
+	request        *generated.ExportMarcRequest
+	catalogRequest *generated.ExportCatalogRequest
+	stream         *exportMarcStream
+}
+
+func (c *exportClient) ExportMarc(
+	_ context.Context,
+	req *generated.ExportMarcRequest,
+	_ ...grpc.CallOption,
+) (generated.Library_ExportMarcClient, error) {
+	c.request = req
+	return c.stream, nil
+}
+
+type exportMarcStream struct {
+	grpc.ClientStream
+	chunks [][]byte
+}
+
+func (s *exportMarcStream) Recv() (*generated.ExportMarcResponse, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+
+	return &generated.ExportMarcResponse{Data: chunk}, nil
+}
+
+func TestExportMarc(t *testing.T) {
+	t.Parallel()
+	client := &exportClient{
+		stream: &exportMarcStream{
+			chunks: [][]byte{[]byte("<collection>"), []byte("</collection>")},
+		},
+	}
+	var out bytes.Buffer
+
+	err := exportMarc(
+		context.Background(),
+		client,
+		generated.CatalogFormat_CATALOG_FORMAT_MARCXML,
+		[]string{"6b1f4ad2-3a5c-4c36-9d43-0c7b0d2a9f10"},
+		&out,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"6b1f4ad2-3a5c-4c36-9d43-0c7b0d2a9f10"}, client.request.GetBookIds())
+	require.Equal(t, generated.CatalogFormat_CATALOG_FORMAT_MARCXML, client.request.GetFormat())
+	require.Equal(t, "<collection></collection>", out.String())
+}
+
+func (c *exportClient) ExportCatalog(
+	_ context.Context,
+	req *generated.ExportCatalogRequest,
+	_ ...grpc.CallOption,
+) (generated.Library_ExportCatalogClient, error) {
+	c.catalogRequest = req
+	return &exportCatalogStream{exportMarcStream: c.stream}, nil
+}
+
+type exportCatalogStream struct {
+	*exportMarcStream
+}
+
+func (s *exportCatalogStream) Recv() (*generated.ExportCatalogResponse, error) {
+	resp, err := s.exportMarcStream.Recv()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &generated.ExportCatalogResponse{Data: resp.GetData()}, nil
+}
+
+func TestExportCatalog(t *testing.T) {
+	t.Parallel()
+	client := &exportClient{
+		stream: &exportMarcStream{
+			chunks: [][]byte{[]byte("id,name\n"), []byte("1,Dune\n")},
+		},
+	}
+	var out bytes.Buffer
+
+	err := exportCatalog(context.Background(), client, &generated.ExportCatalogRequest{
+		Format:  generated.CatalogFormat_CATALOG_FORMAT_CSV,
+		Subject: "fantasy",
+	}, &out)
+	require.NoError(t, err)
+	require.Equal(t, "fantasy", client.catalogRequest.GetSubject())
+	require.Equal(t, "id,name\n1,Dune\n", out.String())
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	generated "github.com/project/library/generated/api/library"
+)
+
+const importChunkSize = 64 * 1024
+
+// Import runs the "import" subcommand: it streams a catalog file
+// to the ImportCatalog rpc and prints the summary with the rejected rows.
+func Import(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+
+	addr := flags.String("addr", "localhost:"+defaultGRPCPort(), "library grpc address")
+	format := flags.String("format", "", "catalog format: csv, jsonl, marc21 or marcxml, guessed from the file extension by default")
+	dryRun := flags.Bool("dry-run", false, "validate the catalog without importing it")
+
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stdout, "usage: library import [flags] <file|->")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("catalog file is required")
+	}
+
+	path := flags.Arg(0)
+	catalogFormat, err := parseCatalogFormat(*format, path)
+
+	if err != nil {
+		return err
+	}
+
+	input := stdin
+	if path != "-" {
+		file, err := os.Open(path)
+
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = file.Close()
+		}()
+
+		input = file
+	}
+
+	conn, err := dial(*addr)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	return importCatalog(ctx, generated.NewLibraryClient(conn), catalogFormat, *dryRun, input, stdout)
+}
+
+func importCatalog(
+	ctx context.Context,
+	client generated.LibraryClient,
+	format generated.CatalogFormat,
+	dryRun bool,
+	input io.Reader,
+	stdout io.Writer,
+) error {
+	stream, err := client.ImportCatalog(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	err = sendCatalog(stream, format, dryRun, input)
+
+	// io.EOF means the server has already failed the stream, the reason comes with CloseAndRecv
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	resp, err := stream.CloseAndRecv()
+
+	if err != nil {
+		return err
+	}
+
+	for _, rowError := range resp.GetErrors() {
+		_, _ = fmt.Fprintf(stdout, "line %d: %s\n", rowError.GetLine(), rowError.GetMessage())
+	}
+
+	summary := "imported"
+	if resp.GetDryRun() {
+		summary = "would import"
+	}
+
+	_, err = fmt.Fprintf(stdout, "%s %d of %d books, %d new authors, %d rejected rows\n",
+		summary, resp.GetImportedBooks(), resp.GetTotalRows(), resp.GetCreatedAuthors(), len(resp.GetErrors()))
+
+	return err
+}
+
+func sendCatalog(
+	stream generated.Library_ImportCatalogClient,
+	format generated.CatalogFormat,
+	dryRun bool,
+	input io.Reader,
+) error {
+	req := &generated.ImportCatalogRequest{
+		Format: format,
+		DryRun: dryRun,
+	}
+
+	buf := make([]byte, importChunkSize)
+	for {
+		n, err := input.Read(buf)
+
+		if n > 0 {
+			req.Data = buf[:n]
+
+			if err := stream.Send(req); err != nil {
+				return err
+			}
+
+			req = &generated.ImportCatalogRequest{}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("cannot read catalog: %w", err)
+		}
+	}
+
+	// an empty input still has to carry the format
+	if req.GetFormat() != generated.CatalogFormat_CATALOG_FORMAT_UNSPECIFIED {
+		return stream.Send(req)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	generated "github.com/project/library/generated/api/library"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type importCatalogClient struct {
+	generated.LibraryClient
+	stream *importCatalogStream
+}
+
+func (c *importCatalogClient) ImportCatalog(_ context.Context, _ ...grpc.CallOption) (generated.Library_ImportCatalogClient, error) {
+	return c.stream, nil
+}
+
+type importCatalogStream struct {
+	grpc.ClientStream
+	requests []*generated.ImportCatalogRequest
+	response *generated.ImportCatalogResponse
+}
+
+func (s *importCatalogStream) Send(req *generated.ImportCatalogRequest) error {
+	s.requests = append(s.requests, &generated.ImportCatalogRequest{
+		Format: req.GetFormat(),
+		DryRun: req.GetDryRun(),
+		Data:   bytes.Clone(req.GetData()),
+	})
+
+	return nil
+}
+
+func (s *importCatalogStream) CloseAndRecv() (*generated.ImportCatalogResponse, error) {
+	return s.response, nil
+}
+
+func TestParseCatalogFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := parseCatalogFormat("", "books.CSV")
+	require.NoError(t, err)
+	require.Equal(t, generated.CatalogFormat_CATALOG_FORMAT_CSV, format)
+
+	format, err = parseCatalogFormat("jsonl", "-")
+	require.NoError(t, err)
+	require.Equal(t, generated.CatalogFormat_CATALOG_FORMAT_JSONL, format)
+
+	_, err = parseCatalogFormat("", "books.txt")
+	require.Error(t, err)
+}
+
+func TestImportCatalog(t *testing.T) {
+	t.Parallel()
+	stream := &importCatalogStream{
+		response: &generated.ImportCatalogResponse{
+			TotalRows:      3,
+			ImportedBooks:  2,
+			CreatedAuthors: 1,
+			DryRun:         true,
+			Errors: []*generated.ImportRowError{
+				{Line: 4, Message: "book name is empty"},
+			},
+		},
+	}
+	input := strings.Repeat("a", importChunkSize+1)
+	var out bytes.Buffer
+
+	err := importCatalog(
+		context.Background(),
+		&importCatalogClient{stream: stream},
+		generated.CatalogFormat_CATALOG_FORMAT_CSV,
+		true,
+		strings.NewReader(input),
+		&out,
+	)
+	require.NoError(t, err)
+
+	require.Len(t, stream.requests, 2)
+	require.Equal(t, generated.CatalogFormat_CATALOG_FORMAT_CSV, stream.requests[0].GetFormat())
+	require.True(t, stream.requests[0].GetDryRun())
+	require.Len(t, stream.requests[1].GetData(), 1)
+	require.Equal(t, "line 4: book name is empty\nwould import 2 of 3 books, 1 new authors, 1 rejected rows\n", out.String())
+}
+
+func TestImportCatalogEmptyInput(t *testing.T) {
+	t.Parallel()
+	stream := &importCatalogStream{response: &generated.ImportCatalogResponse{}}
+
+	err := importCatalog(
+		context.Background(),
+		&importCatalogClient{stream: stream},
+		generated.CatalogFormat_CATALOG_FORMAT_JSONL,
+		false,
+		strings.NewReader(""),
+		io.Discard,
+	)
+	require.NoError(t, err)
+	require.Len(t, stream.requests, 1)
+	require.Equal(t, generated.CatalogFormat_CATALOG_FORMAT_JSONL, stream.requests[0].GetFormat())
+}
+
+func TestParseCatalogFormatMARC(t *testing.T) {
+	t.Parallel()
+
+	format, err := parseCatalogFormat("", "records.mrc")
+	require.NoError(t, err)
+	require.Equal(t, generated.CatalogFormat_CATALOG_FORMAT_MARC21, format)
+
+	format, err = parseCatalogFormat("", "records.xml")
+	require.NoError(t, err)
+	require.Equal(t, generated.CatalogFormat_CATALOG_FORMAT_MARCXML, format)
+}
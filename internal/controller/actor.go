@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// actorHeader is the metadata key of the caller recorded in the audit log,
+// the gateway forwards the X-Actor http header under it.
+const actorHeader = "x-actor"
+
+const maxActorLength = 255
+
+// unverifiedActorPrefix marks the actors named by the caller itself. Anyone can send the header,
+// so the audit log keeps such actors apart from the authenticated principals,
+// which replace the header once authentication is on, see authContext.
+const unverifiedActorPrefix = "unverified:"
+
+// actorContext stores the actor of the incoming metadata in ctx, see entity.WithActor.
+func actorContext(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(actorHeader)
+
+	if len(values) == 0 || values[0] == "" {
+		return ctx, nil
+	}
+
+	if len(values[0]) > maxActorLength {
+		return nil, status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", actorHeader, maxActorLength)
+	}
+
+	return entity.WithActor(ctx, unverifiedActorPrefix+values[0]), nil
+}
+
+func ActorUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := actorContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func ActorStreamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := actorContext(stream.Context())
+
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &actorServerStream{ServerStream: stream, ctx: ctx})
+}
+
+// actorServerStream replaces the context of a stream with the one carrying the actor and the principal.
+type actorServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorServerStream) Context() context.Context {
+	return s.ctx
+}
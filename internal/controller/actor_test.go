@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestActorInterceptors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		md            metadata.MD
+		expectedActor string
+		expectedCode  codes.Code
+	}{
+		{
+			name:          "no actor",
+			md:            metadata.MD{},
+			expectedActor: "",
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "actor",
+			md:            metadata.Pairs(actorHeader, "librarian@example.com"),
+			expectedActor: "unverified:librarian@example.com",
+			expectedCode:  codes.OK,
+		},
+		{
+			name:         "actor too long",
+			md:           metadata.Pairs(actorHeader, strings.Repeat("a", maxActorLength+1)),
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			var unaryActor string
+			_, err := ActorUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+				unaryActor = entity.ActorFromContext(ctx)
+				return nil, nil
+			})
+			require.Equal(t, tt.expectedCode, status.Code(err))
+
+			var streamActor string
+			err = ActorStreamInterceptor(nil, &contextServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(_ any, stream grpc.ServerStream) error {
+				streamActor = entity.ActorFromContext(stream.Context())
+				return nil
+			})
+			require.Equal(t, tt.expectedCode, status.Code(err))
+
+			require.Equal(t, tt.expectedActor, unaryActor)
+			require.Equal(t, tt.expectedActor, streamActor)
+		})
+	}
+}
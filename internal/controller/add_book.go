@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -13,7 +14,13 @@ func (i *implementation) AddBook(ctx context.Context, req *library.AddBookReques
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	response, err := i.booksUseCase.RegisterBook(ctx, req.GetName(), req.GetAuthorIds())
+	key, err := idempotencyKey(ctx, "AddBook", req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.booksUseCase.RegisterBook(ctx, req.GetName(), req.GetAuthorIds(), convertContributors(req.GetContributors()), key)
 
 	if err != nil {
 		return nil, i.convertError(err)
@@ -21,3 +28,15 @@ func (i *implementation) AddBook(ctx context.Context, req *library.AddBookReques
 
 	return response, nil
 }
+
+func convertContributors(contributors []*library.Contributor) []entity.Contributor {
+	result := make([]entity.Contributor, len(contributors))
+	for i, contributor := range contributors {
+		result[i] = entity.Contributor{
+			AuthorID: contributor.GetAuthorId(),
+			Role:     entity.ContributorRole(contributor.GetRole()),
+		}
+	}
+
+	return result
+}
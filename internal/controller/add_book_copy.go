@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) AddBookCopy(ctx context.Context, req *library.AddBookCopyRequest) (*library.AddBookCopyResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	key, err := idempotencyKey(ctx, "AddBookCopy", req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.branchUseCase.AddBookCopy(ctx, req.GetBookId(), req.GetBranchId(), key)
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerAddBookCopy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookCopy := &library.BookCopy{
+		Id:       uuid.New().String(),
+		BookId:   uuid.New().String(),
+		BranchId: uuid.New().String(),
+		Status:   library.CopyStatus_COPY_STATUS_AVAILABLE,
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBranchUseCase)
+		bookID       string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid book id",
+			prepare:      emptyBranchUseCasePrepare,
+			bookID:       "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book does not exist",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().AddBookCopy(ctx, bookCopy.GetBookId(), bookCopy.GetBranchId(), entity.IdempotencyKey{}).Return(nil, entity.ErrBookNotFound)
+			},
+			bookID:       bookCopy.GetBookId(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().AddBookCopy(ctx, bookCopy.GetBookId(), bookCopy.GetBranchId(), entity.IdempotencyKey{}).Return(&library.AddBookCopyResponse{
+					Copy: bookCopy,
+				}, nil)
+			},
+			bookID:       bookCopy.GetBookId(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.branchUseCase)
+
+			result, err := data.impl.AddBookCopy(ctx, &library.AddBookCopyRequest{
+				BookId:   tt.bookID,
+				BranchId: bookCopy.GetBranchId(),
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, bookCopy.GetId(), result.GetCopy().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -51,10 +51,24 @@ func TestControllerAddBook(t *testing.T) {
 			expectedCode: codes.InvalidArgument,
 			noError:      false,
 		},
+		{
+			name:    "invalid contributor id",
+			prepare: emptyBookUseCasePrepare,
+			book: &library.Book{
+				Id:       book.GetId(),
+				Name:     book.GetName(),
+				AuthorId: book.GetAuthorId(),
+				Contributors: []*library.Contributor{
+					{AuthorId: "some invalid uuid", Role: library.ContributorRole_CONTRIBUTOR_ROLE_EDITOR},
+				},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
 		{
 			name: "author does not exist",
 			prepare: func(mock *mocks.MockBookUseCase) {
-				mock.EXPECT().RegisterBook(ctx, book.GetName(), book.GetAuthorId()).Return(nil, entity.ErrAuthorNotFound)
+				mock.EXPECT().RegisterBook(ctx, book.GetName(), book.GetAuthorId(), []entity.Contributor{}, entity.IdempotencyKey{}).Return(nil, entity.ErrAuthorNotFound)
 			},
 			book:         book,
 			expectedCode: codes.NotFound,
@@ -63,7 +77,7 @@ func TestControllerAddBook(t *testing.T) {
 		{
 			name: "success",
 			prepare: func(mock *mocks.MockBookUseCase) {
-				mock.EXPECT().RegisterBook(ctx, book.GetName(), book.GetAuthorId()).Return(&library.AddBookResponse{
+				mock.EXPECT().RegisterBook(ctx, book.GetName(), book.GetAuthorId(), []entity.Contributor{}, entity.IdempotencyKey{}).Return(&library.AddBookResponse{
 					Book: book,
 				}, nil)
 			},
@@ -80,8 +94,9 @@ func TestControllerAddBook(t *testing.T) {
 			tt.prepare(data.bookUseCase)
 
 			result, err := data.impl.AddBook(ctx, &library.AddBookRequest{
-				Name:      tt.book.GetName(),
-				AuthorIds: tt.book.GetAuthorId(),
+				Name:         tt.book.GetName(),
+				AuthorIds:    tt.book.GetAuthorId(),
+				Contributors: tt.book.GetContributors(),
 			})
 			if tt.noError {
 				require.NoError(t, err)
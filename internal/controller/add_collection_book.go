@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) AddCollectionBook(ctx context.Context, req *library.AddCollectionBookRequest) (*library.AddCollectionBookResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := requirePatron(ctx, req.GetOwnerId()); err != nil {
+		return nil, err
+	}
+
+	response, err := i.collectionUseCase.AddCollectionBook(ctx, req.GetCollectionId(), req.GetOwnerId(), req.GetBookId(), req.GetNote())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) AddReview(ctx context.Context, req *library.AddReviewRequest) (*library.AddReviewResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	key, err := idempotencyKey(ctx, "AddReview", req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.reviewUseCase.AddReview(ctx, req.GetPatronId(), req.GetBookId(), int(req.GetRating()), req.GetText(), key)
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
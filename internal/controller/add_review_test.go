@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerAddReview(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	review := &library.Review{
+		Id:       uuid.New().String(),
+		BookId:   uuid.New().String(),
+		PatronId: uuid.New().String(),
+		Rating:   4,
+		Text:     "good book",
+		Status:   library.ReviewStatus_REVIEW_STATUS_PENDING,
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockReviewUseCase)
+		bookID       string
+		rating       int32
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid book id",
+			prepare:      emptyReviewUseCasePrepare,
+			bookID:       "some invalid uuid",
+			rating:       review.GetRating(),
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "rating out of range",
+			prepare:      emptyReviewUseCasePrepare,
+			bookID:       review.GetBookId(),
+			rating:       6,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book not found",
+			prepare: func(mock *mocks.MockReviewUseCase) {
+				mock.EXPECT().AddReview(ctx, review.GetPatronId(), review.GetBookId(), 4, review.GetText(), entity.IdempotencyKey{}).
+					Return(nil, entity.ErrBookNotFound)
+			},
+			bookID:       review.GetBookId(),
+			rating:       review.GetRating(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "review already exists",
+			prepare: func(mock *mocks.MockReviewUseCase) {
+				mock.EXPECT().AddReview(ctx, review.GetPatronId(), review.GetBookId(), 4, review.GetText(), entity.IdempotencyKey{}).
+					Return(nil, entity.ErrReviewAlreadyExists)
+			},
+			bookID:       review.GetBookId(),
+			rating:       review.GetRating(),
+			expectedCode: codes.AlreadyExists,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockReviewUseCase) {
+				mock.EXPECT().AddReview(ctx, review.GetPatronId(), review.GetBookId(), 4, review.GetText(), entity.IdempotencyKey{}).
+					Return(&library.AddReviewResponse{Review: review}, nil)
+			},
+			bookID:       review.GetBookId(),
+			rating:       review.GetRating(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.reviewUseCase)
+
+			result, err := data.impl.AddReview(ctx, &library.AddReviewRequest{
+				PatronId: review.GetPatronId(),
+				BookId:   tt.bookID,
+				Rating:   tt.rating,
+				Text:     review.GetText(),
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, review.GetId(), result.GetReview().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationHeader is the metadata key of the credentials, the gateway forwards the Authorization http header under it.
+const authorizationHeader = "authorization"
+
+// publicMethodPrefix marks the grpc reflection service, it describes the api and is served without credentials.
+const publicMethodPrefix = "/grpc.reflection."
+
+type Authenticator interface {
+	Authenticate(authorization string) (entity.Principal, error)
+}
+
+// authContext authenticates the incoming metadata and stores the principal in ctx.
+// The principal is the actor of the audit log, the x-actor header can not override it.
+func authContext(ctx context.Context, authenticator Authenticator, method string) (context.Context, error) {
+	if strings.HasPrefix(method, publicMethodPrefix) {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationHeader)
+
+	if len(values) == 0 || values[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	principal, err := authenticator.Authenticate(values[0])
+
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	ctx = entity.WithPrincipal(ctx, principal)
+
+	return entity.WithActor(ctx, principal.Subject), nil
+}
+
+// requireAdmin rejects the callers who are not admins once authentication is on.
+// Without authentication there are no principals, and the admin calls are open to everyone.
+func requireAdmin(ctx context.Context, what string) error {
+	principal, ok := entity.PrincipalFromContext(ctx)
+
+	if ok && !principal.Admin {
+		return status.Errorf(codes.PermissionDenied, "%s is allowed to admins only", what)
+	}
+
+	return nil
+}
+
+// requirePatron rejects the callers acting for another patron once authentication is on:
+// the subject of a JWT or the name of an API key must be the patron id. Admins may act for any patron.
+func requirePatron(ctx context.Context, patronID string) error {
+	principal, ok := entity.PrincipalFromContext(ctx)
+
+	if !ok || principal.Admin || patronID == "" || principal.Subject == patronID {
+		return nil
+	}
+
+	return status.Error(codes.PermissionDenied, "the caller can not act for another patron")
+}
+
+// AuthUnaryInterceptor rejects the calls without valid credentials, it goes after ActorUnaryInterceptor.
+func AuthUnaryInterceptor(authenticator Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authContext(ctx, authenticator, info.FullMethod)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor rejects the streams without valid credentials, it goes after ActorStreamInterceptor.
+func AuthStreamInterceptor(authenticator Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authContext(stream.Context(), authenticator, info.FullMethod)
+
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &actorServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type staticAuthenticator map[string]entity.Principal
+
+func (a staticAuthenticator) Authenticate(authorization string) (entity.Principal, error) {
+	principal, ok := a[authorization]
+
+	if !ok {
+		return entity.Principal{}, fmt.Errorf("%w: unknown credentials", entity.ErrUnauthenticated)
+	}
+
+	return principal, nil
+}
+
+func TestAuthInterceptors(t *testing.T) {
+	t.Parallel()
+
+	principal := entity.Principal{Subject: "alice", Method: entity.AuthMethodJWT}
+	authenticator := staticAuthenticator{"Bearer token": principal}
+
+	tests := []struct {
+		name              string
+		md                metadata.MD
+		method            string
+		expectedPrincipal entity.Principal
+		expectedActor     string
+		expectedCode      codes.Code
+	}{
+		{
+			name:              "valid credentials",
+			md:                metadata.Pairs(authorizationHeader, "Bearer token"),
+			method:            "/library.Library/ChangeAuthorInfo",
+			expectedPrincipal: principal,
+			expectedActor:     "alice",
+			expectedCode:      codes.OK,
+		},
+		{
+			name:              "principal overrides the actor header",
+			md:                metadata.Pairs(authorizationHeader, "Bearer token", actorHeader, "mallory"),
+			method:            "/library.Library/ChangeAuthorInfo",
+			expectedPrincipal: principal,
+			expectedActor:     "alice",
+			expectedCode:      codes.OK,
+		},
+		{
+			name:         "missing credentials",
+			md:           metadata.MD{},
+			method:       "/library.Library/ChangeAuthorInfo",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "invalid credentials",
+			md:           metadata.Pairs(authorizationHeader, "Bearer other"),
+			method:       "/library.Library/ChangeAuthorInfo",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "reflection is public",
+			md:           metadata.MD{},
+			method:       "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			ctx, err := actorContext(ctx)
+			require.NoError(t, err)
+
+			var (
+				unaryPrincipal, streamPrincipal entity.Principal
+				unaryActor, streamActor         string
+			)
+
+			unary := AuthUnaryInterceptor(authenticator)
+			_, err = unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, _ any) (any, error) {
+				unaryPrincipal, _ = entity.PrincipalFromContext(ctx)
+				unaryActor = entity.ActorFromContext(ctx)
+				return nil, nil
+			})
+			require.Equal(t, tt.expectedCode, status.Code(err))
+
+			stream := AuthStreamInterceptor(authenticator)
+			err = stream(nil, &contextServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: tt.method}, func(_ any, stream grpc.ServerStream) error {
+				streamPrincipal, _ = entity.PrincipalFromContext(stream.Context())
+				streamActor = entity.ActorFromContext(stream.Context())
+				return nil
+			})
+			require.Equal(t, tt.expectedCode, status.Code(err))
+
+			require.Equal(t, tt.expectedPrincipal, unaryPrincipal)
+			require.Equal(t, tt.expectedPrincipal, streamPrincipal)
+			require.Equal(t, tt.expectedActor, unaryActor)
+			require.Equal(t, tt.expectedActor, streamActor)
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	t.Parallel()
+
+	// without authentication there is no principal and the admin calls are open
+	require.NoError(t, requireAdmin(context.Background(), "include_deleted"))
+
+	admin := entity.WithPrincipal(context.Background(), entity.Principal{Subject: "alice", Method: entity.AuthMethodJWT, Admin: true})
+	require.NoError(t, requireAdmin(admin, "include_deleted"))
+
+	reader := entity.WithPrincipal(context.Background(), entity.Principal{Subject: "bob", Method: entity.AuthMethodJWT})
+	require.Equal(t, codes.PermissionDenied, status.Code(requireAdmin(reader, "include_deleted")))
+}
+
+func TestRequirePatron(t *testing.T) {
+	t.Parallel()
+	patronID := "4e5c2a61-1f7e-4b5e-9a55-7a1f4f0c9b11"
+
+	require.NoError(t, requirePatron(context.Background(), patronID))
+
+	patron := entity.WithPrincipal(context.Background(), entity.Principal{Subject: patronID, Method: entity.AuthMethodJWT})
+	require.NoError(t, requirePatron(patron, patronID))
+	require.NoError(t, requirePatron(patron, ""))
+
+	staff := entity.WithPrincipal(context.Background(), entity.Principal{Subject: "alice", Method: entity.AuthMethodJWT, Admin: true})
+	require.NoError(t, requirePatron(staff, patronID))
+
+	other := entity.WithPrincipal(context.Background(), entity.Principal{Subject: "bob", Method: entity.AuthMethodJWT})
+	require.Equal(t, codes.PermissionDenied, status.Code(requirePatron(other, patronID)))
+}
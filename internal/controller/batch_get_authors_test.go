@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerBatchGetAuthors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	author := &library.AuthorSummary{Id: uuid.New().String(), Name: "Terry Pratchett"}
+	missingID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockAuthorUseCase)
+		ids          []string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "no ids",
+			prepare:      emptyAuthorUseCasePrepare,
+			ids:          nil,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "invalid uuid",
+			prepare:      emptyAuthorUseCasePrepare,
+			ids:          []string{"some invalid uuid"},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "internal error",
+			prepare: func(mock *mocks.MockAuthorUseCase) {
+				mock.EXPECT().BatchGetAuthors(ctx, []string{author.GetId()}).Return(nil, errors.New("connection refused"))
+			},
+			ids:          []string{author.GetId()},
+			expectedCode: codes.Internal,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockAuthorUseCase) {
+				mock.EXPECT().BatchGetAuthors(ctx, []string{author.GetId(), missingID}).Return(&library.BatchGetAuthorsResponse{
+					Authors:    []*library.AuthorSummary{author},
+					MissingIds: []string{missingID},
+				}, nil)
+			},
+			ids:          []string{author.GetId(), missingID},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.authorUseCase)
+
+			result, err := data.impl.BatchGetAuthors(ctx, &library.BatchGetAuthorsRequest{Ids: tt.ids})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetAuthors(), 1)
+				require.Equal(t, author.GetName(), result.GetAuthors()[0].GetName())
+				require.Equal(t, []string{missingID}, result.GetMissingIds())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerBatchGetBooks(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	book := &library.Book{Id: uuid.New().String(), Name: "Book1"}
+	missingID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBookUseCase)
+		ids          []string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "no ids",
+			prepare:      emptyBookUseCasePrepare,
+			ids:          nil,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "invalid uuid",
+			prepare:      emptyBookUseCasePrepare,
+			ids:          []string{book.GetId(), "some invalid uuid"},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "non unique ids",
+			prepare:      emptyBookUseCasePrepare,
+			ids:          []string{book.GetId(), book.GetId()},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "too many ids",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().BatchGetBooks(ctx, []string{book.GetId(), missingID}, entity.BookViewUndefined).
+					Return(nil, entity.ErrTooManyIDs)
+			},
+			ids:          []string{book.GetId(), missingID},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().BatchGetBooks(ctx, []string{missingID, book.GetId()}, entity.BookViewUndefined).
+					Return(&library.BatchGetBooksResponse{
+						Books:      []*library.Book{book},
+						MissingIds: []string{missingID},
+					}, nil)
+			},
+			ids:          []string{missingID, book.GetId()},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.bookUseCase)
+
+			result, err := data.impl.BatchGetBooks(ctx, &library.BatchGetBooksRequest{Ids: tt.ids})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetBooks(), 1)
+				compareBooks(t, book, result.GetBooks()[0])
+				require.Equal(t, []string{missingID}, result.GetMissingIds())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) BatchWrite(ctx context.Context, req *library.BatchWriteRequest) (*library.BatchWriteResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	operations := make([]entity.BatchOperation, len(req.GetOperations()))
+	for j, operation := range req.GetOperations() {
+		operations[j] = convertBatchOperation(operation)
+	}
+
+	response, err := i.booksUseCase.BatchWrite(ctx, operations)
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
+
+func convertBatchOperation(operation *library.BatchOperation) entity.BatchOperation {
+	switch {
+	case operation.GetCreateAuthor() != nil:
+		return entity.BatchOperation{
+			Kind:   entity.BatchOperationCreateAuthor,
+			TempID: operation.GetCreateAuthor().GetTempId(),
+			Name:   operation.GetCreateAuthor().GetName(),
+		}
+	case operation.GetUpdateAuthor() != nil:
+		return entity.BatchOperation{
+			Kind: entity.BatchOperationUpdateAuthor,
+			ID:   operation.GetUpdateAuthor().GetId(),
+			Name: operation.GetUpdateAuthor().GetName(),
+		}
+	case operation.GetDeleteAuthor() != nil:
+		return entity.BatchOperation{
+			Kind: entity.BatchOperationDeleteAuthor,
+			ID:   operation.GetDeleteAuthor().GetId(),
+		}
+	case operation.GetCreateBook() != nil:
+		return entity.BatchOperation{
+			Kind:         entity.BatchOperationCreateBook,
+			TempID:       operation.GetCreateBook().GetTempId(),
+			Name:         operation.GetCreateBook().GetName(),
+			AuthorIDs:    operation.GetCreateBook().GetAuthorIds(),
+			Contributors: convertBatchContributors(operation.GetCreateBook().GetContributors()),
+		}
+	case operation.GetUpdateBook() != nil:
+		return entity.BatchOperation{
+			Kind:         entity.BatchOperationUpdateBook,
+			ID:           operation.GetUpdateBook().GetId(),
+			Name:         operation.GetUpdateBook().GetName(),
+			AuthorIDs:    operation.GetUpdateBook().GetAuthorIds(),
+			Contributors: convertBatchContributors(operation.GetUpdateBook().GetContributors()),
+		}
+	case operation.GetDeleteBook() != nil:
+		return entity.BatchOperation{
+			Kind: entity.BatchOperationDeleteBook,
+			ID:   operation.GetDeleteBook().GetId(),
+		}
+	default:
+		return entity.BatchOperation{}
+	}
+}
+
+func convertBatchContributors(contributors []*library.BatchContributor) []entity.Contributor {
+	result := make([]entity.Contributor, len(contributors))
+	for i, contributor := range contributors {
+		result[i] = entity.Contributor{
+			AuthorID: contributor.GetAuthorId(),
+			Role:     entity.ContributorRole(contributor.GetRole()),
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerBatchWrite(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	authorID := uuid.New().String()
+
+	operations := []*library.BatchOperation{
+		{Operation: &library.BatchOperation_CreateAuthor{CreateAuthor: &library.CreateAuthorOperation{
+			TempId: "pratchett",
+			Name:   "Terry Pratchett",
+		}}},
+		{Operation: &library.BatchOperation_CreateBook{CreateBook: &library.CreateBookOperation{
+			TempId:    "mort",
+			Name:      "Mort",
+			AuthorIds: []string{"pratchett"},
+			Contributors: []*library.BatchContributor{
+				{AuthorId: authorID, Role: library.ContributorRole_CONTRIBUTOR_ROLE_ILLUSTRATOR},
+			},
+		}}},
+		{Operation: &library.BatchOperation_DeleteBook{DeleteBook: &library.DeleteBookOperation{Id: bookID}}},
+	}
+	expected := []entity.BatchOperation{
+		{Kind: entity.BatchOperationCreateAuthor, TempID: "pratchett", Name: "Terry Pratchett"},
+		{
+			Kind:         entity.BatchOperationCreateBook,
+			TempID:       "mort",
+			Name:         "Mort",
+			AuthorIDs:    []string{"pratchett"},
+			Contributors: []entity.Contributor{{AuthorID: authorID, Role: entity.ContributorRoleIllustrator}},
+		},
+		{Kind: entity.BatchOperationDeleteBook, ID: bookID},
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBookUseCase)
+		operations   []*library.BatchOperation
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "no operations",
+			prepare:      emptyBookUseCasePrepare,
+			operations:   nil,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "empty operation",
+			prepare:      emptyBookUseCasePrepare,
+			operations:   []*library.BatchOperation{{}},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "invalid author name",
+			prepare: emptyBookUseCasePrepare,
+			operations: []*library.BatchOperation{
+				{Operation: &library.BatchOperation_UpdateAuthor{UpdateAuthor: &library.UpdateAuthorOperation{
+					Id:   authorID,
+					Name: "  ",
+				}}},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "operation failed",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().BatchWrite(ctx, expected).Return(nil, entity.ErrBookNotFound)
+			},
+			operations:   operations,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().BatchWrite(ctx, expected).Return(&library.BatchWriteResponse{
+					Results: []*library.BatchOperationResult{
+						{Id: authorID, TempId: "pratchett"},
+						{Id: uuid.New().String(), TempId: "mort"},
+						{Id: bookID},
+					},
+				}, nil)
+			},
+			operations:   operations,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.bookUseCase)
+
+			result, err := data.impl.BatchWrite(ctx, &library.BatchWriteRequest{Operations: tt.operations})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetResults(), 3)
+				require.Equal(t, authorID, result.GetResults()[0].GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
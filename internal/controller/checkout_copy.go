@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) CheckoutCopy(ctx context.Context, req *library.CheckoutCopyRequest) (*library.CheckoutCopyResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := i.branchUseCase.CheckoutCopy(ctx, req.GetCopyId(), req.GetPatronId())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerCheckoutCopy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	loan := &library.Loan{
+		Id:       uuid.New().String(),
+		CopyId:   uuid.New().String(),
+		BookId:   uuid.New().String(),
+		PatronId: uuid.New().String(),
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBranchUseCase)
+		copyID       string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid copy id",
+			prepare:      emptyBranchUseCasePrepare,
+			copyID:       "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "copy not available",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().CheckoutCopy(ctx, loan.GetCopyId(), loan.GetPatronId()).Return(nil, entity.ErrCopyNotAvailable)
+			},
+			copyID:       loan.GetCopyId(),
+			expectedCode: codes.FailedPrecondition,
+			noError:      false,
+		},
+		{
+			name: "patron not found",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().CheckoutCopy(ctx, loan.GetCopyId(), loan.GetPatronId()).Return(nil, entity.ErrPatronNotFound)
+			},
+			copyID:       loan.GetCopyId(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().CheckoutCopy(ctx, loan.GetCopyId(), loan.GetPatronId()).Return(&library.CheckoutCopyResponse{
+					Loan: loan,
+				}, nil)
+			},
+			copyID:       loan.GetCopyId(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.branchUseCase)
+
+			result, err := data.impl.CheckoutCopy(ctx, &library.CheckoutCopyRequest{
+				CopyId:   tt.copyID,
+				PatronId: loan.GetPatronId(),
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, loan.GetId(), result.GetLoan().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -12,15 +12,39 @@ import (
 )
 
 type controllerData struct {
-	authorUseCase *mocks.MockAuthorUseCase
-	bookUseCase   *mocks.MockBookUseCase
-	impl          *implementation
+	authorUseCase       *mocks.MockAuthorUseCase
+	bookUseCase         *mocks.MockBookUseCase
+	notificationUseCase *mocks.MockNotificationUseCase
+	branchUseCase       *mocks.MockBranchUseCase
+	reviewUseCase       *mocks.MockReviewUseCase
+	collectionUseCase   *mocks.MockCollectionUseCase
+	recommendUseCase    *mocks.MockRecommendationUseCase
+	catalogUseCase      *mocks.MockCatalogUseCase
+	attachmentUseCase   *mocks.MockAttachmentUseCase
+	auditUseCase        *mocks.MockAuditUseCase
+	impl                *implementation
 }
 
 func emptyBookUseCasePrepare(_ *mocks.MockBookUseCase) {}
 
 func emptyAuthorUseCasePrepare(_ *mocks.MockAuthorUseCase) {}
 
+func emptyNotificationUseCasePrepare(_ *mocks.MockNotificationUseCase) {}
+
+func emptyBranchUseCasePrepare(_ *mocks.MockBranchUseCase) {}
+
+func emptyReviewUseCasePrepare(_ *mocks.MockReviewUseCase) {}
+
+func emptyCollectionUseCasePrepare(_ *mocks.MockCollectionUseCase) {}
+
+func emptyRecommendationUseCasePrepare(_ *mocks.MockRecommendationUseCase) {}
+
+func emptyCatalogUseCasePrepare(_ *mocks.MockCatalogUseCase) {}
+
+func emptyAttachmentUseCasePrepare(_ *mocks.MockAttachmentUseCase) {}
+
+func emptyAuditUseCasePrepare(_ *mocks.MockAuditUseCase) {}
+
 func compareBooks(t *testing.T, a *library.Book, b *library.Book) {
 	t.Helper()
 	require.Equal(t, a.GetId(), b.GetId())
@@ -35,12 +59,28 @@ func getControllerData(t *testing.T) *controllerData {
 
 	mockAuthorUseCase := mocks.NewMockAuthorUseCase(ctrl)
 	mockBookUseCase := mocks.NewMockBookUseCase(ctrl)
+	mockNotificationUseCase := mocks.NewMockNotificationUseCase(ctrl)
+	mockBranchUseCase := mocks.NewMockBranchUseCase(ctrl)
+	mockReviewUseCase := mocks.NewMockReviewUseCase(ctrl)
+	mockCollectionUseCase := mocks.NewMockCollectionUseCase(ctrl)
+	mockRecommendUseCase := mocks.NewMockRecommendationUseCase(ctrl)
+	mockCatalogUseCase := mocks.NewMockCatalogUseCase(ctrl)
+	mockAttachmentUseCase := mocks.NewMockAttachmentUseCase(ctrl)
+	mockAuditUseCase := mocks.NewMockAuditUseCase(ctrl)
 
-	impl := New(&zap.Logger{}, mockBookUseCase, mockAuthorUseCase)
+	impl := New(&zap.Logger{}, mockBookUseCase, mockAuthorUseCase, mockNotificationUseCase, mockBranchUseCase, mockReviewUseCase, mockCollectionUseCase, mockRecommendUseCase, mockCatalogUseCase, mockAttachmentUseCase, mockAuditUseCase)
 
 	return &controllerData{
-		authorUseCase: mockAuthorUseCase,
-		bookUseCase:   mockBookUseCase,
-		impl:          impl,
+		authorUseCase:       mockAuthorUseCase,
+		bookUseCase:         mockBookUseCase,
+		notificationUseCase: mockNotificationUseCase,
+		branchUseCase:       mockBranchUseCase,
+		reviewUseCase:       mockReviewUseCase,
+		collectionUseCase:   mockCollectionUseCase,
+		recommendUseCase:    mockRecommendUseCase,
+		catalogUseCase:      mockCatalogUseCase,
+		attachmentUseCase:   mockAttachmentUseCase,
+		auditUseCase:        mockAuditUseCase,
+		impl:                impl,
 	}
 }
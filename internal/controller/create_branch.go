@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) CreateBranch(ctx context.Context, req *library.CreateBranchRequest) (*library.CreateBranchResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	key, err := idempotencyKey(ctx, "CreateBranch", req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.branchUseCase.CreateBranch(ctx, req.GetName(), req.GetAddress(), key)
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
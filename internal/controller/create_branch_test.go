@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerCreateBranch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	branch := &library.Branch{
+		Id:      uuid.New().String(),
+		Name:    "Central",
+		Address: "Main street 1",
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBranchUseCase)
+		branch       *library.Branch
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:    "name too short",
+			prepare: emptyBranchUseCasePrepare,
+			branch: &library.Branch{
+				Name:    "",
+				Address: branch.GetAddress(),
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "name too long",
+			prepare: emptyBranchUseCasePrepare,
+			branch: &library.Branch{
+				Name:    strings.Repeat("a", 513),
+				Address: branch.GetAddress(),
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().CreateBranch(ctx, branch.GetName(), branch.GetAddress(), entity.IdempotencyKey{}).Return(&library.CreateBranchResponse{
+					Branch: branch,
+				}, nil)
+			},
+			branch:       branch,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.branchUseCase)
+
+			result, err := data.impl.CreateBranch(ctx, &library.CreateBranchRequest{
+				Name:    tt.branch.GetName(),
+				Address: tt.branch.GetAddress(),
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, branch.GetId(), result.GetBranch().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
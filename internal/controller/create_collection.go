@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) CreateCollection(ctx context.Context, req *library.CreateCollectionRequest) (*library.CreateCollectionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := requirePatron(ctx, req.GetOwnerId()); err != nil {
+		return nil, err
+	}
+
+	key, err := idempotencyKey(ctx, "CreateCollection", req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.collectionUseCase.CreateCollection(ctx, entity.Collection{
+		OwnerID:     req.GetOwnerId(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Visibility:  entity.CollectionVisibility(req.GetVisibility()),
+	}, key)
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
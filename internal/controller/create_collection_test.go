@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerCreateCollection(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	collection := &library.Collection{
+		Id:         uuid.New().String(),
+		OwnerId:    uuid.New().String(),
+		Name:       "Summer reading 2026",
+		Visibility: library.CollectionVisibility_COLLECTION_VISIBILITY_PRIVATE,
+	}
+	expected := entity.Collection{
+		OwnerID:    collection.GetOwnerId(),
+		Name:       collection.GetName(),
+		Visibility: entity.CollectionVisibilityPrivate,
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockCollectionUseCase)
+		ownerID      string
+		visibility   library.CollectionVisibility
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid owner id",
+			prepare:      emptyCollectionUseCasePrepare,
+			ownerID:      "some invalid uuid",
+			visibility:   collection.GetVisibility(),
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "unspecified visibility",
+			prepare:      emptyCollectionUseCasePrepare,
+			ownerID:      collection.GetOwnerId(),
+			visibility:   library.CollectionVisibility_COLLECTION_VISIBILITY_UNSPECIFIED,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "owner not found",
+			prepare: func(mock *mocks.MockCollectionUseCase) {
+				mock.EXPECT().CreateCollection(ctx, expected, entity.IdempotencyKey{}).Return(nil, entity.ErrPatronNotFound)
+			},
+			ownerID:      collection.GetOwnerId(),
+			visibility:   collection.GetVisibility(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockCollectionUseCase) {
+				mock.EXPECT().CreateCollection(ctx, expected, entity.IdempotencyKey{}).Return(&library.CreateCollectionResponse{
+					Collection: collection,
+				}, nil)
+			},
+			ownerID:      collection.GetOwnerId(),
+			visibility:   collection.GetVisibility(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.collectionUseCase)
+
+			result, err := data.impl.CreateCollection(ctx, &library.CreateCollectionRequest{
+				OwnerId:    tt.ownerID,
+				Name:       collection.GetName(),
+				Visibility: tt.visibility,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, collection.GetId(), result.GetCollection().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) CreateWork(ctx context.Context, req *library.CreateWorkRequest) (*library.CreateWorkResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	key, err := idempotencyKey(ctx, "CreateWork", req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.booksUseCase.CreateWork(ctx, req.GetName(), req.GetBookIds(), key)
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerCreateWork(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookIDs := []string{uuid.New().String(), uuid.New().String()}
+	work := &library.Work{Id: uuid.New().String(), Name: "Good Omens"}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBookUseCase)
+		req          *library.CreateWorkRequest
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "empty name",
+			prepare:      emptyBookUseCasePrepare,
+			req:          &library.CreateWorkRequest{BookIds: bookIDs},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "duplicate books",
+			prepare:      emptyBookUseCasePrepare,
+			req:          &library.CreateWorkRequest{Name: work.GetName(), BookIds: []string{bookIDs[0], bookIDs[0]}},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book not found",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().CreateWork(ctx, work.GetName(), bookIDs, entity.IdempotencyKey{}).Return(nil, entity.ErrBookNotFound)
+			},
+			req:          &library.CreateWorkRequest{Name: work.GetName(), BookIds: bookIDs},
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().CreateWork(ctx, work.GetName(), bookIDs, entity.IdempotencyKey{}).Return(&library.CreateWorkResponse{Work: work}, nil)
+			},
+			req:          &library.CreateWorkRequest{Name: work.GetName(), BookIds: bookIDs},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.bookUseCase)
+
+			result, err := data.impl.CreateWork(ctx, tt.req)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, work.GetId(), result.GetWork().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
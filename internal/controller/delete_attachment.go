@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) DeleteAttachment(ctx context.Context, req *library.DeleteAttachmentRequest) (*library.DeleteAttachmentResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := i.attachmentUseCase.DeleteAttachment(ctx, req.GetId()); err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return &library.DeleteAttachmentResponse{}, nil
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerDeleteAttachment(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	attachmentID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockAttachmentUseCase)
+		id           string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid id",
+			prepare:      emptyAttachmentUseCasePrepare,
+			id:           "invalid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "not found",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().DeleteAttachment(ctx, attachmentID).Return(entity.ErrAttachmentNotFound)
+			},
+			id:           attachmentID,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().DeleteAttachment(ctx, attachmentID).Return(nil)
+			},
+			id:           attachmentID,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.attachmentUseCase)
+
+			_, err := data.impl.DeleteAttachment(ctx, &library.DeleteAttachmentRequest{Id: tt.id})
+			if tt.noError {
+				require.NoError(t, err)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"bufio"
+	"io"
+
+	generated "github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) DownloadAttachment(
+	req *generated.DownloadAttachmentRequest,
+	server generated.Library_DownloadAttachmentServer,
+) error {
+	if err := req.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	attachment, data, err := i.attachmentUseCase.DownloadAttachment(server.Context(), req.GetId(), req.GetThumbnail())
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	defer func() {
+		_ = data.Close()
+	}()
+
+	if err = server.Send(&generated.DownloadAttachmentResponse{Attachment: attachment}); err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriterSize(chunkWriter{
+		send: func(data []byte) error {
+			return server.Send(&generated.DownloadAttachmentResponse{Data: data})
+		},
+	}, exportChunkSize)
+
+	_, err = io.Copy(writer, data)
+
+	if err == nil {
+		err = writer.Flush()
+	}
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	return nil
+}
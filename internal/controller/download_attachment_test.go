@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type downloadAttachmentServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*library.DownloadAttachmentResponse
+}
+
+func (s *downloadAttachmentServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *downloadAttachmentServer) Send(resp *library.DownloadAttachmentResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func TestControllerDownloadAttachment(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	attachment := &library.Attachment{
+		Id:          uuid.New().String(),
+		ContentType: "image/png",
+	}
+	content := strings.Repeat("x", exportChunkSize+1)
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockAttachmentUseCase)
+		id           string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid id",
+			prepare:      emptyAttachmentUseCasePrepare,
+			id:           "invalid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "not found",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().DownloadAttachment(ctx, attachment.GetId(), true).
+					Return(nil, nil, entity.ErrAttachmentNotFound)
+			},
+			id:           attachment.GetId(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().DownloadAttachment(ctx, attachment.GetId(), true).
+					Return(attachment, io.NopCloser(strings.NewReader(content)), nil)
+			},
+			id:           attachment.GetId(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.attachmentUseCase)
+
+			server := &downloadAttachmentServer{ctx: ctx}
+			err := data.impl.DownloadAttachment(&library.DownloadAttachmentRequest{Id: tt.id, Thumbnail: true}, server)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, server.responses, 3)
+				require.Equal(t, "image/png", server.responses[0].GetAttachment().GetContentType())
+
+				var received []byte
+				for _, resp := range server.responses[1:] {
+					require.Nil(t, resp.GetAttachment())
+					received = append(received, resp.GetData()...)
+				}
+
+				require.Equal(t, content, string(received))
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
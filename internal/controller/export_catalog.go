@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"bufio"
+
+	generated "github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) ExportCatalog(req *generated.ExportCatalogRequest, server generated.Library_ExportCatalogServer) error {
+	if err := req.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	filter := entity.CatalogFilter{
+		AuthorID:            req.GetAuthorId(),
+		NameQuery:           req.GetNameQuery(),
+		Subject:             req.GetSubject(),
+		PublicationYearFrom: int(req.GetPublicationYearFrom()),
+		PublicationYearTo:   int(req.GetPublicationYearTo()),
+		CollapseWorks:       req.GetCollapseWorks(),
+	}
+
+	writer := bufio.NewWriterSize(chunkWriter{
+		send: func(data []byte) error {
+			return server.Send(&generated.ExportCatalogResponse{Data: data})
+		},
+	}, exportChunkSize)
+
+	err := i.catalogUseCase.ExportCatalog(server.Context(), entity.CatalogFormat(req.GetFormat()), filter, writer)
+
+	if err == nil {
+		err = writer.Flush()
+	}
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type exportCatalogServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	data []byte
+}
+
+func (s *exportCatalogServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportCatalogServer) Send(resp *library.ExportCatalogResponse) error {
+	s.data = append(s.data, resp.GetData()...)
+	return nil
+}
+
+func TestControllerExportCatalog(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	authorID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockCatalogUseCase)
+		req          *library.ExportCatalogRequest
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "format is not set",
+			prepare:      emptyCatalogUseCasePrepare,
+			req:          &library.ExportCatalogRequest{},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "invalid author id",
+			prepare: emptyCatalogUseCasePrepare,
+			req: &library.ExportCatalogRequest{
+				Format:   library.CatalogFormat_CATALOG_FORMAT_CSV,
+				AuthorId: "1",
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockCatalogUseCase) {
+				mock.EXPECT().ExportCatalog(ctx, entity.CatalogFormatRIS, entity.CatalogFilter{
+					AuthorID:            authorID,
+					NameQuery:           "dune",
+					PublicationYearFrom: 1960,
+					CollapseWorks:       true,
+				}, gomock.Any()).DoAndReturn(func(_ context.Context, _ entity.CatalogFormat, _ entity.CatalogFilter, w io.Writer) error {
+					_, err := io.WriteString(w, "TY  - BOOK\n")
+					return err
+				})
+			},
+			req: &library.ExportCatalogRequest{
+				Format:              library.CatalogFormat_CATALOG_FORMAT_RIS,
+				AuthorId:            authorID,
+				NameQuery:           "dune",
+				PublicationYearFrom: 1960,
+				CollapseWorks:       true,
+			},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.catalogUseCase)
+
+			server := &exportCatalogServer{ctx: ctx}
+			err := data.impl.ExportCatalog(tt.req, server)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, "TY  - BOOK\n", string(server.data))
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"bufio"
+
+	generated "github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const exportChunkSize = 64 * 1024
+
+// chunkWriter sends everything written to it as data messages of a server stream.
+type chunkWriter struct {
+	send func(data []byte) error
+}
+
+func (c chunkWriter) Write(p []byte) (int, error) {
+	// the stream may keep the message after Send returns, p is reused by the caller
+	if err := c.send(append([]byte(nil), p...)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (i *implementation) ExportMarc(req *generated.ExportMarcRequest, server generated.Library_ExportMarcServer) error {
+	if err := req.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	writer := bufio.NewWriterSize(chunkWriter{
+		send: func(data []byte) error {
+			return server.Send(&generated.ExportMarcResponse{Data: data})
+		},
+	}, exportChunkSize)
+
+	err := i.catalogUseCase.ExportMarc(server.Context(), entity.CatalogFormat(req.GetFormat()), req.GetBookIds(), writer)
+
+	if err == nil {
+		err = writer.Flush()
+	}
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	return nil
+}
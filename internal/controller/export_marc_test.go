@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type exportMarcServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	data []byte
+}
+
+func (s *exportMarcServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportMarcServer) Send(resp *library.ExportMarcResponse) error {
+	s.data = append(s.data, resp.GetData()...)
+	return nil
+}
+
+func TestControllerExportMarc(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookIDs := []string{uuid.New().String(), uuid.New().String()}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockCatalogUseCase)
+		req          *library.ExportMarcRequest
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:    "no books",
+			prepare: emptyCatalogUseCasePrepare,
+			req: &library.ExportMarcRequest{
+				Format: library.CatalogFormat_CATALOG_FORMAT_MARC21,
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "format is not marc",
+			prepare: emptyCatalogUseCasePrepare,
+			req: &library.ExportMarcRequest{
+				BookIds: bookIDs,
+				Format:  library.CatalogFormat_CATALOG_FORMAT_CSV,
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book not found",
+			prepare: func(mock *mocks.MockCatalogUseCase) {
+				mock.EXPECT().ExportMarc(ctx, entity.CatalogFormatMARC21, bookIDs, gomock.Any()).Return(entity.ErrBookNotFound)
+			},
+			req: &library.ExportMarcRequest{
+				BookIds: bookIDs,
+				Format:  library.CatalogFormat_CATALOG_FORMAT_MARC21,
+			},
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockCatalogUseCase) {
+				mock.EXPECT().ExportMarc(ctx, entity.CatalogFormatMARCXML, bookIDs, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ entity.CatalogFormat, _ []string, w io.Writer) error {
+						_, err := io.WriteString(w, "<collection></collection>")
+						return err
+					})
+			},
+			req: &library.ExportMarcRequest{
+				BookIds: bookIDs,
+				Format:  library.CatalogFormat_CATALOG_FORMAT_MARCXML,
+			},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.catalogUseCase)
+
+			server := &exportMarcServer{ctx: ctx}
+			err := data.impl.ExportMarc(tt.req, server)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, "<collection></collection>", string(server.data))
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
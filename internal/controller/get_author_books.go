@@ -2,6 +2,7 @@ package controller
 
 import (
 	generated "github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -11,7 +12,12 @@ func (i *implementation) GetAuthorBooks(req *generated.GetAuthorBooksRequest, se
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	books, err := i.booksUseCase.GetBooksByAuthor(server.Context(), req.GetAuthorId())
+	books, err := i.booksUseCase.GetBooksByAuthor(
+		server.Context(),
+		req.GetAuthorId(),
+		entity.ContributorRole(req.GetRole()),
+		entity.BookView(req.GetView()),
+	)
 
 	if err != nil {
 		return i.convertError(err)
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"github.com/project/library/generated/api/library"
 	"google.golang.org/grpc/codes"
@@ -13,7 +14,22 @@ func (i *implementation) GetAuthorInfo(ctx context.Context, req *library.GetAuth
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	response, err := i.authorUseCase.GetAuthor(ctx, req.GetId())
+	var asOf time.Time
+	if req.GetAsOf() != nil {
+		if req.GetIncludeDeleted() {
+			return nil, status.Error(codes.InvalidArgument, "include_deleted can not be combined with as_of")
+		}
+
+		asOf = req.GetAsOf().AsTime()
+	}
+
+	if req.GetIncludeDeleted() {
+		if err := requireAdmin(ctx, "include_deleted"); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := i.authorUseCase.GetAuthor(ctx, req.GetId(), asOf, req.GetIncludeDeleted())
 
 	if err != nil {
 		return nil, i.convertError(err)
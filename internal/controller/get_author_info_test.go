@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/project/library/generated/api/library"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestControllerGetAuthorInfo(t *testing.T) {
@@ -20,13 +22,16 @@ func TestControllerGetAuthorInfo(t *testing.T) {
 		ID:   uuid.New().String(),
 		Name: "Author1",
 	}
+	asOf := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
-		name         string
-		prepare      func(*mocks.MockAuthorUseCase)
-		author       entity.Author
-		expectedCode codes.Code
-		noError      bool
+		name           string
+		prepare        func(*mocks.MockAuthorUseCase)
+		author         entity.Author
+		asOf           time.Time
+		includeDeleted bool
+		expectedCode   codes.Code
+		noError        bool
 	}{
 		{
 			name:    "invalid uuid",
@@ -41,7 +46,7 @@ func TestControllerGetAuthorInfo(t *testing.T) {
 		{
 			name: "author not found",
 			prepare: func(mock *mocks.MockAuthorUseCase) {
-				mock.EXPECT().GetAuthor(ctx, author.ID).Return(nil, entity.ErrAuthorNotFound)
+				mock.EXPECT().GetAuthor(ctx, author.ID, time.Time{}, false).Return(nil, entity.ErrAuthorNotFound)
 			},
 			author:       author,
 			expectedCode: codes.NotFound,
@@ -50,7 +55,7 @@ func TestControllerGetAuthorInfo(t *testing.T) {
 		{
 			name: "success",
 			prepare: func(mock *mocks.MockAuthorUseCase) {
-				mock.EXPECT().GetAuthor(ctx, author.ID).Return(&library.GetAuthorInfoResponse{
+				mock.EXPECT().GetAuthor(ctx, author.ID, time.Time{}, false).Return(&library.GetAuthorInfoResponse{
 					Id:   author.ID,
 					Name: author.Name,
 				}, nil)
@@ -59,6 +64,42 @@ func TestControllerGetAuthorInfo(t *testing.T) {
 			expectedCode: codes.OK,
 			noError:      true,
 		},
+		{
+			name: "success as of a moment",
+			prepare: func(mock *mocks.MockAuthorUseCase) {
+				mock.EXPECT().GetAuthor(ctx, author.ID, asOf, false).Return(&library.GetAuthorInfoResponse{
+					Id:   author.ID,
+					Name: author.Name,
+				}, nil)
+			},
+			author:       author,
+			asOf:         asOf,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+		{
+			name: "success including deleted",
+			prepare: func(mock *mocks.MockAuthorUseCase) {
+				mock.EXPECT().GetAuthor(ctx, author.ID, time.Time{}, true).Return(&library.GetAuthorInfoResponse{
+					Id:        author.ID,
+					Name:      author.Name,
+					DeletedAt: timestamppb.New(asOf),
+				}, nil)
+			},
+			author:         author,
+			includeDeleted: true,
+			expectedCode:   codes.OK,
+			noError:        true,
+		},
+		{
+			name:           "include deleted as of a moment",
+			prepare:        emptyAuthorUseCasePrepare,
+			author:         author,
+			asOf:           asOf,
+			includeDeleted: true,
+			expectedCode:   codes.InvalidArgument,
+			noError:        false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -67,9 +108,15 @@ func TestControllerGetAuthorInfo(t *testing.T) {
 			data := getControllerData(t)
 			tt.prepare(data.authorUseCase)
 
-			result, err := data.impl.GetAuthorInfo(ctx, &library.GetAuthorInfoRequest{
-				Id: tt.author.ID,
-			})
+			req := &library.GetAuthorInfoRequest{
+				Id:             tt.author.ID,
+				IncludeDeleted: tt.includeDeleted,
+			}
+			if !tt.asOf.IsZero() {
+				req.AsOf = timestamppb.New(tt.asOf)
+			}
+
+			result, err := data.impl.GetAuthorInfo(ctx, req)
 			if tt.noError {
 				require.NoError(t, err)
 				require.NotNil(t, result)
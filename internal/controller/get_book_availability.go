@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) GetBookAvailability(ctx context.Context, req *library.GetBookAvailabilityRequest) (*library.GetBookAvailabilityResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := i.branchUseCase.GetBookAvailability(ctx, req.GetBookId(), req.GetBranchId())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
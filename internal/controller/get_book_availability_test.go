@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerGetBookAvailability(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	branchID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBranchUseCase)
+		branchID     string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid branch id",
+			prepare:      emptyBranchUseCasePrepare,
+			branchID:     "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "all branches",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().GetBookAvailability(ctx, bookID, "").Return(&library.GetBookAvailabilityResponse{
+					Branches: []*library.BranchAvailability{{BranchId: branchID, Available: 1}},
+				}, nil)
+			},
+			branchID:     "",
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+		{
+			name: "single branch",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().GetBookAvailability(ctx, bookID, branchID).Return(&library.GetBookAvailabilityResponse{
+					Branches: []*library.BranchAvailability{{BranchId: branchID, Available: 1}},
+				}, nil)
+			},
+			branchID:     branchID,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.branchUseCase)
+
+			result, err := data.impl.GetBookAvailability(ctx, &library.GetBookAvailabilityRequest{
+				BookId:   bookID,
+				BranchId: tt.branchID,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetBranches(), 1)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
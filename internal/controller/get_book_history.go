@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) GetBookHistory(ctx context.Context, req *library.GetBookHistoryRequest) (*library.GetBookHistoryResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := i.booksUseCase.GetBookHistory(ctx, req.GetId(), entity.BookView(req.GetView()))
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerGetBookHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBookUseCase)
+		id           string
+		view         library.BookView
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid book id",
+			prepare:      emptyBookUseCasePrepare,
+			id:           "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "unknown view",
+			prepare:      emptyBookUseCasePrepare,
+			id:           bookID,
+			view:         library.BookView(42),
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book not found",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().GetBookHistory(ctx, bookID, entity.BookViewUndefined).Return(nil, entity.ErrBookNotFound)
+			},
+			id:           bookID,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().GetBookHistory(ctx, bookID, entity.BookViewFull).Return(&library.GetBookHistoryResponse{
+					Versions: []*library.BookVersion{
+						{Book: &library.Book{Id: bookID, Name: "Dune World"}},
+						{Book: &library.Book{Id: bookID, Name: "Dune"}},
+					},
+				}, nil)
+			},
+			id:           bookID,
+			view:         library.BookView_BOOK_VIEW_FULL,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.bookUseCase)
+
+			result, err := data.impl.GetBookHistory(ctx, &library.GetBookHistoryRequest{
+				Id:   tt.id,
+				View: tt.view,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetVersions(), 2)
+				require.Equal(t, "Dune", result.GetVersions()[1].GetBook().GetName())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
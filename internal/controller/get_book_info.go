@@ -2,8 +2,10 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -13,7 +15,26 @@ func (i *implementation) GetBookInfo(ctx context.Context, req *library.GetBookIn
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	response, err := i.booksUseCase.GetBook(ctx, req.GetId())
+	var asOf time.Time
+	if req.GetAsOf() != nil {
+		if req.GetIncludeEditions() {
+			return nil, status.Error(codes.InvalidArgument, "include_editions can not be combined with as_of")
+		}
+
+		if req.GetIncludeDeleted() {
+			return nil, status.Error(codes.InvalidArgument, "include_deleted can not be combined with as_of")
+		}
+
+		asOf = req.GetAsOf().AsTime()
+	}
+
+	if req.GetIncludeDeleted() {
+		if err := requireAdmin(ctx, "include_deleted"); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := i.booksUseCase.GetBook(ctx, req.GetId(), req.GetIncludeEditions(), entity.BookView(req.GetView()), asOf, req.GetIncludeDeleted())
 
 	if err != nil {
 		return nil, i.convertError(err)
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/project/library/generated/api/library"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestControllerGetBookInfo(t *testing.T) {
@@ -21,13 +23,18 @@ func TestControllerGetBookInfo(t *testing.T) {
 		Name:     "Book1",
 		AuthorId: []string{uuid.New().String()},
 	}
+	asOf := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
-		name         string
-		prepare      func(*mocks.MockBookUseCase)
-		book         *library.Book
-		expectedCode codes.Code
-		noError      bool
+		name           string
+		prepare        func(*mocks.MockBookUseCase)
+		book           *library.Book
+		view           library.BookView
+		editions       bool
+		asOf           time.Time
+		includeDeleted bool
+		expectedCode   codes.Code
+		noError        bool
 	}{
 		{
 			name:    "invalid book uid",
@@ -40,10 +47,18 @@ func TestControllerGetBookInfo(t *testing.T) {
 			expectedCode: codes.InvalidArgument,
 			noError:      false,
 		},
+		{
+			name:         "unknown view",
+			prepare:      emptyBookUseCasePrepare,
+			book:         book,
+			view:         library.BookView(42),
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
 		{
 			name: "book not found",
 			prepare: func(mock *mocks.MockBookUseCase) {
-				mock.EXPECT().GetBook(ctx, book.GetId()).Return(nil, entity.ErrBookNotFound)
+				mock.EXPECT().GetBook(ctx, book.GetId(), false, entity.BookViewUndefined, time.Time{}, false).Return(nil, entity.ErrBookNotFound)
 			},
 			book:         book,
 			expectedCode: codes.NotFound,
@@ -52,14 +67,68 @@ func TestControllerGetBookInfo(t *testing.T) {
 		{
 			name: "success",
 			prepare: func(mock *mocks.MockBookUseCase) {
-				mock.EXPECT().GetBook(ctx, book.GetId()).Return(&library.GetBookInfoResponse{
+				mock.EXPECT().GetBook(ctx, book.GetId(), false, entity.BookViewUndefined, time.Time{}, false).Return(&library.GetBookInfoResponse{
+					Book: book,
+				}, nil)
+			},
+			book:         book,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+		{
+			name: "success with full view",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().GetBook(ctx, book.GetId(), false, entity.BookViewFull, time.Time{}, false).Return(&library.GetBookInfoResponse{
 					Book: book,
 				}, nil)
 			},
 			book:         book,
+			view:         library.BookView_BOOK_VIEW_FULL,
 			expectedCode: codes.OK,
 			noError:      true,
 		},
+		{
+			name:         "editions as of a moment",
+			prepare:      emptyBookUseCasePrepare,
+			book:         book,
+			editions:     true,
+			asOf:         asOf,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "success as of a moment",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().GetBook(ctx, book.GetId(), false, entity.BookViewUndefined, asOf, false).Return(&library.GetBookInfoResponse{
+					Book: book,
+				}, nil)
+			},
+			book:         book,
+			asOf:         asOf,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+		{
+			name: "success including deleted",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().GetBook(ctx, book.GetId(), false, entity.BookViewUndefined, time.Time{}, true).Return(&library.GetBookInfoResponse{
+					Book: book,
+				}, nil)
+			},
+			book:           book,
+			includeDeleted: true,
+			expectedCode:   codes.OK,
+			noError:        true,
+		},
+		{
+			name:           "include deleted as of a moment",
+			prepare:        emptyBookUseCasePrepare,
+			book:           book,
+			asOf:           asOf,
+			includeDeleted: true,
+			expectedCode:   codes.InvalidArgument,
+			noError:        false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -69,9 +138,17 @@ func TestControllerGetBookInfo(t *testing.T) {
 
 			tt.prepare(data.bookUseCase)
 
-			result, err := data.impl.GetBookInfo(ctx, &library.GetBookInfoRequest{
-				Id: tt.book.GetId(),
-			})
+			req := &library.GetBookInfoRequest{
+				Id:              tt.book.GetId(),
+				View:            tt.view,
+				IncludeEditions: tt.editions,
+				IncludeDeleted:  tt.includeDeleted,
+			}
+			if !tt.asOf.IsZero() {
+				req.AsOf = timestamppb.New(tt.asOf)
+			}
+
+			result, err := data.impl.GetBookInfo(ctx, req)
 			if tt.noError {
 				require.NoError(t, err)
 				require.NotNil(t, result)
@@ -84,3 +161,15 @@ func TestControllerGetBookInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestControllerGetBookInfoIncludeDeletedByReader(t *testing.T) {
+	t.Parallel()
+	ctx := entity.WithPrincipal(context.Background(), entity.Principal{Subject: "bob", Method: entity.AuthMethodJWT})
+	data := getControllerData(t)
+
+	_, err := data.impl.GetBookInfo(ctx, &library.GetBookInfoRequest{
+		Id:             uuid.New().String(),
+		IncludeDeleted: true,
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerGetBranchInfo(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	branch := &library.Branch{
+		Id:   uuid.New().String(),
+		Name: "Central",
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBranchUseCase)
+		id           string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid uuid",
+			prepare:      emptyBranchUseCasePrepare,
+			id:           "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "branch not found",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().GetBranch(ctx, branch.GetId()).Return(nil, entity.ErrBranchNotFound)
+			},
+			id:           branch.GetId(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().GetBranch(ctx, branch.GetId()).Return(&library.GetBranchInfoResponse{
+					Branch: branch,
+				}, nil)
+			},
+			id:           branch.GetId(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.branchUseCase)
+
+			result, err := data.impl.GetBranchInfo(ctx, &library.GetBranchInfoRequest{
+				Id: tt.id,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, branch.GetName(), result.GetBranch().GetName())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
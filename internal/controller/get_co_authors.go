@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) GetCoAuthors(ctx context.Context, req *library.GetCoAuthorsRequest) (*library.GetCoAuthorsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := i.authorUseCase.GetCoAuthors(ctx, req.GetAuthorId())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
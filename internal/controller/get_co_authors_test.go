@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerGetCoAuthors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	authorID := uuid.New().String()
+	coAuthor := &library.CoAuthor{
+		Id:          uuid.New().String(),
+		Name:        "Author2",
+		SharedBooks: 2,
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockAuthorUseCase)
+		authorID     string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid uuid",
+			prepare:      emptyAuthorUseCasePrepare,
+			authorID:     "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "author not found",
+			prepare: func(mock *mocks.MockAuthorUseCase) {
+				mock.EXPECT().GetCoAuthors(ctx, authorID).Return(nil, entity.ErrAuthorNotFound)
+			},
+			authorID:     authorID,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockAuthorUseCase) {
+				mock.EXPECT().GetCoAuthors(ctx, authorID).Return(&library.GetCoAuthorsResponse{
+					CoAuthors: []*library.CoAuthor{coAuthor},
+				}, nil)
+			},
+			authorID:     authorID,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.authorUseCase)
+
+			result, err := data.impl.GetCoAuthors(ctx, &library.GetCoAuthorsRequest{
+				AuthorId: tt.authorID,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetCoAuthors(), 1)
+				require.Equal(t, int32(2), result.GetCoAuthors()[0].GetSharedBooks())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) GetCollaborationPath(
+	ctx context.Context,
+	req *library.GetCollaborationPathRequest,
+) (*library.GetCollaborationPathResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := i.authorUseCase.GetCollaborationPath(ctx, req.GetFromAuthorId(), req.GetToAuthorId(), int(req.GetMaxDepth()))
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerGetCollaborationPath(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fromID := uuid.New().String()
+	toID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockAuthorUseCase)
+		toID         string
+		maxDepth     int32
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid uuid",
+			prepare:      emptyAuthorUseCasePrepare,
+			toID:         "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "depth limit too big",
+			prepare:      emptyAuthorUseCasePrepare,
+			toID:         toID,
+			maxDepth:     100,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "authors are not connected",
+			prepare: func(mock *mocks.MockAuthorUseCase) {
+				mock.EXPECT().GetCollaborationPath(ctx, fromID, toID, 3).Return(nil, entity.ErrCollaborationPathNotFound)
+			},
+			toID:         toID,
+			maxDepth:     3,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockAuthorUseCase) {
+				mock.EXPECT().GetCollaborationPath(ctx, fromID, toID, 3).Return(&library.GetCollaborationPathResponse{
+					Distance: 1,
+					Steps: []*library.CollaborationStep{
+						{AuthorId: fromID},
+						{AuthorId: toID, BookId: uuid.New().String()},
+					},
+				}, nil)
+			},
+			toID:         toID,
+			maxDepth:     3,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.authorUseCase)
+
+			result, err := data.impl.GetCollaborationPath(ctx, &library.GetCollaborationPathRequest{
+				FromAuthorId: fromID,
+				ToAuthorId:   tt.toID,
+				MaxDepth:     tt.maxDepth,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, int32(1), result.GetDistance())
+				require.Len(t, result.GetSteps(), 2)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
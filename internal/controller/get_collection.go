@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) GetCollection(ctx context.Context, req *library.GetCollectionRequest) (*library.GetCollectionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := requirePatron(ctx, req.GetViewerId()); err != nil {
+		return nil, err
+	}
+
+	response, err := i.collectionUseCase.GetCollection(ctx, req.GetId(), req.GetViewerId(), entity.BookView(req.GetView()))
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerGetNotificationPreferences(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	patronID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockNotificationUseCase)
+		patronID     string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid patron id",
+			prepare:      emptyNotificationUseCasePrepare,
+			patronID:     "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "preferences not found",
+			prepare: func(mock *mocks.MockNotificationUseCase) {
+				mock.EXPECT().GetNotificationPreferences(ctx, patronID).Return(nil, entity.ErrNotificationPreferencesNotFound)
+			},
+			patronID:     patronID,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockNotificationUseCase) {
+				mock.EXPECT().GetNotificationPreferences(ctx, patronID).Return(&library.GetNotificationPreferencesResponse{
+					Preferences: &library.NotificationPreferences{
+						PatronId: patronID,
+						Channels: []library.NotificationChannel{library.NotificationChannel_NOTIFICATION_CHANNEL_WEBHOOK},
+					},
+				}, nil)
+			},
+			patronID:     patronID,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.notificationUseCase)
+
+			result, err := data.impl.GetNotificationPreferences(ctx, &library.GetNotificationPreferencesRequest{
+				PatronId: tt.patronID,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, patronID, result.GetPreferences().GetPatronId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerGetPatronInfo(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	patron := &library.Patron{
+		Id:           uuid.New().String(),
+		Name:         "Patron1",
+		HomeBranchId: uuid.New().String(),
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBranchUseCase)
+		id           string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid uuid",
+			prepare:      emptyBranchUseCasePrepare,
+			id:           "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "patron not found",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().GetPatron(ctx, patron.GetId()).Return(nil, entity.ErrPatronNotFound)
+			},
+			id:           patron.GetId(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().GetPatron(ctx, patron.GetId()).Return(&library.GetPatronInfoResponse{
+					Patron: patron,
+				}, nil)
+			},
+			id:           patron.GetId(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.branchUseCase)
+
+			result, err := data.impl.GetPatronInfo(ctx, &library.GetPatronInfoRequest{
+				Id: tt.id,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, patron.GetHomeBranchId(), result.GetPatron().GetHomeBranchId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerGetWork(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	work := &library.Work{Id: uuid.New().String(), Name: "Good Omens"}
+	editions := []*library.Book{
+		{Id: uuid.New().String(), Name: "Good Omens", WorkId: work.GetId(), Language: "en"},
+		{Id: uuid.New().String(), Name: "Buone apocalissi", WorkId: work.GetId(), Language: "it"},
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBookUseCase)
+		id           string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid uuid",
+			prepare:      emptyBookUseCasePrepare,
+			id:           "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "work not found",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().GetWork(ctx, work.GetId(), entity.BookViewUndefined).Return(nil, entity.ErrWorkNotFound)
+			},
+			id:           work.GetId(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().GetWork(ctx, work.GetId(), entity.BookViewUndefined).Return(&library.GetWorkResponse{
+					Work:     work,
+					Editions: editions,
+				}, nil)
+			},
+			id:           work.GetId(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.bookUseCase)
+
+			result, err := data.impl.GetWork(ctx, &library.GetWorkRequest{Id: tt.id})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, work.GetId(), result.GetWork().GetId())
+				require.Len(t, result.GetEditions(), 2)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+	generated "github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) ImportCatalog(server generated.Library_ImportCatalogServer) error {
+	req, err := server.Recv()
+
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "empty import stream")
+	}
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reader := &streamReader[*generated.ImportCatalogRequest]{
+		recv: server.Recv,
+		buf:  req.GetData(),
+	}
+
+	response, err := i.catalogUseCase.ImportCatalog(
+		server.Context(),
+		entity.CatalogFormat(req.GetFormat()),
+		req.GetDryRun(),
+		reader,
+	)
+
+	if reader.err != nil {
+		return reader.err
+	}
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	return server.SendAndClose(response)
+}
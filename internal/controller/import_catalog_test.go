@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type importCatalogServer struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*library.ImportCatalogRequest
+	response *library.ImportCatalogResponse
+}
+
+func (s *importCatalogServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *importCatalogServer) Recv() (*library.ImportCatalogRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+
+	return req, nil
+}
+
+func (s *importCatalogServer) SendAndClose(response *library.ImportCatalogResponse) error {
+	s.response = response
+	return nil
+}
+
+func TestControllerImportCatalog(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockCatalogUseCase)
+		requests     []*library.ImportCatalogRequest
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "empty stream",
+			prepare:      emptyCatalogUseCasePrepare,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "undefined format",
+			prepare: emptyCatalogUseCasePrepare,
+			requests: []*library.ImportCatalogRequest{
+				{Format: library.CatalogFormat(10)},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "invalid catalog",
+			prepare: func(mock *mocks.MockCatalogUseCase) {
+				mock.EXPECT().ImportCatalog(ctx, entity.CatalogFormatCSV, false, gomock.Any()).
+					Return(nil, entity.ErrInvalidCatalog)
+			},
+			requests: []*library.ImportCatalogRequest{
+				{Format: library.CatalogFormat_CATALOG_FORMAT_CSV, Data: []byte("title\n")},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockCatalogUseCase) {
+				mock.EXPECT().ImportCatalog(ctx, entity.CatalogFormatJSONL, true, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ entity.CatalogFormat, _ bool, data io.Reader) (*library.ImportCatalogResponse, error) {
+						content, err := io.ReadAll(data)
+						require.NoError(t, err)
+						require.Equal(t, `{"name": "Dune"}`, string(content))
+
+						return &library.ImportCatalogResponse{TotalRows: 1, DryRun: true}, nil
+					})
+			},
+			requests: []*library.ImportCatalogRequest{
+				{Format: library.CatalogFormat_CATALOG_FORMAT_JSONL, DryRun: true, Data: []byte(`{"name": `)},
+				{Data: []byte(`"Dune"}`)},
+			},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.catalogUseCase)
+
+			server := &importCatalogServer{ctx: ctx, requests: tt.requests}
+			err := data.impl.ImportCatalog(server)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, int32(1), server.response.GetTotalRows())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
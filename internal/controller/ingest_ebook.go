@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+	generated "github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) IngestEbook(server generated.Library_IngestEbookServer) error {
+	req, err := server.Recv()
+
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "empty upload stream")
+	}
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reader := &streamReader[*generated.IngestEbookRequest]{
+		recv: server.Recv,
+		buf:  req.GetData(),
+	}
+
+	response, err := i.attachmentUseCase.IngestEbook(server.Context(), req.GetName(), req.GetDryRun(), reader)
+
+	if reader.err != nil {
+		return reader.err
+	}
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	return server.SendAndClose(response)
+}
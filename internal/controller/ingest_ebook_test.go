@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ingestEbookServer struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*library.IngestEbookRequest
+	response *library.IngestEbookResponse
+}
+
+func (s *ingestEbookServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *ingestEbookServer) Recv() (*library.IngestEbookRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+
+	return req, nil
+}
+
+func (s *ingestEbookServer) SendAndClose(response *library.IngestEbookResponse) error {
+	s.response = response
+	return nil
+}
+
+func TestControllerIngestEbook(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	book := &library.Book{Id: uuid.New().String(), Name: "Dune"}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockAttachmentUseCase)
+		requests     []*library.IngestEbookRequest
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "empty stream",
+			prepare:      emptyAttachmentUseCasePrepare,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "invalid chunk",
+			prepare: emptyAttachmentUseCasePrepare,
+			requests: []*library.IngestEbookRequest{
+				{Data: make([]byte, 4194305)},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "invalid e-book",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().IngestEbook(ctx, "dune.epub", false, gomock.Any()).
+					Return(nil, entity.ErrInvalidEbook)
+			},
+			requests: []*library.IngestEbookRequest{
+				{Name: "dune.epub", Data: []byte("PK")},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "too large",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().IngestEbook(ctx, "", true, gomock.Any()).
+					Return(nil, entity.ErrAttachmentTooLarge)
+			},
+			requests: []*library.IngestEbookRequest{
+				{DryRun: true, Data: []byte("%PDF")},
+			},
+			expectedCode: codes.ResourceExhausted,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().IngestEbook(ctx, "dune.pdf", false, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, _ bool, data io.Reader) (*library.IngestEbookResponse, error) {
+						content, err := io.ReadAll(data)
+						require.NoError(t, err)
+						require.Equal(t, "%PDF-1.7", string(content))
+
+						return &library.IngestEbookResponse{Book: book}, nil
+					})
+			},
+			requests: []*library.IngestEbookRequest{
+				{Name: "dune.pdf", Data: []byte("%PDF")},
+				{Data: []byte("-1.7")},
+			},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.attachmentUseCase)
+
+			server := &ingestEbookServer{ctx: ctx, requests: tt.requests}
+			err := data.impl.IngestEbook(server)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, book.GetId(), server.response.GetBook().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
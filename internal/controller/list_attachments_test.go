@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerListAttachments(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockAttachmentUseCase)
+		bookID       string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid book id",
+			prepare:      emptyAttachmentUseCasePrepare,
+			bookID:       "invalid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book not found",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().ListAttachments(ctx, bookID).Return(nil, entity.ErrBookNotFound)
+			},
+			bookID:       bookID,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().ListAttachments(ctx, bookID).Return(&library.ListAttachmentsResponse{
+					Attachments: []*library.Attachment{{Id: uuid.New().String(), BookId: bookID}},
+				}, nil)
+			},
+			bookID:       bookID,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.attachmentUseCase)
+
+			resp, err := data.impl.ListAttachments(ctx, &library.ListAttachmentsRequest{BookId: tt.bookID})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, resp.GetAttachments(), 1)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
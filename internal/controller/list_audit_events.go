@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) ListAuditEvents(ctx context.Context, req *library.ListAuditEventsRequest) (*library.ListAuditEventsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	filter := entity.AuditFilter{
+		Entity:   req.GetEntity(),
+		EntityID: req.GetEntityId(),
+		Actor:    req.GetActor(),
+	}
+
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+
+	response, err := i.auditUseCase.ListAuditEvents(ctx, filter, int(req.GetPageSize()), req.GetPageToken())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
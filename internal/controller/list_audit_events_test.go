@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestControllerListAuditEvents(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	from := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockAuditUseCase)
+		req          *library.ListAuditEventsRequest
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "unknown entity",
+			prepare:      emptyAuditUseCasePrepare,
+			req:          &library.ListAuditEventsRequest{Entity: "audit_event"},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "actor too long",
+			prepare:      emptyAuditUseCasePrepare,
+			req:          &library.ListAuditEventsRequest{Actor: strings.Repeat("a", 256)},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "page size too big",
+			prepare:      emptyAuditUseCasePrepare,
+			req:          &library.ListAuditEventsRequest{PageSize: 1000},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "invalid page token",
+			prepare: func(mock *mocks.MockAuditUseCase) {
+				mock.EXPECT().ListAuditEvents(ctx, entity.AuditFilter{}, 10, "garbage").Return(nil, entity.ErrInvalidPageToken)
+			},
+			req:          &library.ListAuditEventsRequest{PageSize: 10, PageToken: "garbage"},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockAuditUseCase) {
+				filter := entity.AuditFilter{Entity: "book", EntityID: bookID, Actor: "librarian", From: from}
+				mock.EXPECT().ListAuditEvents(ctx, filter, 10, "").Return(&library.ListAuditEventsResponse{
+					Events:        []*library.AuditEvent{{Id: uuid.New().String(), Entity: "book", EntityId: bookID}},
+					NextPageToken: "next",
+				}, nil)
+			},
+			req: &library.ListAuditEventsRequest{
+				Entity:   "book",
+				EntityId: bookID,
+				Actor:    "librarian",
+				From:     timestamppb.New(from),
+				PageSize: 10,
+			},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.auditUseCase)
+
+			result, err := data.impl.ListAuditEvents(ctx, tt.req)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetEvents(), 1)
+				require.Equal(t, "next", result.GetNextPageToken())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) ListCollections(ctx context.Context, req *library.ListCollectionsRequest) (*library.ListCollectionsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := requirePatron(ctx, req.GetViewerId()); err != nil {
+		return nil, err
+	}
+
+	filter := entity.CollectionFilter{
+		ViewerID: req.GetViewerId(),
+		OwnerID:  req.GetOwnerId(),
+	}
+
+	response, err := i.collectionUseCase.ListCollections(ctx, filter, int(req.GetPageSize()), req.GetPageToken(), entity.BookView(req.GetView()))
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
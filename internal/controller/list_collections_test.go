@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerListCollections(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	viewerID := uuid.New().String()
+	filter := entity.CollectionFilter{ViewerID: viewerID}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockCollectionUseCase)
+		viewerID     string
+		pageToken    string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid viewer id",
+			prepare:      emptyCollectionUseCasePrepare,
+			viewerID:     "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "invalid page token",
+			prepare: func(mock *mocks.MockCollectionUseCase) {
+				mock.EXPECT().ListCollections(ctx, filter, 0, "garbage", entity.BookViewUndefined).Return(nil, entity.ErrInvalidPageToken)
+			},
+			viewerID:     viewerID,
+			pageToken:    "garbage",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockCollectionUseCase) {
+				mock.EXPECT().ListCollections(ctx, filter, 0, "", entity.BookViewUndefined).Return(&library.ListCollectionsResponse{
+					Collections: []*library.Collection{{Id: uuid.New().String(), OwnerId: viewerID}},
+				}, nil)
+			},
+			viewerID:     viewerID,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.collectionUseCase)
+
+			result, err := data.impl.ListCollections(ctx, &library.ListCollectionsRequest{
+				ViewerId:  tt.viewerID,
+				PageToken: tt.pageToken,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetCollections(), 1)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
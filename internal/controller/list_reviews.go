@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) ListReviews(ctx context.Context, req *library.ListReviewsRequest) (*library.ListReviewsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	filter := entity.ReviewFilter{
+		BookID:   req.GetBookId(),
+		PatronID: req.GetPatronId(),
+		Status:   entity.ReviewStatus(req.GetStatus()),
+	}
+
+	response, err := i.reviewUseCase.ListReviews(ctx, filter, int(req.GetPageSize()), req.GetPageToken())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerListReviews(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	filter := entity.ReviewFilter{BookID: bookID}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockReviewUseCase)
+		bookID       string
+		pageSize     int32
+		pageToken    string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid book id",
+			prepare:      emptyReviewUseCasePrepare,
+			bookID:       "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "page size too big",
+			prepare:      emptyReviewUseCasePrepare,
+			bookID:       bookID,
+			pageSize:     1000,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "invalid page token",
+			prepare: func(mock *mocks.MockReviewUseCase) {
+				mock.EXPECT().ListReviews(ctx, filter, 10, "garbage").Return(nil, entity.ErrInvalidPageToken)
+			},
+			bookID:       bookID,
+			pageSize:     10,
+			pageToken:    "garbage",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockReviewUseCase) {
+				mock.EXPECT().ListReviews(ctx, filter, 10, "").Return(&library.ListReviewsResponse{
+					Reviews:       []*library.Review{{Id: uuid.New().String(), BookId: bookID}},
+					NextPageToken: "next",
+				}, nil)
+			},
+			bookID:       bookID,
+			pageSize:     10,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.reviewUseCase)
+
+			result, err := data.impl.ListReviews(ctx, &library.ListReviewsRequest{
+				BookId:    tt.bookID,
+				PageSize:  tt.pageSize,
+				PageToken: tt.pageToken,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetReviews(), 1)
+				require.Equal(t, "next", result.GetNextPageToken())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
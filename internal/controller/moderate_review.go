@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) ModerateReview(ctx context.Context, req *library.ModerateReviewRequest) (*library.ModerateReviewResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := i.reviewUseCase.ModerateReview(ctx, req.GetId(), entity.ReviewStatus(req.GetStatus()))
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerModerateReview(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	review := &library.Review{
+		Id:     uuid.New().String(),
+		Status: library.ReviewStatus_REVIEW_STATUS_APPROVED,
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockReviewUseCase)
+		status       library.ReviewStatus
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "unspecified status",
+			prepare:      emptyReviewUseCasePrepare,
+			status:       library.ReviewStatus_REVIEW_STATUS_UNSPECIFIED,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "review not found",
+			prepare: func(mock *mocks.MockReviewUseCase) {
+				mock.EXPECT().ModerateReview(ctx, review.GetId(), entity.ReviewStatusApproved).Return(nil, entity.ErrReviewNotFound)
+			},
+			status:       library.ReviewStatus_REVIEW_STATUS_APPROVED,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockReviewUseCase) {
+				mock.EXPECT().ModerateReview(ctx, review.GetId(), entity.ReviewStatusApproved).Return(&library.ModerateReviewResponse{
+					Review: review,
+				}, nil)
+			},
+			status:       library.ReviewStatus_REVIEW_STATUS_APPROVED,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.reviewUseCase)
+
+			result, err := data.impl.ModerateReview(ctx, &library.ModerateReviewRequest{
+				Id:     review.GetId(),
+				Status: tt.status,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, library.ReviewStatus_REVIEW_STATUS_APPROVED, result.GetReview().GetStatus())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
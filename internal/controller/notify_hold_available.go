@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) NotifyHoldAvailable(
+	ctx context.Context,
+	req *library.NotifyHoldAvailableRequest,
+) (*library.NotifyHoldAvailableResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := i.notificationUseCase.NotifyHoldAvailable(ctx, req.GetPatronId(), req.GetBookId())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerNotifyHoldAvailable(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	patronID := uuid.New().String()
+	bookID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockNotificationUseCase)
+		bookID       string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid book id",
+			prepare:      emptyNotificationUseCasePrepare,
+			bookID:       "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book does not exist",
+			prepare: func(mock *mocks.MockNotificationUseCase) {
+				mock.EXPECT().NotifyHoldAvailable(ctx, patronID, bookID).Return(nil, entity.ErrBookNotFound)
+			},
+			bookID:       bookID,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockNotificationUseCase) {
+				mock.EXPECT().NotifyHoldAvailable(ctx, patronID, bookID).Return(&library.NotifyHoldAvailableResponse{
+					Notification: &library.Notification{
+						Id:       uuid.New().String(),
+						PatronId: patronID,
+						BookId:   bookID,
+						Kind:     library.NotificationKind_NOTIFICATION_KIND_HOLD_AVAILABLE,
+					},
+				}, nil)
+			},
+			bookID:       bookID,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.notificationUseCase)
+
+			result, err := data.impl.NotifyHoldAvailable(ctx, &library.NotifyHoldAvailableRequest{
+				PatronId: patronID,
+				BookId:   tt.bookID,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, bookID, result.GetNotification().GetBookId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
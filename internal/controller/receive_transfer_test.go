@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerReceiveTransfer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	transferID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBranchUseCase)
+		id           string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid uuid",
+			prepare:      emptyBranchUseCasePrepare,
+			id:           "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "transfer already received",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().ReceiveTransfer(ctx, transferID).Return(nil, entity.ErrTransferAlreadyReceived)
+			},
+			id:           transferID,
+			expectedCode: codes.FailedPrecondition,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().ReceiveTransfer(ctx, transferID).Return(&library.ReceiveTransferResponse{
+					Transfer: &library.Transfer{
+						Id:     transferID,
+						Status: library.TransferStatus_TRANSFER_STATUS_RECEIVED,
+					},
+				}, nil)
+			},
+			id:           transferID,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.branchUseCase)
+
+			result, err := data.impl.ReceiveTransfer(ctx, &library.ReceiveTransferRequest{
+				Id: tt.id,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, library.TransferStatus_TRANSFER_STATUS_RECEIVED, result.GetTransfer().GetStatus())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
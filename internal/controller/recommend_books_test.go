@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerRecommendBooks(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	recommended := &library.Book{
+		Id:   uuid.New().String(),
+		Name: "book",
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockRecommendationUseCase)
+		bookID       string
+		limit        int32
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid book id",
+			prepare:      emptyRecommendationUseCasePrepare,
+			bookID:       "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "limit too big",
+			prepare:      emptyRecommendationUseCasePrepare,
+			bookID:       bookID,
+			limit:        1000,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "internal error",
+			prepare: func(mock *mocks.MockRecommendationUseCase) {
+				mock.EXPECT().RecommendBooks(ctx, bookID, 5, entity.BookViewUndefined).Return(nil, errors.New("connection refused"))
+			},
+			bookID:       bookID,
+			limit:        5,
+			expectedCode: codes.Internal,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockRecommendationUseCase) {
+				mock.EXPECT().RecommendBooks(ctx, bookID, 5, entity.BookViewUndefined).Return(&library.RecommendBooksResponse{
+					Books: []*library.RecommendedBook{{Book: recommended, Score: 3}},
+				}, nil)
+			},
+			bookID:       bookID,
+			limit:        5,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.recommendUseCase)
+
+			result, err := data.impl.RecommendBooks(ctx, &library.RecommendBooksRequest{
+				BookId: tt.bookID,
+				Limit:  tt.limit,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetBooks(), 1)
+				compareBooks(t, recommended, result.GetBooks()[0].GetBook())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
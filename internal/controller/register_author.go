@@ -13,7 +13,13 @@ func (i *implementation) RegisterAuthor(ctx context.Context, req *library.Regist
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	response, err := i.authorUseCase.RegisterAuthor(ctx, req.GetName())
+	key, err := idempotencyKey(ctx, "RegisterAuthor", req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.authorUseCase.RegisterAuthor(ctx, req.GetName(), key)
 
 	if err != nil {
 		return nil, i.convertError(err)
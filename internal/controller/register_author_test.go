@@ -63,7 +63,7 @@ func TestControllerRegisterAuthor(t *testing.T) {
 		{
 			name: "author already exist",
 			prepare: func(mock *mocks.MockAuthorUseCase) {
-				mock.EXPECT().RegisterAuthor(ctx, author.Name).Return(nil, errors.New("some random error"))
+				mock.EXPECT().RegisterAuthor(ctx, author.Name, entity.IdempotencyKey{}).Return(nil, errors.New("some random error"))
 			},
 			author:       author,
 			expectedCode: codes.Internal,
@@ -72,7 +72,7 @@ func TestControllerRegisterAuthor(t *testing.T) {
 		{
 			name: "success",
 			prepare: func(mock *mocks.MockAuthorUseCase) {
-				mock.EXPECT().RegisterAuthor(ctx, author.Name).Return(&library.RegisterAuthorResponse{
+				mock.EXPECT().RegisterAuthor(ctx, author.Name, entity.IdempotencyKey{}).Return(&library.RegisterAuthorResponse{
 					Id: author.ID,
 				}, nil)
 			},
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) RegisterPatron(ctx context.Context, req *library.RegisterPatronRequest) (*library.RegisterPatronResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	key, err := idempotencyKey(ctx, "RegisterPatron", req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := i.branchUseCase.RegisterPatron(ctx, req.GetName(), req.GetHomeBranchId(), key)
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerRegisterPatron(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	patron := &library.Patron{
+		Id:           uuid.New().String(),
+		Name:         "Patron1",
+		HomeBranchId: uuid.New().String(),
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBranchUseCase)
+		patron       *library.Patron
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:    "invalid home branch id",
+			prepare: emptyBranchUseCasePrepare,
+			patron: &library.Patron{
+				Name:         patron.GetName(),
+				HomeBranchId: "some invalid uuid",
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "home branch does not exist",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().RegisterPatron(ctx, patron.GetName(), patron.GetHomeBranchId(), entity.IdempotencyKey{}).Return(nil, entity.ErrBranchNotFound)
+			},
+			patron:       patron,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().RegisterPatron(ctx, patron.GetName(), patron.GetHomeBranchId(), entity.IdempotencyKey{}).Return(&library.RegisterPatronResponse{
+					Patron: patron,
+				}, nil)
+			},
+			patron:       patron,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.branchUseCase)
+
+			result, err := data.impl.RegisterPatron(ctx, &library.RegisterPatronRequest{
+				Name:         tt.patron.GetName(),
+				HomeBranchId: tt.patron.GetHomeBranchId(),
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, patron.GetId(), result.GetPatron().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerRemoveCollectionBook(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	collection := &library.Collection{
+		Id:         uuid.New().String(),
+		OwnerId:    uuid.New().String(),
+		Name:       "Staff picks",
+		Visibility: library.CollectionVisibility_COLLECTION_VISIBILITY_PUBLIC,
+	}
+	bookID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockCollectionUseCase)
+		collectionID string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid collection id",
+			prepare:      emptyCollectionUseCasePrepare,
+			collectionID: "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book not in collection",
+			prepare: func(mock *mocks.MockCollectionUseCase) {
+				mock.EXPECT().RemoveCollectionBook(ctx, collection.GetId(), collection.GetOwnerId(), bookID).
+					Return(nil, entity.ErrBookNotInCollection)
+			},
+			collectionID: collection.GetId(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockCollectionUseCase) {
+				mock.EXPECT().RemoveCollectionBook(ctx, collection.GetId(), collection.GetOwnerId(), bookID).
+					Return(&library.RemoveCollectionBookResponse{Collection: collection}, nil)
+			},
+			collectionID: collection.GetId(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.collectionUseCase)
+
+			result, err := data.impl.RemoveCollectionBook(ctx, &library.RemoveCollectionBookRequest{
+				CollectionId: tt.collectionID,
+				OwnerId:      collection.GetOwnerId(),
+				BookId:       bookID,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, collection.GetId(), result.GetCollection().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
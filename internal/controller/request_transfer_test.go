@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerRequestTransfer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	transfer := &library.Transfer{
+		Id:           uuid.New().String(),
+		CopyId:       uuid.New().String(),
+		FromBranchId: uuid.New().String(),
+		ToBranchId:   uuid.New().String(),
+		Status:       library.TransferStatus_TRANSFER_STATUS_IN_TRANSIT,
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBranchUseCase)
+		copyID       string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid copy id",
+			prepare:      emptyBranchUseCasePrepare,
+			copyID:       "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "copy not found",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().RequestTransfer(ctx, transfer.GetCopyId(), transfer.GetToBranchId()).Return(nil, entity.ErrCopyNotFound)
+			},
+			copyID:       transfer.GetCopyId(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "copy not available",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().RequestTransfer(ctx, transfer.GetCopyId(), transfer.GetToBranchId()).Return(nil, entity.ErrCopyNotAvailable)
+			},
+			copyID:       transfer.GetCopyId(),
+			expectedCode: codes.FailedPrecondition,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBranchUseCase) {
+				mock.EXPECT().RequestTransfer(ctx, transfer.GetCopyId(), transfer.GetToBranchId()).Return(&library.RequestTransferResponse{
+					Transfer: transfer,
+				}, nil)
+			},
+			copyID:       transfer.GetCopyId(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.branchUseCase)
+
+			result, err := data.impl.RequestTransfer(ctx, &library.RequestTransferRequest{
+				CopyId:     tt.copyID,
+				ToBranchId: transfer.GetToBranchId(),
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, library.TransferStatus_TRANSFER_STATUS_IN_TRANSIT, result.GetTransfer().GetStatus())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) RestoreAuthor(ctx context.Context, req *library.RestoreAuthorRequest) (*library.RestoreAuthorResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := requireAdmin(ctx, "RestoreAuthor"); err != nil {
+		return nil, err
+	}
+
+	err := i.authorUseCase.RestoreAuthor(ctx, req.GetId())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return &library.RestoreAuthorResponse{}, nil
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerRestoreAuthor(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	id := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockAuthorUseCase)
+		id           string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid author id",
+			prepare:      emptyAuthorUseCasePrepare,
+			id:           "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "author not in the trash",
+			prepare: func(mock *mocks.MockAuthorUseCase) {
+				mock.EXPECT().RestoreAuthor(ctx, id).Return(entity.ErrAuthorNotFound)
+			},
+			id:           id,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockAuthorUseCase) {
+				mock.EXPECT().RestoreAuthor(ctx, id).Return(nil)
+			},
+			id:           id,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+
+			tt.prepare(data.authorUseCase)
+
+			result, err := data.impl.RestoreAuthor(ctx, &library.RestoreAuthorRequest{
+				Id: tt.id,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) RestoreBook(ctx context.Context, req *library.RestoreBookRequest) (*library.RestoreBookResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := requireAdmin(ctx, "RestoreBook"); err != nil {
+		return nil, err
+	}
+
+	err := i.booksUseCase.RestoreBook(ctx, req.GetId())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return &library.RestoreBookResponse{}, nil
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerRestoreBook(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	id := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBookUseCase)
+		id           string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid book id",
+			prepare:      emptyBookUseCasePrepare,
+			id:           "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book not in the trash",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().RestoreBook(ctx, id).Return(entity.ErrBookNotFound)
+			},
+			id:           id,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().RestoreBook(ctx, id).Return(nil)
+			},
+			id:           id,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+
+			tt.prepare(data.bookUseCase)
+
+			result, err := data.impl.RestoreBook(ctx, &library.RestoreBookRequest{
+				Id: tt.id,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) ScheduleDueDateReminders(
+	ctx context.Context,
+	req *library.ScheduleDueDateRemindersRequest,
+) (*library.ScheduleDueDateRemindersResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := i.notificationUseCase.ScheduleDueDateReminders(ctx, req.GetPatronId(), req.GetBookId(), req.GetDueAt().AsTime())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
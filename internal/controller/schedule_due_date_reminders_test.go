@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestControllerScheduleDueDateReminders(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	patronID := uuid.New().String()
+	bookID := uuid.New().String()
+	dueAt := time.Now().AddDate(0, 0, 14).UTC()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockNotificationUseCase)
+		req          *library.ScheduleDueDateRemindersRequest
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:    "invalid patron id",
+			prepare: emptyNotificationUseCasePrepare,
+			req: &library.ScheduleDueDateRemindersRequest{
+				PatronId: "some invalid uuid",
+				BookId:   bookID,
+				DueAt:    timestamppb.New(dueAt),
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "missing due date",
+			prepare: emptyNotificationUseCasePrepare,
+			req: &library.ScheduleDueDateRemindersRequest{
+				PatronId: patronID,
+				BookId:   bookID,
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book does not exist",
+			prepare: func(mock *mocks.MockNotificationUseCase) {
+				mock.EXPECT().ScheduleDueDateReminders(ctx, patronID, bookID, dueAt).Return(nil, entity.ErrBookNotFound)
+			},
+			req: &library.ScheduleDueDateRemindersRequest{
+				PatronId: patronID,
+				BookId:   bookID,
+				DueAt:    timestamppb.New(dueAt),
+			},
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockNotificationUseCase) {
+				mock.EXPECT().ScheduleDueDateReminders(ctx, patronID, bookID, dueAt).
+					Return(&library.ScheduleDueDateRemindersResponse{
+						Notifications: []*library.Notification{
+							{Id: uuid.New().String(), Kind: library.NotificationKind_NOTIFICATION_KIND_DUE_SOON},
+							{Id: uuid.New().String(), Kind: library.NotificationKind_NOTIFICATION_KIND_OVERDUE},
+						},
+					}, nil)
+			},
+			req: &library.ScheduleDueDateRemindersRequest{
+				PatronId: patronID,
+				BookId:   bookID,
+				DueAt:    timestamppb.New(dueAt),
+			},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.notificationUseCase)
+
+			result, err := data.impl.ScheduleDueDateReminders(ctx, tt.req)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, result.GetNotifications(), 2)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -9,15 +9,43 @@ import (
 var _ generated.LibraryServer = (*implementation)(nil)
 
 type implementation struct {
-	logger        *zap.Logger
-	booksUseCase  library.BookUseCase
-	authorUseCase library.AuthorUseCase
+	logger              *zap.Logger
+	booksUseCase        library.BookUseCase
+	authorUseCase       library.AuthorUseCase
+	notificationUseCase library.NotificationUseCase
+	branchUseCase       library.BranchUseCase
+	reviewUseCase       library.ReviewUseCase
+	collectionUseCase   library.CollectionUseCase
+	recommendUseCase    library.RecommendationUseCase
+	catalogUseCase      library.CatalogUseCase
+	attachmentUseCase   library.AttachmentUseCase
+	auditUseCase        library.AuditUseCase
 }
 
-func New(logger *zap.Logger, booksUseCase library.BookUseCase, authorsUseCase library.AuthorUseCase) *implementation {
+func New(
+	logger *zap.Logger,
+	booksUseCase library.BookUseCase,
+	authorsUseCase library.AuthorUseCase,
+	notificationUseCase library.NotificationUseCase,
+	branchUseCase library.BranchUseCase,
+	reviewUseCase library.ReviewUseCase,
+	collectionUseCase library.CollectionUseCase,
+	recommendUseCase library.RecommendationUseCase,
+	catalogUseCase library.CatalogUseCase,
+	attachmentUseCase library.AttachmentUseCase,
+	auditUseCase library.AuditUseCase,
+) *implementation {
 	return &implementation{
-		logger:        logger,
-		booksUseCase:  booksUseCase,
-		authorUseCase: authorsUseCase,
+		logger:              logger,
+		booksUseCase:        booksUseCase,
+		authorUseCase:       authorsUseCase,
+		notificationUseCase: notificationUseCase,
+		branchUseCase:       branchUseCase,
+		reviewUseCase:       reviewUseCase,
+		collectionUseCase:   collectionUseCase,
+		recommendUseCase:    recommendUseCase,
+		catalogUseCase:      catalogUseCase,
+		attachmentUseCase:   attachmentUseCase,
+		auditUseCase:        auditUseCase,
 	}
 }
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) SetBookEdition(ctx context.Context, req *library.SetBookEditionRequest) (*library.SetBookEditionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := i.booksUseCase.SetBookEdition(ctx, req.GetBookId(), entity.Edition{
+		WorkID:          req.GetWorkId(),
+		ISBN:            req.GetIsbn(),
+		Publisher:       req.GetPublisher(),
+		PublicationYear: int(req.GetPublicationYear()),
+		Language:        req.GetLanguage(),
+		Translator:      req.GetTranslator(),
+	})
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerSetBookEdition(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	workID := uuid.New().String()
+	edition := entity.Edition{
+		WorkID:          workID,
+		ISBN:            "9788804492948",
+		Publisher:       "Mondadori",
+		PublicationYear: 2007,
+		Language:        "it",
+		Translator:      "Luca Fusari",
+	}
+	req := &library.SetBookEditionRequest{
+		BookId:          bookID,
+		WorkId:          workID,
+		Isbn:            edition.ISBN,
+		Publisher:       edition.Publisher,
+		PublicationYear: int32(edition.PublicationYear),
+		Language:        edition.Language,
+		Translator:      edition.Translator,
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockBookUseCase)
+		req          *library.SetBookEditionRequest
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid isbn",
+			prepare:      emptyBookUseCasePrepare,
+			req:          &library.SetBookEditionRequest{BookId: bookID, Isbn: "978-88-04-49294-8"},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "invalid work id",
+			prepare:      emptyBookUseCasePrepare,
+			req:          &library.SetBookEditionRequest{BookId: bookID, WorkId: "invalid"},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "work not found",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().SetBookEdition(ctx, bookID, edition).Return(nil, entity.ErrWorkNotFound)
+			},
+			req:          req,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "detach from work",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().SetBookEdition(ctx, bookID, entity.Edition{}).
+					Return(&library.SetBookEditionResponse{Book: &library.Book{Id: bookID}}, nil)
+			},
+			req:          &library.SetBookEditionRequest{BookId: bookID},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().SetBookEdition(ctx, bookID, edition).
+					Return(&library.SetBookEditionResponse{Book: &library.Book{Id: bookID, WorkId: workID}}, nil)
+			},
+			req:          req,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.bookUseCase)
+
+			result, err := data.impl.SetBookEdition(ctx, tt.req)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, bookID, result.GetBook().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) SetBookSubjects(ctx context.Context, req *library.SetBookSubjectsRequest) (*library.SetBookSubjectsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err := i.recommendUseCase.SetBookSubjects(ctx, req.GetBookId(), req.GetSubjects())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return &library.SetBookSubjectsResponse{}, nil
+}
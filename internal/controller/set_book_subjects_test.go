@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerSetBookSubjects(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	subjects := []string{"fantasy", "dragons"}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockRecommendationUseCase)
+		subjects     []string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "duplicate subjects",
+			prepare:      emptyRecommendationUseCasePrepare,
+			subjects:     []string{"fantasy", "fantasy"},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "subject too long",
+			prepare:      emptyRecommendationUseCasePrepare,
+			subjects:     []string{strings.Repeat("a", 129)},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "book not found",
+			prepare: func(mock *mocks.MockRecommendationUseCase) {
+				mock.EXPECT().SetBookSubjects(ctx, bookID, subjects).Return(entity.ErrBookNotFound)
+			},
+			subjects:     subjects,
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockRecommendationUseCase) {
+				mock.EXPECT().SetBookSubjects(ctx, bookID, subjects).Return(nil)
+			},
+			subjects:     subjects,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.recommendUseCase)
+
+			_, err := data.impl.SetBookSubjects(ctx, &library.SetBookSubjectsRequest{
+				BookId:   bookID,
+				Subjects: tt.subjects,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
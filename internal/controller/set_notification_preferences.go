@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) SetNotificationPreferences(
+	ctx context.Context,
+	req *library.SetNotificationPreferencesRequest,
+) (*library.SetNotificationPreferencesResponse, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	preferences := req.GetPreferences()
+	channels := make([]entity.NotificationChannel, len(preferences.GetChannels()))
+
+	for idx, channel := range preferences.GetChannels() {
+		channels[idx] = entity.NotificationChannel(channel)
+	}
+
+	err := i.notificationUseCase.SetNotificationPreferences(ctx, entity.NotificationPreferences{
+		PatronID:   preferences.GetPatronId(),
+		Channels:   channels,
+		Email:      preferences.GetEmail(),
+		WebhookURL: preferences.GetWebhookUrl(),
+	})
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return &library.SetNotificationPreferencesResponse{}, nil
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerSetNotificationPreferences(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	preferences := &library.NotificationPreferences{
+		PatronId: uuid.New().String(),
+		Channels: []library.NotificationChannel{
+			library.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL,
+			library.NotificationChannel_NOTIFICATION_CHANNEL_WEBHOOK,
+		},
+		Email:      "patron@example.com",
+		WebhookUrl: "https://example.com/hook",
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockNotificationUseCase)
+		preferences  *library.NotificationPreferences
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "missing preferences",
+			prepare:      emptyNotificationUseCasePrepare,
+			preferences:  nil,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "unspecified channel",
+			prepare: emptyNotificationUseCasePrepare,
+			preferences: &library.NotificationPreferences{
+				PatronId: preferences.GetPatronId(),
+				Channels: []library.NotificationChannel{library.NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "duplicate channels",
+			prepare: emptyNotificationUseCasePrepare,
+			preferences: &library.NotificationPreferences{
+				PatronId: preferences.GetPatronId(),
+				Channels: []library.NotificationChannel{
+					library.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL,
+					library.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL,
+				},
+				Email: preferences.GetEmail(),
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "invalid email",
+			prepare: emptyNotificationUseCasePrepare,
+			preferences: &library.NotificationPreferences{
+				PatronId: preferences.GetPatronId(),
+				Channels: preferences.GetChannels(),
+				Email:    "not an email",
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockNotificationUseCase) {
+				mock.EXPECT().SetNotificationPreferences(ctx, entity.NotificationPreferences{
+					PatronID:   preferences.GetPatronId(),
+					Channels:   []entity.NotificationChannel{entity.NotificationChannelEmail, entity.NotificationChannelWebhook},
+					Email:      preferences.GetEmail(),
+					WebhookURL: preferences.GetWebhookUrl(),
+				}).Return(nil)
+			},
+			preferences:  preferences,
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.notificationUseCase)
+
+			result, err := data.impl.SetNotificationPreferences(ctx, &library.SetNotificationPreferencesRequest{
+				Preferences: tt.preferences,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
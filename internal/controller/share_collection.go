@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) ShareCollection(ctx context.Context, req *library.ShareCollectionRequest) (*library.ShareCollectionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := requirePatron(ctx, req.GetOwnerId()); err != nil {
+		return nil, err
+	}
+
+	response, err := i.collectionUseCase.ShareCollection(ctx, req.GetCollectionId(), req.GetOwnerId(), req.GetPatronId())
+
+	if err != nil {
+		return nil, i.convertError(err)
+	}
+
+	return response, nil
+}
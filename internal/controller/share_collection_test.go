@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestControllerShareCollection(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	collection := &library.Collection{
+		Id:         uuid.New().String(),
+		OwnerId:    uuid.New().String(),
+		Name:       "Staff picks",
+		Visibility: library.CollectionVisibility_COLLECTION_VISIBILITY_PUBLIC,
+	}
+	patronID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockCollectionUseCase)
+		collectionID string
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "invalid collection id",
+			prepare:      emptyCollectionUseCasePrepare,
+			collectionID: "some invalid uuid",
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "patron not found",
+			prepare: func(mock *mocks.MockCollectionUseCase) {
+				mock.EXPECT().ShareCollection(ctx, collection.GetId(), collection.GetOwnerId(), patronID).
+					Return(nil, entity.ErrPatronNotFound)
+			},
+			collectionID: collection.GetId(),
+			expectedCode: codes.NotFound,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockCollectionUseCase) {
+				mock.EXPECT().ShareCollection(ctx, collection.GetId(), collection.GetOwnerId(), patronID).
+					Return(&library.ShareCollectionResponse{Collection: collection}, nil)
+			},
+			collectionID: collection.GetId(),
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.collectionUseCase)
+
+			result, err := data.impl.ShareCollection(ctx, &library.ShareCollectionRequest{
+				CollectionId: tt.collectionID,
+				OwnerId:      collection.GetOwnerId(),
+				PatronId:     patronID,
+			})
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, collection.GetId(), result.GetCollection().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
+
+func TestControllerShareCollectionOfAnotherPatron(t *testing.T) {
+	t.Parallel()
+	ctx := entity.WithPrincipal(context.Background(), entity.Principal{Subject: uuid.New().String(), Method: entity.AuthMethodJWT})
+	data := getControllerData(t)
+
+	_, err := data.impl.ShareCollection(ctx, &library.ShareCollectionRequest{
+		CollectionId: uuid.New().String(),
+		OwnerId:      uuid.New().String(),
+		PatronId:     uuid.New().String(),
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
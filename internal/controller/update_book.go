@@ -13,7 +13,7 @@ func (i *implementation) UpdateBook(ctx context.Context, req *library.UpdateBook
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	err := i.booksUseCase.ChangeBookInfo(ctx, req.GetId(), req.GetName(), req.GetAuthorIds())
+	err := i.booksUseCase.ChangeBookInfo(ctx, req.GetId(), req.GetName(), req.GetAuthorIds(), convertContributors(req.GetContributors()))
 
 	if err != nil {
 		return nil, i.convertError(err)
@@ -21,11 +21,13 @@ func TestControllerUpdateBook(t *testing.T) {
 		Name:      "Book1",
 		AuthorIDs: []string{uuid.New().String()},
 	}
+	translatorID := uuid.New().String()
 
 	tests := []struct {
 		name         string
 		prepare      func(*mocks.MockBookUseCase)
 		book         entity.Book
+		contributors []*library.Contributor
 		expectedCode codes.Code
 		noError      bool
 	}{
@@ -62,10 +64,20 @@ func TestControllerUpdateBook(t *testing.T) {
 			expectedCode: codes.InvalidArgument,
 			noError:      false,
 		},
+		{
+			name:    "unspecified contributor role",
+			prepare: emptyBookUseCasePrepare,
+			book:    book,
+			contributors: []*library.Contributor{
+				{AuthorId: translatorID},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
 		{
 			name: "author does not exist",
 			prepare: func(mock *mocks.MockBookUseCase) {
-				mock.EXPECT().ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs).Return(entity.ErrAuthorNotFound)
+				mock.EXPECT().ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs, []entity.Contributor{}).Return(entity.ErrAuthorNotFound)
 			},
 			book:         book,
 			expectedCode: codes.NotFound,
@@ -74,7 +86,7 @@ func TestControllerUpdateBook(t *testing.T) {
 		{
 			name: "book not found",
 			prepare: func(mock *mocks.MockBookUseCase) {
-				mock.EXPECT().ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs).Return(entity.ErrBookNotFound)
+				mock.EXPECT().ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs, []entity.Contributor{}).Return(entity.ErrBookNotFound)
 			},
 			book:         book,
 			expectedCode: codes.NotFound,
@@ -83,12 +95,26 @@ func TestControllerUpdateBook(t *testing.T) {
 		{
 			name: "success",
 			prepare: func(mock *mocks.MockBookUseCase) {
-				mock.EXPECT().ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs).Return(nil)
+				mock.EXPECT().ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs, []entity.Contributor{}).Return(nil)
 			},
 			book:         book,
 			expectedCode: codes.OK,
 			noError:      true,
 		},
+		{
+			name: "success with contributors",
+			prepare: func(mock *mocks.MockBookUseCase) {
+				mock.EXPECT().ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs, []entity.Contributor{
+					{AuthorID: translatorID, Role: entity.ContributorRoleTranslator},
+				}).Return(nil)
+			},
+			book: book,
+			contributors: []*library.Contributor{
+				{AuthorId: translatorID, Role: library.ContributorRole_CONTRIBUTOR_ROLE_TRANSLATOR},
+			},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -99,9 +125,10 @@ func TestControllerUpdateBook(t *testing.T) {
 			tt.prepare(data.bookUseCase)
 
 			result, err := data.impl.UpdateBook(ctx, &library.UpdateBookRequest{
-				Id:        tt.book.ID,
-				Name:      tt.book.Name,
-				AuthorIds: tt.book.AuthorIDs,
+				Id:           tt.book.ID,
+				Name:         tt.book.Name,
+				AuthorIds:    tt.book.AuthorIDs,
+				Contributors: tt.contributors,
 			})
 			if tt.noError {
 				require.NoError(t, err)
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+	generated "github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) UploadAttachment(server generated.Library_UploadAttachmentServer) error {
+	req, err := server.Recv()
+
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "empty upload stream")
+	}
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.GetBookId() == "" || req.GetKind() == generated.AttachmentKind_ATTACHMENT_KIND_UNSPECIFIED {
+		return status.Error(codes.InvalidArgument, "book_id and kind are required in the first message")
+	}
+
+	reader := &streamReader[*generated.UploadAttachmentRequest]{
+		recv: server.Recv,
+		buf:  req.GetData(),
+	}
+
+	response, err := i.attachmentUseCase.UploadAttachment(
+		server.Context(),
+		req.GetBookId(),
+		entity.AttachmentKind(req.GetKind()),
+		req.GetName(),
+		reader,
+	)
+
+	if reader.err != nil {
+		return reader.err
+	}
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	return server.SendAndClose(response)
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type uploadAttachmentServer struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*library.UploadAttachmentRequest
+	response *library.UploadAttachmentResponse
+}
+
+func (s *uploadAttachmentServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *uploadAttachmentServer) Recv() (*library.UploadAttachmentRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+
+	return req, nil
+}
+
+func (s *uploadAttachmentServer) SendAndClose(response *library.UploadAttachmentResponse) error {
+	s.response = response
+	return nil
+}
+
+func TestControllerUploadAttachment(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	attachment := &library.Attachment{
+		Id:     uuid.New().String(),
+		BookId: bookID,
+		Kind:   library.AttachmentKind_ATTACHMENT_KIND_FILE,
+	}
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockAttachmentUseCase)
+		requests     []*library.UploadAttachmentRequest
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "empty stream",
+			prepare:      emptyAttachmentUseCasePrepare,
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "no book id",
+			prepare: emptyAttachmentUseCasePrepare,
+			requests: []*library.UploadAttachmentRequest{
+				{Kind: library.AttachmentKind_ATTACHMENT_KIND_COVER, Data: []byte("image")},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "invalid book id",
+			prepare: emptyAttachmentUseCasePrepare,
+			requests: []*library.UploadAttachmentRequest{
+				{BookId: "invalid", Kind: library.AttachmentKind_ATTACHMENT_KIND_COVER},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "unsupported content type",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().UploadAttachment(ctx, bookID, entity.AttachmentKindCover, "", gomock.Any()).
+					Return(nil, entity.ErrUnsupportedContentType)
+			},
+			requests: []*library.UploadAttachmentRequest{
+				{BookId: bookID, Kind: library.AttachmentKind_ATTACHMENT_KIND_COVER, Data: []byte("%PDF")},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "too large",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().UploadAttachment(ctx, bookID, entity.AttachmentKindFile, "", gomock.Any()).
+					Return(nil, entity.ErrAttachmentTooLarge)
+			},
+			requests: []*library.UploadAttachmentRequest{
+				{BookId: bookID, Kind: library.AttachmentKind_ATTACHMENT_KIND_FILE, Data: []byte("%PDF")},
+			},
+			expectedCode: codes.ResourceExhausted,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockAttachmentUseCase) {
+				mock.EXPECT().UploadAttachment(ctx, bookID, entity.AttachmentKindFile, "book.pdf", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, _ entity.AttachmentKind, _ string, data io.Reader) (*library.UploadAttachmentResponse, error) {
+						content, err := io.ReadAll(data)
+						require.NoError(t, err)
+						require.Equal(t, "%PDF-1.7", string(content))
+
+						return &library.UploadAttachmentResponse{Attachment: attachment}, nil
+					})
+			},
+			requests: []*library.UploadAttachmentRequest{
+				{BookId: bookID, Kind: library.AttachmentKind_ATTACHMENT_KIND_FILE, Name: "book.pdf", Data: []byte("%PDF")},
+				{Data: []byte("-1.7")},
+			},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.attachmentUseCase)
+
+			server := &uploadAttachmentServer{ctx: ctx, requests: tt.requests}
+			err := data.impl.UploadAttachment(server)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, attachment.GetId(), server.response.GetAttachment().GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
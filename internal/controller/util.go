@@ -1,19 +1,141 @@
 package controller
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
 	"github.com/pkg/errors"
 	"github.com/project/library/internal/entity"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
+// idempotencyKeyHeader is the metadata key of a client supplied idempotency key,
+// the gateway forwards the Idempotency-Key http header under it.
+const idempotencyKeyHeader = "idempotency-key"
+
+const maxIdempotencyKeyLength = 255
+
 func (i *implementation) convertError(err error) error {
 	switch {
 	case errors.Is(err, entity.ErrAuthorNotFound):
 		return status.Error(codes.NotFound, err.Error())
 	case errors.Is(err, entity.ErrBookNotFound):
 		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, entity.ErrNotificationPreferencesNotFound),
+		errors.Is(err, entity.ErrBranchNotFound),
+		errors.Is(err, entity.ErrPatronNotFound),
+		errors.Is(err, entity.ErrCopyNotFound),
+		errors.Is(err, entity.ErrTransferNotFound),
+		errors.Is(err, entity.ErrReviewNotFound),
+		errors.Is(err, entity.ErrCollectionNotFound),
+		errors.Is(err, entity.ErrBookNotInCollection),
+		errors.Is(err, entity.ErrCollaborationPathNotFound),
+		errors.Is(err, entity.ErrAttachmentNotFound),
+		errors.Is(err, entity.ErrBlobNotFound),
+		errors.Is(err, entity.ErrWorkNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, entity.ErrCopyNotAvailable),
+		errors.Is(err, entity.ErrCopyAlreadyAtBranch),
+		errors.Is(err, entity.ErrTransferAlreadyReceived),
+		errors.Is(err, entity.ErrCopyNotCheckedOut),
+		errors.Is(err, entity.ErrIdempotencyKeyReused):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, entity.ErrCollectionAccessDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, entity.ErrReviewAlreadyExists),
+		errors.Is(err, entity.ErrBookAlreadyInCollection):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, entity.ErrInvalidPageToken),
+		errors.Is(err, entity.ErrInvalidCollectionOrder),
+		errors.Is(err, entity.ErrUnsupportedCatalogFormat),
+		errors.Is(err, entity.ErrInvalidCatalog),
+		errors.Is(err, entity.ErrUnsupportedContentType),
+		errors.Is(err, entity.ErrInvalidAttachment),
+		errors.Is(err, entity.ErrInvalidEbook),
+		errors.Is(err, entity.ErrTooManyIDs),
+		errors.Is(err, entity.ErrInvalidBatch):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, entity.ErrCatalogTooLarge),
+		errors.Is(err, entity.ErrAttachmentTooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
 }
+
+// idempotencyKey reads the idempotency key of a create request from the incoming metadata.
+// The request is hashed, so that the key can not be reused for another request of the same method,
+// and the key belongs to the actor, the authenticated principal when auth is enabled.
+func idempotencyKey(ctx context.Context, method string, req proto.Message) (entity.IdempotencyKey, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(idempotencyKeyHeader)
+
+	if len(values) == 0 || values[0] == "" {
+		return entity.IdempotencyKey{}, nil
+	}
+
+	if len(values[0]) > maxIdempotencyKeyLength {
+		return entity.IdempotencyKey{}, status.Errorf(codes.InvalidArgument,
+			"%s must be at most %d characters", idempotencyKeyHeader, maxIdempotencyKeyLength)
+	}
+
+	serialized, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+
+	if err != nil {
+		return entity.IdempotencyKey{}, status.Error(codes.Internal, err.Error())
+	}
+
+	hash := sha256.Sum256(serialized)
+
+	return entity.IdempotencyKey{
+		Key:         values[0],
+		Actor:       entity.ActorFromContext(ctx),
+		Method:      method,
+		RequestHash: hex.EncodeToString(hash[:]),
+	}, nil
+}
+
+// dataRequest is a message of a client stream that uploads a file in chunks.
+type dataRequest interface {
+	GetData() []byte
+	Validate() error
+}
+
+// streamReader exposes the data chunks of a client stream as an io.Reader.
+// The first message is already received by the handler and is put into buf.
+type streamReader[T dataRequest] struct {
+	recv func() (T, error)
+	buf  []byte
+	err  error
+}
+
+func (r *streamReader[T]) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.recv()
+
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+
+		if err == nil {
+			err = req.Validate()
+		}
+
+		if err != nil {
+			r.err = status.Error(codes.InvalidArgument, err.Error())
+			return 0, r.err
+		}
+
+		r.buf = req.GetData()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
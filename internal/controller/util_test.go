@@ -1,17 +1,17 @@
 package controller
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
+	"github.com/project/library/generated/api/library"
 	"github.com/project/library/internal/entity"
-	"github.com/project/library/internal/usecase/library/mocks"
 	"github.com/stretchr/testify/require"
 
-	"go.uber.org/mock/gomock"
-	"go.uber.org/zap"
-
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -32,6 +32,91 @@ func TestControllerConvertError(t *testing.T) {
 			err:    entity.ErrAuthorNotFound,
 			status: codes.NotFound,
 		},
+		{
+			name:   "notification preferences not found error",
+			err:    entity.ErrNotificationPreferencesNotFound,
+			status: codes.NotFound,
+		},
+		{
+			name:   "branch not found error",
+			err:    entity.ErrBranchNotFound,
+			status: codes.NotFound,
+		},
+		{
+			name:   "copy not available error",
+			err:    entity.ErrCopyNotAvailable,
+			status: codes.FailedPrecondition,
+		},
+		{
+			name:   "review already exists error",
+			err:    entity.ErrReviewAlreadyExists,
+			status: codes.AlreadyExists,
+		},
+		{
+			name:   "invalid page token error",
+			err:    entity.ErrInvalidPageToken,
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "collection access denied error",
+			err:    entity.ErrCollectionAccessDenied,
+			status: codes.PermissionDenied,
+		},
+		{
+			name:   "copy not checked out error",
+			err:    entity.ErrCopyNotCheckedOut,
+			status: codes.FailedPrecondition,
+		},
+		{
+			name:   "invalid catalog error",
+			err:    entity.ErrInvalidCatalog,
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "catalog too large error",
+			err:    entity.ErrCatalogTooLarge,
+			status: codes.ResourceExhausted,
+		},
+		{
+			name:   "attachment not found error",
+			err:    entity.ErrAttachmentNotFound,
+			status: codes.NotFound,
+		},
+		{
+			name:   "unsupported content type error",
+			err:    entity.ErrUnsupportedContentType,
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "attachment too large error",
+			err:    entity.ErrAttachmentTooLarge,
+			status: codes.ResourceExhausted,
+		},
+		{
+			name:   "invalid e-book error",
+			err:    entity.ErrInvalidEbook,
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "too many ids error",
+			err:    entity.ErrTooManyIDs,
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "invalid batch error",
+			err:    entity.ErrInvalidBatch,
+			status: codes.InvalidArgument,
+		},
+		{
+			name:   "work not found error",
+			err:    entity.ErrWorkNotFound,
+			status: codes.NotFound,
+		},
+		{
+			name:   "idempotency key reused error",
+			err:    entity.ErrIdempotencyKeyReused,
+			status: codes.FailedPrecondition,
+		},
 		{
 			name:   "unknown error",
 			err:    errors.New("unknown error"),
@@ -42,13 +127,7 @@ func TestControllerConvertError(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			mockAuthorUseCase := mocks.NewMockAuthorUseCase(ctrl)
-			mockBookUseCase := mocks.NewMockBookUseCase(ctrl)
-
-			impl := New(&zap.Logger{}, mockBookUseCase, mockAuthorUseCase)
+			impl := getControllerData(t).impl
 
 			err := impl.convertError(tt.err)
 			s, ok := status.FromError(err)
@@ -57,3 +136,39 @@ func TestControllerConvertError(t *testing.T) {
 		})
 	}
 }
+
+func TestControllerIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	req := &library.RegisterAuthorRequest{Name: "Terry Pratchett"}
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyHeader, key))
+	}
+
+	key, err := idempotencyKey(context.Background(), "RegisterAuthor", req)
+	require.NoError(t, err)
+	require.Equal(t, entity.IdempotencyKey{}, key)
+
+	key, err = idempotencyKey(withKey("retry-1"), "RegisterAuthor", req)
+	require.NoError(t, err)
+	require.Equal(t, "retry-1", key.Key)
+	require.Equal(t, "RegisterAuthor", key.Method)
+
+	same, err := idempotencyKey(withKey("retry-1"), "RegisterAuthor", &library.RegisterAuthorRequest{Name: "Terry Pratchett"})
+	require.NoError(t, err)
+	require.Equal(t, key, same)
+
+	other, err := idempotencyKey(withKey("retry-1"), "RegisterAuthor", &library.RegisterAuthorRequest{Name: "Neil Gaiman"})
+	require.NoError(t, err)
+	require.NotEqual(t, key.RequestHash, other.RequestHash)
+	require.Empty(t, key.Actor)
+
+	scoped, err := idempotencyKey(entity.WithActor(withKey("retry-1"), "alice"), "RegisterAuthor", req)
+	require.NoError(t, err)
+	require.Equal(t, "alice", scoped.Actor)
+	require.Equal(t, key.RequestHash, scoped.RequestHash)
+
+	_, err = idempotencyKey(withKey(strings.Repeat("k", maxIdempotencyKeyLength+1)), "RegisterAuthor", req)
+	s, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, s.Code())
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	generated "github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func (i *implementation) WatchCatalog(req *generated.WatchCatalogRequest, server generated.Library_WatchCatalogServer) error {
+	if err := req.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// the gateway bridges wait for the headers to tell a rejected watch from one without changes yet
+	if err := server.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	entities := make([]entity.CatalogEntity, len(req.GetEntities()))
+	for j, changed := range req.GetEntities() {
+		entities[j] = entity.CatalogEntity(changed)
+	}
+
+	err := i.catalogUseCase.WatchCatalog(server.Context(), req.GetAfterSequence(), entities, entity.BookView(req.GetView()), server.Send)
+
+	if err != nil {
+		return i.convertError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/library/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type watchCatalogServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	changes []*library.WatchCatalogResponse
+}
+
+func (s *watchCatalogServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchCatalogServer) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *watchCatalogServer) Send(resp *library.WatchCatalogResponse) error {
+	s.changes = append(s.changes, resp)
+	return nil
+}
+
+func TestControllerWatchCatalog(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+
+	tests := []struct {
+		name         string
+		prepare      func(*mocks.MockCatalogUseCase)
+		req          *library.WatchCatalogRequest
+		expectedCode codes.Code
+		noError      bool
+	}{
+		{
+			name:         "negative sequence",
+			prepare:      emptyCatalogUseCasePrepare,
+			req:          &library.WatchCatalogRequest{AfterSequence: -1},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:         "unknown view",
+			prepare:      emptyCatalogUseCasePrepare,
+			req:          &library.WatchCatalogRequest{View: library.BookView(10)},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "unspecified entity",
+			prepare: emptyCatalogUseCasePrepare,
+			req: &library.WatchCatalogRequest{
+				Entities: []library.CatalogEntity{library.CatalogEntity_CATALOG_ENTITY_UNSPECIFIED},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name:    "repeated entity",
+			prepare: emptyCatalogUseCasePrepare,
+			req: &library.WatchCatalogRequest{
+				Entities: []library.CatalogEntity{library.CatalogEntity_CATALOG_ENTITY_BOOK, library.CatalogEntity_CATALOG_ENTITY_BOOK},
+			},
+			expectedCode: codes.InvalidArgument,
+			noError:      false,
+		},
+		{
+			name: "use case error",
+			prepare: func(mock *mocks.MockCatalogUseCase) {
+				mock.EXPECT().WatchCatalog(ctx, int64(0), []entity.CatalogEntity{}, entity.BookViewUndefined, gomock.Any()).Return(errors.New("connection refused"))
+			},
+			req:          &library.WatchCatalogRequest{},
+			expectedCode: codes.Internal,
+			noError:      false,
+		},
+		{
+			name: "success",
+			prepare: func(mock *mocks.MockCatalogUseCase) {
+				mock.EXPECT().WatchCatalog(ctx, int64(41), []entity.CatalogEntity{entity.CatalogEntityBook}, entity.BookViewFull, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ int64, _ []entity.CatalogEntity, _ entity.BookView, send func(*library.WatchCatalogResponse) error) error {
+						return send(&library.WatchCatalogResponse{
+							Sequence: 42,
+							Entity:   library.CatalogEntity_CATALOG_ENTITY_BOOK,
+							Kind:     library.ChangeKind_CHANGE_KIND_DELETED,
+							Id:       bookID,
+						})
+					})
+			},
+			req: &library.WatchCatalogRequest{
+				AfterSequence: 41,
+				View:          library.BookView_BOOK_VIEW_FULL,
+				Entities:      []library.CatalogEntity{library.CatalogEntity_CATALOG_ENTITY_BOOK},
+			},
+			expectedCode: codes.OK,
+			noError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getControllerData(t)
+			tt.prepare(data.catalogUseCase)
+
+			server := &watchCatalogServer{ctx: ctx}
+			err := data.impl.WatchCatalog(tt.req, server)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Len(t, server.changes, 1)
+				require.Equal(t, int64(42), server.changes[0].GetSequence())
+				require.Equal(t, bookID, server.changes[0].GetId())
+			} else {
+				s, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedCode, s.Code())
+			}
+		})
+	}
+}
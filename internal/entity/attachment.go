@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type AttachmentKind int
+
+const (
+	AttachmentKindUndefined AttachmentKind = iota
+	AttachmentKindCover
+	AttachmentKindFile
+)
+
+// Attachment is a file stored in the blob store, a book has at most one cover.
+// ThumbnailKey is set for covers only.
+type Attachment struct {
+	ID           string
+	BookID       string
+	Kind         AttachmentKind
+	Name         string
+	ContentType  string
+	Size         int64
+	BlobKey      string
+	ThumbnailKey string
+	CreatedAt    time.Time
+}
+
+var (
+	ErrAttachmentNotFound     = errors.New("attachment not found")
+	ErrAttachmentTooLarge     = errors.New("attachment is too large")
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+	ErrInvalidAttachment      = errors.New("invalid attachment")
+	ErrBlobNotFound           = errors.New("blob not found")
+)
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// AuditEvent is an insert, update or delete of a library table row. Before and After are JSON objects
+// of the row columns, an update keeps only the changed ones.
+type AuditEvent struct {
+	ID        string
+	Actor     string
+	Entity    string
+	EntityID  string
+	Action    ChangeKind
+	Before    []byte
+	After     []byte
+	CreatedAt time.Time
+}
+
+// AuditFilter narrows the audit log down, zero From and To leave the time range open.
+type AuditFilter struct {
+	Entity   string
+	EntityID string
+	Actor    string
+	From     time.Time
+	To       time.Time
+}
+
+type actorKey struct{}
+
+// WithActor stores the actor of a request, the audit log records it with every change made under ctx.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, empty for requests without one.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
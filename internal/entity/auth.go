@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+type AuthMethod int
+
+const (
+	AuthMethodUndefined AuthMethod = iota
+	AuthMethodJWT
+	AuthMethodAPIKey
+)
+
+func (m AuthMethod) String() string {
+	switch m {
+	case AuthMethodJWT:
+		return "jwt"
+	case AuthMethodAPIKey:
+		return "api_key"
+	default:
+		return "undefined"
+	}
+}
+
+// Principal is the authenticated caller of a request: the subject of a JWT or the name of an API key.
+// Admin callers may read and restore the trash.
+type Principal struct {
+	Subject string
+	Method  AuthMethod
+	Admin   bool
+}
+
+var (
+	ErrUnauthenticated = errors.New("unauthenticated")
+)
+
+type principalKey struct{}
+
+// WithPrincipal stores the authenticated caller of a request.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored by WithPrincipal, false when authentication is off.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
@@ -1,14 +1,31 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 )
 
 type Author struct {
 	ID   string
 	Name string
+	// DeletedAt is set for an author in the trash.
+	DeletedAt time.Time
+}
+
+type CoAuthor struct {
+	Author      Author
+	SharedBooks int
+}
+
+// CollaborationStep is a single hop of a collaboration path,
+// BookID is the book shared with the previous author and is empty for the first step.
+type CollaborationStep struct {
+	Author Author
+	BookID string
 }
 
 var (
-	ErrAuthorNotFound = errors.New("author not found")
+	ErrAuthorNotFound            = errors.New("author not found")
+	ErrCollaborationPathNotFound = errors.New("authors are not connected within the depth limit")
 )
@@ -0,0 +1,32 @@
+package entity
+
+import "github.com/pkg/errors"
+
+type BatchOperationKind int
+
+const (
+	BatchOperationUndefined BatchOperationKind = iota
+	BatchOperationCreateAuthor
+	BatchOperationUpdateAuthor
+	BatchOperationDeleteAuthor
+	BatchOperationCreateBook
+	BatchOperationUpdateBook
+	BatchOperationDeleteBook
+)
+
+// BatchOperation is one step of a batch write. TempID names the author or book being created
+// so that later operations may use it in ID, AuthorIDs and Contributors instead of a uuid.
+type BatchOperation struct {
+	Kind         BatchOperationKind
+	TempID       string
+	ID           string
+	Name         string
+	AuthorIDs    []string
+	Contributors []Contributor
+}
+
+var (
+	// ErrTooManyIDs is returned when a batch request asks for more ids than the service reads at once.
+	ErrTooManyIDs   = errors.New("too many ids")
+	ErrInvalidBatch = errors.New("invalid batch")
+)
@@ -7,13 +7,52 @@ import (
 )
 
 type Book struct {
-	ID        string
-	Name      string
-	AuthorIDs []string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID              string
+	Name            string
+	AuthorIDs       []string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RatingAverage   float64
+	RatingCount     int
+	ISBN            string
+	Publisher       string
+	PublicationYear int
+	WorkID          string
+	Language        string
+	Translator      string
+	Contributors    []Contributor
+	// DeletedAt is set for a book in the trash.
+	DeletedAt time.Time
 }
 
+// ContributorRole is the part a person took in a book. Authors are also listed in Book.AuthorIDs,
+// contributors with other roles are only listed in Book.Contributors.
+type ContributorRole int
+
+const (
+	ContributorRoleUndefined ContributorRole = iota
+	ContributorRoleAuthor
+	ContributorRoleTranslator
+	ContributorRoleEditor
+	ContributorRoleIllustrator
+	ContributorRoleNarrator
+)
+
+type Contributor struct {
+	AuthorID string
+	Role     ContributorRole
+}
+
+// BookView tells how much of a book a response carries, BookViewFull adds the names
+// of its authors and contributors. BookViewUndefined is the same as BookViewBasic.
+type BookView int
+
+const (
+	BookViewUndefined BookView = iota
+	BookViewBasic
+	BookViewFull
+)
+
 var (
 	ErrBookNotFound = errors.New("book not found")
 )
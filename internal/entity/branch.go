@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type Branch struct {
+	ID      string
+	Name    string
+	Address string
+}
+
+type Patron struct {
+	ID           string
+	Name         string
+	HomeBranchID string
+}
+
+type CopyStatus int
+
+const (
+	CopyStatusUndefined CopyStatus = iota
+	CopyStatusAvailable
+	CopyStatusInTransit
+	CopyStatusCheckedOut
+)
+
+type BookCopy struct {
+	ID       string
+	BookID   string
+	BranchID string
+	Status   CopyStatus
+}
+
+type TransferStatus int
+
+const (
+	TransferStatusUndefined TransferStatus = iota
+	TransferStatusInTransit
+	TransferStatusReceived
+)
+
+type Transfer struct {
+	ID           string
+	CopyID       string
+	FromBranchID string
+	ToBranchID   string
+	Status       TransferStatus
+	CreatedAt    time.Time
+	ReceivedAt   time.Time
+}
+
+type Loan struct {
+	ID         string
+	CopyID     string
+	BookID     string
+	PatronID   string
+	BorrowedAt time.Time
+	ReturnedAt time.Time
+}
+
+type BranchAvailability struct {
+	BranchID  string
+	Available int
+	InTransit int
+}
+
+var (
+	ErrBranchNotFound          = errors.New("branch not found")
+	ErrPatronNotFound          = errors.New("patron not found")
+	ErrCopyNotFound            = errors.New("book copy not found")
+	ErrCopyNotAvailable        = errors.New("book copy is not available")
+	ErrCopyAlreadyAtBranch     = errors.New("book copy is already at this branch")
+	ErrTransferNotFound        = errors.New("transfer not found")
+	ErrTransferAlreadyReceived = errors.New("transfer already received")
+	ErrCopyNotCheckedOut       = errors.New("book copy is not checked out")
+)
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"github.com/pkg/errors"
+)
+
+type CatalogFormat int
+
+const (
+	CatalogFormatUndefined CatalogFormat = iota
+	CatalogFormatCSV
+	CatalogFormatJSONL
+	CatalogFormatMARC21
+	CatalogFormatMARCXML
+	CatalogFormatBibTeX
+	CatalogFormatRIS
+)
+
+// CatalogRow is a single book of an imported catalog, Line is its position in the source:
+// the line for text formats and the record number for MARC.
+type CatalogRow struct {
+	Line            int
+	Name            string
+	AuthorNames     []string
+	ISBN            string
+	Publisher       string
+	PublicationYear int
+}
+
+// CatalogBook is a book with its author names resolved, used by exports.
+type CatalogBook struct {
+	Book        Book
+	AuthorNames []string
+}
+
+type CatalogRowError struct {
+	Line    int
+	Message string
+}
+
+type CatalogImport struct {
+	Rows           int
+	Books          []Book
+	CreatedAuthors []Author
+	Errors         []CatalogRowError
+}
+
+// CatalogAuthor and CatalogSubject are entries of catalog navigation with the number of their books.
+type CatalogAuthor struct {
+	Author Author
+	Books  int
+}
+
+type CatalogSubject struct {
+	Name  string
+	Books int
+}
+
+// CatalogFilter narrows a catalog export, zero values disable a condition.
+type CatalogFilter struct {
+	AuthorID            string
+	NameQuery           string
+	Subject             string
+	PublicationYearFrom int
+	PublicationYearTo   int
+	// CollapseWorks keeps one edition per work, the one with the smallest id among the matching ones.
+	CollapseWorks bool
+}
+
+var (
+	ErrUnsupportedCatalogFormat = errors.New("unsupported catalog format")
+	ErrInvalidCatalog           = errors.New("invalid catalog")
+	ErrCatalogTooLarge          = errors.New("catalog has too many rows")
+)
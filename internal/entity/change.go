@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type CatalogEntity int
+
+const (
+	CatalogEntityUndefined CatalogEntity = iota
+	CatalogEntityBook
+	CatalogEntityAuthor
+)
+
+type ChangeKind int
+
+const (
+	ChangeKindUndefined ChangeKind = iota
+	ChangeKindCreated
+	ChangeKindUpdated
+	ChangeKindDeleted
+)
+
+// CatalogChange is a create, update or delete of a book or an author. Sequence grows in commit order,
+// so a client that has seen every change up to a sequence resumes after it without gaps.
+type CatalogChange struct {
+	Sequence  int64
+	Entity    CatalogEntity
+	Kind      ChangeKind
+	EntityID  string
+	ChangedAt time.Time
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type CollectionVisibility int
+
+const (
+	CollectionVisibilityUndefined CollectionVisibility = iota
+	CollectionVisibilityPrivate
+	CollectionVisibilityPublic
+)
+
+type CollectionItem struct {
+	Book Book
+	Note string
+}
+
+type Collection struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	Description string
+	Visibility  CollectionVisibility
+	Items       []CollectionItem
+	SharedWith  []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// VisibleTo reports whether the patron may read the collection,
+// an empty patronID stands for an anonymous reader.
+func (c Collection) VisibleTo(patronID string) bool {
+	if c.Visibility == CollectionVisibilityPublic {
+		return true
+	}
+
+	if patronID == "" {
+		return false
+	}
+
+	return c.OwnerID == patronID || slices.Contains(c.SharedWith, patronID)
+}
+
+// BookIDs returns ids of the collection books in their current order.
+func (c Collection) BookIDs() []string {
+	result := make([]string, len(c.Items))
+	for i, item := range c.Items {
+		result[i] = item.Book.ID
+	}
+
+	return result
+}
+
+type CollectionFilter struct {
+	ViewerID string
+	OwnerID  string
+}
+
+var (
+	ErrCollectionNotFound      = errors.New("collection not found")
+	ErrCollectionAccessDenied  = errors.New("collection belongs to another patron")
+	ErrBookAlreadyInCollection = errors.New("book already in collection")
+	ErrBookNotInCollection     = errors.New("book not in collection")
+	ErrInvalidCollectionOrder  = errors.New("order must contain every collection book exactly once")
+)
@@ -0,0 +1,27 @@
+package entity
+
+import "github.com/pkg/errors"
+
+// EbookCreator is a creator listed in the e-book metadata, Role is a MARC relator code
+// such as "aut" or "edt" and is empty when the file does not specify it.
+type EbookCreator struct {
+	Name string
+	Role string
+}
+
+// EbookMetadata is what could be read from an EPUB package document or a PDF document
+// information dictionary. Cover is set for EPUB files that declare a cover image.
+type EbookMetadata struct {
+	ContentType      string
+	Title            string
+	Creators         []EbookCreator
+	Language         string
+	Identifiers      []string
+	ISBN             string
+	Publisher        string
+	PublicationYear  int
+	Cover            []byte
+	CoverContentType string
+}
+
+var ErrInvalidEbook = errors.New("invalid e-book")
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// BookVersion is a state of a book with its contributors, valid in [ValidFrom, ValidTo).
+// ValidTo is zero for the current state.
+type BookVersion struct {
+	Book      Book
+	ValidFrom time.Time
+	ValidTo   time.Time
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "github.com/pkg/errors"
+
+// IdempotencyKey is the client supplied key of a create request. Keys are scoped by Actor and Method,
+// so that callers never get the responses of each other, RequestHash tells a retry of the same request
+// from a reuse of the key for another one.
+type IdempotencyKey struct {
+	Key         string
+	Actor       string
+	Method      string
+	RequestHash string
+}
+
+// IdempotencyRecord is the stored response of the first request made with a key.
+type IdempotencyRecord struct {
+	IdempotencyKey
+	Response []byte
+}
+
+var (
+	ErrIdempotencyRecordNotFound = errors.New("idempotency record not found")
+	ErrIdempotencyKeyReused      = errors.New("idempotency key is already used for another request")
+)
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type NotificationKind int
+
+const (
+	NotificationKindUndefined NotificationKind = iota
+	NotificationKindDueSoon
+	NotificationKindOverdue
+	NotificationKindHoldAvailable
+)
+
+func (n NotificationKind) String() string {
+	switch n {
+	case NotificationKindDueSoon:
+		return "due_soon"
+	case NotificationKindOverdue:
+		return "overdue"
+	case NotificationKindHoldAvailable:
+		return "hold_available"
+	default:
+		return "undefined"
+	}
+}
+
+type NotificationChannel int
+
+const (
+	NotificationChannelUndefined NotificationChannel = iota
+	NotificationChannelEmail
+	NotificationChannelWebhook
+)
+
+func (n NotificationChannel) String() string {
+	switch n {
+	case NotificationChannelEmail:
+		return "email"
+	case NotificationChannelWebhook:
+		return "webhook"
+	default:
+		return "undefined"
+	}
+}
+
+type Notification struct {
+	ID       string
+	PatronID string
+	BookID   string
+	Kind     NotificationKind
+	DueAt    time.Time
+	SendAt   time.Time
+}
+
+type NotificationPreferences struct {
+	PatronID   string
+	Channels   []NotificationChannel
+	Email      string
+	WebhookURL string
+}
+
+var (
+	ErrNotificationPreferencesNotFound = errors.New("notification preferences not found")
+)
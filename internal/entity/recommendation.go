@@ -0,0 +1,13 @@
+package entity
+
+type Recommendation struct {
+	Book  Book
+	Score float64
+}
+
+// RecommendationWeights sets how much every kind of shared signal adds to the score of a candidate book.
+type RecommendationWeights struct {
+	SharedAuthor  float64
+	SharedSubject float64
+	CoBorrowed    float64
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type ReviewStatus int
+
+const (
+	ReviewStatusUndefined ReviewStatus = iota
+	ReviewStatusPending
+	ReviewStatusApproved
+	ReviewStatusRejected
+)
+
+type Review struct {
+	ID        string
+	BookID    string
+	PatronID  string
+	Rating    int
+	Text      string
+	Status    ReviewStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CountsInRating reports whether the review contributes to the book rating aggregate.
+func (r Review) CountsInRating() bool {
+	return r.Status == ReviewStatusApproved
+}
+
+type ReviewFilter struct {
+	BookID   string
+	PatronID string
+	Status   ReviewStatus
+}
+
+// PageCursor points at the last element of a page ordered by (CreatedAt, ID).
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+var (
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrReviewAlreadyExists = errors.New("patron already reviewed this book")
+	ErrInvalidPageToken    = errors.New("invalid page token")
+)
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Work groups the editions of one title, every edition is a Book.
+type Work struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Edition holds the attributes that tell editions of a work apart.
+// An empty WorkID detaches the book from its work.
+type Edition struct {
+	WorkID          string
+	ISBN            string
+	Publisher       string
+	PublicationYear int
+	Language        string
+	Translator      string
+}
+
+var (
+	ErrWorkNotFound = errors.New("work not found")
+)
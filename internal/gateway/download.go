@@ -0,0 +1,199 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	grpcruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	generated "github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/catalog"
+	"github.com/project/library/internal/usecase/media"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CatalogDownloadPath    = "/v1/library/catalog/download"
+	AttachmentDownloadPath = "/v1/library/attachment/{id}/download"
+
+	// collapseWorks is the value of the collapse query parameter that keeps one edition per work.
+	collapseWorks = "works"
+)
+
+// CatalogDownload serves ExportCatalog as a file: chunks are written to the body
+// as they arrive instead of being wrapped into JSON messages by the gateway.
+func CatalogDownload(client generated.LibraryClient) grpcruntime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		req, err := parseExportCatalogRequest(r.URL.Query())
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		stream, err := client.ExportCatalog(outgoingContext(r), req)
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		// validation errors come with the first message, before anything is written
+		resp, err := stream.Recv()
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, err)
+			return
+		}
+
+		format := entity.CatalogFormat(req.GetFormat())
+		extension := catalog.FileExtension(format)
+
+		w.Header().Set("Content-Type", catalog.ContentType(format))
+		w.Header().Set("Content-Disposition", `attachment; filename="catalog.`+extension+`"`)
+
+		writeStream(w, resp, err, stream.Recv)
+	}
+}
+
+// AttachmentDownload serves DownloadAttachment as a file, "?thumbnail=true" returns the cover thumbnail.
+func AttachmentDownload(client generated.LibraryClient) grpcruntime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		thumbnail := false
+
+		if value := r.URL.Query().Get("thumbnail"); value != "" {
+			var err error
+			if thumbnail, err = strconv.ParseBool(value); err != nil {
+				http.Error(w, "thumbnail: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		stream, err := client.DownloadAttachment(outgoingContext(r), &generated.DownloadAttachmentRequest{
+			Id:        pathParams["id"],
+			Thumbnail: thumbnail,
+		})
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		// the first message carries the attachment itself
+		resp, err := stream.Recv()
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		attachment := resp.GetAttachment()
+		contentType := attachment.GetContentType()
+		disposition := "attachment"
+
+		if thumbnail {
+			contentType = media.ThumbnailContentType
+		} else {
+			w.Header().Set("Content-Length", strconv.FormatInt(attachment.GetSize(), 10))
+		}
+
+		if strings.HasPrefix(contentType, "image/") {
+			disposition = "inline"
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{
+			"filename": attachment.GetName(),
+		}))
+
+		resp, err = stream.Recv()
+		writeStream(w, resp, err, stream.Recv)
+	}
+}
+
+type dataResponse interface {
+	GetData() []byte
+}
+
+// writeStream writes the data of a server stream to the body, first and err are the result of the first Recv.
+func writeStream[T dataResponse](w http.ResponseWriter, first T, err error, recv func() (T, error)) {
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	for resp := first; err == nil; resp, err = recv() {
+		if _, err = w.Write(resp.GetData()); err != nil {
+			return
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	if !errors.Is(err, io.EOF) {
+		// the status is already sent, abort so that the client does not take a truncated file
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func parseExportCatalogRequest(query url.Values) (*generated.ExportCatalogRequest, error) {
+	format, err := catalog.FormatByName(query.Get("format"))
+
+	if err != nil {
+		return nil, fmt.Errorf("format: %w", err)
+	}
+
+	req := &generated.ExportCatalogRequest{
+		Format:    generated.CatalogFormat(format),
+		AuthorId:  query.Get("author_id"),
+		NameQuery: query.Get("name_query"),
+		Subject:   query.Get("subject"),
+	}
+
+	if req.CollapseWorks, err = parseCollapse(query.Get("collapse")); err != nil {
+		return nil, fmt.Errorf("collapse: %w", err)
+	}
+
+	if req.PublicationYearFrom, err = parseYear(query.Get("publication_year_from")); err != nil {
+		return nil, fmt.Errorf("publication_year_from: %w", err)
+	}
+
+	if req.PublicationYearTo, err = parseYear(query.Get("publication_year_to")); err != nil {
+		return nil, fmt.Errorf("publication_year_to: %w", err)
+	}
+
+	return req, nil
+}
+
+// parseCollapse accepts "works" and an empty value, the only grouping supported so far.
+func parseCollapse(value string) (bool, error) {
+	switch value {
+	case "":
+		return false, nil
+	case collapseWorks:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown grouping %q", value)
+	}
+}
+
+func parseYear(value string) (int32, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	year, err := strconv.ParseInt(value, 10, 32)
+
+	return int32(year), err
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	http.Error(w, s.Message(), grpcruntime.HTTPStatusFromCode(s.Code()))
+}
@@ -0,0 +1,239 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	generated "github.com/project/library/generated/api/library"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type exportCatalogClient struct {
+	generated.LibraryClient
+	request *generated.ExportCatalogRequest
+	stream  *exportCatalogStream
+}
+
+func (c *exportCatalogClient) ExportCatalog(
+	_ context.Context,
+	req *generated.ExportCatalogRequest,
+	_ ...grpc.CallOption,
+) (generated.Library_ExportCatalogClient, error) {
+	c.request = req
+	return c.stream, nil
+}
+
+type exportCatalogStream struct {
+	grpc.ClientStream
+	chunks []string
+	err    error
+}
+
+func (s *exportCatalogStream) Recv() (*generated.ExportCatalogResponse, error) {
+	if len(s.chunks) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+
+		return nil, io.EOF
+	}
+
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+
+	return &generated.ExportCatalogResponse{Data: []byte(chunk)}, nil
+}
+
+func TestCatalogDownload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		query        string
+		stream       *exportCatalogStream
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "unknown format",
+			query:        "format=pdf",
+			stream:       &exportCatalogStream{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid year",
+			query:        "format=csv&publication_year_from=soon",
+			stream:       &exportCatalogStream{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unknown grouping",
+			query:        "format=csv&collapse=authors",
+			stream:       &exportCatalogStream{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid argument from server",
+			query:        "format=csv&author_id=1",
+			stream:       &exportCatalogStream{err: status.Error(codes.InvalidArgument, "invalid author_id")},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "success",
+			query:        "format=csv&subject=fantasy&publication_year_to=2000&collapse=works",
+			stream:       &exportCatalogStream{chunks: []string{"id,name\n", "1,Dune\n"}},
+			expectedCode: http.StatusOK,
+			expectedBody: "id,name\n1,Dune\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := &exportCatalogClient{stream: tt.stream}
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, CatalogDownloadPath+"?"+tt.query, nil)
+
+			CatalogDownload(client)(recorder, req, nil)
+
+			require.Equal(t, tt.expectedCode, recorder.Code)
+			if tt.expectedCode == http.StatusOK {
+				require.Equal(t, tt.expectedBody, recorder.Body.String())
+				require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename="catalog.csv"`, recorder.Header().Get("Content-Disposition"))
+				require.Equal(t, "fantasy", client.request.GetSubject())
+				require.Equal(t, int32(2000), client.request.GetPublicationYearTo())
+				require.True(t, client.request.GetCollapseWorks())
+			}
+		})
+	}
+}
+
+func TestCatalogDownloadAbortsOnStreamError(t *testing.T) {
+	t.Parallel()
+	client := &exportCatalogClient{
+		stream: &exportCatalogStream{
+			chunks: []string{"id,name\n"},
+			err:    status.Error(codes.Internal, "connection lost"),
+		},
+	}
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, CatalogDownloadPath+"?format=csv", nil)
+
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		CatalogDownload(client)(recorder, req, nil)
+	})
+}
+
+type downloadAttachmentClient struct {
+	generated.LibraryClient
+	request   *generated.DownloadAttachmentRequest
+	responses []*generated.DownloadAttachmentResponse
+	err       error
+}
+
+func (c *downloadAttachmentClient) DownloadAttachment(
+	_ context.Context,
+	req *generated.DownloadAttachmentRequest,
+	_ ...grpc.CallOption,
+) (generated.Library_DownloadAttachmentClient, error) {
+	c.request = req
+	return &downloadAttachmentStream{client: c}, nil
+}
+
+type downloadAttachmentStream struct {
+	grpc.ClientStream
+	client *downloadAttachmentClient
+}
+
+func (s *downloadAttachmentStream) Recv() (*generated.DownloadAttachmentResponse, error) {
+	if len(s.client.responses) == 0 {
+		if s.client.err != nil {
+			return nil, s.client.err
+		}
+
+		return nil, io.EOF
+	}
+
+	resp := s.client.responses[0]
+	s.client.responses = s.client.responses[1:]
+
+	return resp, nil
+}
+
+func TestAttachmentDownload(t *testing.T) {
+	t.Parallel()
+	attachment := &generated.Attachment{
+		Id:          "6b1f4ad2-3c3e-4d8a-9a51-0a0c4f3c2b10",
+		Name:        "Dune cover.png",
+		ContentType: "image/png",
+		Size:        5,
+	}
+
+	tests := []struct {
+		name                string
+		query               string
+		client              *downloadAttachmentClient
+		expectedCode        int
+		expectedType        string
+		expectedDisposition string
+	}{
+		{
+			name:         "invalid thumbnail",
+			query:        "thumbnail=maybe",
+			client:       &downloadAttachmentClient{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "not found",
+			client:       &downloadAttachmentClient{err: status.Error(codes.NotFound, "attachment not found")},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "original",
+			client: &downloadAttachmentClient{responses: []*generated.DownloadAttachmentResponse{
+				{Attachment: attachment},
+				{Data: []byte("im")},
+				{Data: []byte("age")},
+			}},
+			expectedCode:        http.StatusOK,
+			expectedType:        "image/png",
+			expectedDisposition: `inline; filename="Dune cover.png"`,
+		},
+		{
+			name:  "thumbnail",
+			query: "thumbnail=true",
+			client: &downloadAttachmentClient{responses: []*generated.DownloadAttachmentResponse{
+				{Attachment: attachment},
+				{Data: []byte("image")},
+			}},
+			expectedCode:        http.StatusOK,
+			expectedType:        "image/jpeg",
+			expectedDisposition: `inline; filename="Dune cover.png"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/library/attachment/"+attachment.GetId()+"/download?"+tt.query, nil)
+
+			AttachmentDownload(tt.client)(recorder, req, map[string]string{"id": attachment.GetId()})
+
+			require.Equal(t, tt.expectedCode, recorder.Code)
+			if tt.expectedCode == http.StatusOK {
+				require.Equal(t, "image", recorder.Body.String())
+				require.Equal(t, tt.expectedType, recorder.Header().Get("Content-Type"))
+				require.Equal(t, tt.expectedDisposition, recorder.Header().Get("Content-Disposition"))
+				require.Equal(t, attachment.GetId(), tt.client.request.GetId())
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	grpcruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	generated "github.com/project/library/generated/api/library"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	CatalogEventsPath          = "/v1/library/catalog/events"
+	CatalogEventsWebSocketPath = "/v1/library/catalog/events/ws"
+
+	// eventsKeepAlive is how often an idle event stream gets a comment, so that proxies do not close it.
+	eventsKeepAlive = 15 * time.Second
+
+	// websocketInternalError is the close code sent when the watch fails.
+	websocketInternalError = 1011
+)
+
+var catalogEntityNames = map[string]generated.CatalogEntity{
+	"book":   generated.CatalogEntity_CATALOG_ENTITY_BOOK,
+	"author": generated.CatalogEntity_CATALOG_ENTITY_AUTHOR,
+}
+
+var bookViewNames = map[string]generated.BookView{
+	"basic": generated.BookView_BOOK_VIEW_BASIC,
+	"full":  generated.BookView_BOOK_VIEW_FULL,
+}
+
+// CatalogEvents serves WatchCatalog as Server-Sent Events. The id of an event is the change sequence,
+// so a reconnecting EventSource resumes after the last event it got through Last-Event-ID.
+func CatalogEvents(client generated.LibraryClient) grpcruntime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		req, err := parseWatchCatalogRequest(r.URL.Query(), r.Header.Get("Last-Event-ID"))
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(outgoingContext(r))
+		defer cancel()
+
+		stream, err := watchCatalog(ctx, client, req)
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		changes, errs := recvChanges(ctx, stream)
+		flusher, _ := w.(http.Flusher)
+		ticker := time.NewTicker(eventsKeepAlive)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case change := <-changes:
+				data, err := protojson.Marshal(change)
+
+				if err != nil {
+					return
+				}
+
+				_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", change.GetSequence(), data)
+
+				if err != nil {
+					return
+				}
+			case <-ticker.C:
+				if _, err = io.WriteString(w, ": keepalive\n\n"); err != nil {
+					return
+				}
+			case err = <-errs:
+				if status.Code(err) != codes.Canceled {
+					message := strings.ReplaceAll(status.Convert(err).Message(), "\n", " ")
+					_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", message)
+				}
+
+				if flusher != nil {
+					flusher.Flush()
+				}
+
+				return
+			}
+
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// CatalogEventsWebSocket serves WatchCatalog over a WebSocket, every change is a JSON text message.
+// The watch is opened before the upgrade, so a rejected request gets a plain http error.
+// Browsers let any page open a WebSocket, so only the pages of the gateway host
+// and of the allowed origins are accepted.
+func CatalogEventsWebSocket(client generated.LibraryClient, allowedOrigins []string) grpcruntime.HandlerFunc {
+	handshake := func(_ *websocket.Config, r *http.Request) error {
+		return checkOrigin(r, allowedOrigins)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		if err := checkOrigin(r, allowedOrigins); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		req, err := parseWatchCatalogRequest(r.URL.Query(), "")
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(outgoingContext(r))
+		defer cancel()
+
+		stream, err := watchCatalog(ctx, client, req)
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		websocket.Server{Handshake: handshake, Handler: func(conn *websocket.Conn) {
+			// the client sends nothing, the read returns once it closes the connection
+			go func() {
+				_, _ = io.Copy(io.Discard, conn)
+				cancel()
+			}()
+
+			for {
+				change, err := stream.Recv()
+
+				if err != nil {
+					if status.Code(err) != codes.Canceled {
+						_ = conn.WriteClose(websocketInternalError)
+					}
+
+					return
+				}
+
+				data, err := protojson.Marshal(change)
+
+				if err != nil {
+					return
+				}
+
+				if err = websocket.Message.Send(conn, string(data)); err != nil {
+					return
+				}
+			}
+		}}.ServeHTTP(w, r)
+	}
+}
+
+// checkOrigin accepts the Origin of the gateway host itself or one of the allowed origins.
+// Like websocket.Handler it rejects requests without an Origin.
+func checkOrigin(r *http.Request, allowedOrigins []string) error {
+	origin := r.Header.Get("Origin")
+
+	if origin == "" {
+		return errors.New("missing origin")
+	}
+
+	parsed, err := url.Parse(origin)
+
+	if err != nil {
+		return fmt.Errorf("invalid origin %q", origin)
+	}
+
+	if parsed.Host == r.Host || slices.Contains(allowedOrigins, origin) {
+		return nil
+	}
+
+	return fmt.Errorf("origin %q is not allowed", origin)
+}
+
+// watchCatalog opens the watch and waits for the headers, which the server sends once the request is accepted.
+// A stream rejected without headers carries its status in the first Recv.
+func watchCatalog(
+	ctx context.Context,
+	client generated.LibraryClient,
+	req *generated.WatchCatalogRequest,
+) (generated.Library_WatchCatalogClient, error) {
+	stream, err := client.WatchCatalog(ctx, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := stream.Header()
+
+	if err == nil && md == nil {
+		_, err = stream.Recv()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// recvChanges moves the changes of the stream to a channel, so that the sender can wait for them and a timer at once.
+func recvChanges(
+	ctx context.Context,
+	stream generated.Library_WatchCatalogClient,
+) (<-chan *generated.WatchCatalogResponse, <-chan error) {
+	changes := make(chan *generated.WatchCatalogResponse)
+	errs := make(chan error, 1)
+
+	go func() {
+		for {
+			change, err := stream.Recv()
+
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, errs
+}
+
+// parseWatchCatalogRequest reads after_sequence, view and the repeated entity parameter.
+// Last-Event-ID of a reconnecting EventSource is newer than the url it reconnects to and takes precedence.
+func parseWatchCatalogRequest(query url.Values, lastEventID string) (*generated.WatchCatalogRequest, error) {
+	req := &generated.WatchCatalogRequest{}
+
+	after := query.Get("after_sequence")
+	if lastEventID != "" {
+		after = lastEventID
+	}
+
+	if after != "" {
+		var err error
+		if req.AfterSequence, err = strconv.ParseInt(after, 10, 64); err != nil {
+			return nil, fmt.Errorf("after_sequence: %w", err)
+		}
+	}
+
+	for _, name := range query["entity"] {
+		changed, ok := catalogEntityNames[strings.ToLower(name)]
+
+		if !ok {
+			return nil, fmt.Errorf("entity: unknown entity %q", name)
+		}
+
+		req.Entities = append(req.Entities, changed)
+	}
+
+	if name := query.Get("view"); name != "" {
+		view, ok := bookViewNames[strings.ToLower(name)]
+
+		if !ok {
+			return nil, fmt.Errorf("view: unknown view %q", name)
+		}
+
+		req.View = view
+	}
+
+	return req, nil
+}
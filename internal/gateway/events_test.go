@@ -0,0 +1,233 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	generated "github.com/project/library/generated/api/library"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type watchCatalogClient struct {
+	generated.LibraryClient
+	request *generated.WatchCatalogRequest
+	stream  *watchCatalogStream
+}
+
+func (c *watchCatalogClient) WatchCatalog(
+	_ context.Context,
+	req *generated.WatchCatalogRequest,
+	_ ...grpc.CallOption,
+) (generated.Library_WatchCatalogClient, error) {
+	c.request = req
+	return c.stream, nil
+}
+
+// watchCatalogStream sends the changes and then ends with err, a stream without header is rejected.
+type watchCatalogStream struct {
+	grpc.ClientStream
+	header  metadata.MD
+	changes []*generated.WatchCatalogResponse
+	err     error
+}
+
+func (s *watchCatalogStream) Header() (metadata.MD, error) {
+	return s.header, nil
+}
+
+func (s *watchCatalogStream) Recv() (*generated.WatchCatalogResponse, error) {
+	if len(s.changes) == 0 {
+		return nil, s.err
+	}
+
+	change := s.changes[0]
+	s.changes = s.changes[1:]
+
+	return change, nil
+}
+
+func testChanges() []*generated.WatchCatalogResponse {
+	return []*generated.WatchCatalogResponse{
+		{
+			Sequence: 12,
+			Entity:   generated.CatalogEntity_CATALOG_ENTITY_BOOK,
+			Kind:     generated.ChangeKind_CHANGE_KIND_CREATED,
+			Id:       "5d4b9c3e-63b8-4c9b-9a43-6b3a1f2f0c11",
+			Book:     &generated.Book{Id: "5d4b9c3e-63b8-4c9b-9a43-6b3a1f2f0c11", Name: "Mort"},
+		},
+		{
+			Sequence: 13,
+			Entity:   generated.CatalogEntity_CATALOG_ENTITY_BOOK,
+			Kind:     generated.ChangeKind_CHANGE_KIND_DELETED,
+			Id:       "5d4b9c3e-63b8-4c9b-9a43-6b3a1f2f0c11",
+		},
+	}
+}
+
+func TestCatalogEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		query        string
+		lastEventID  string
+		stream       *watchCatalogStream
+		expectedCode int
+	}{
+		{
+			name:         "invalid sequence",
+			query:        "after_sequence=last",
+			stream:       &watchCatalogStream{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unknown entity",
+			query:        "entity=review",
+			stream:       &watchCatalogStream{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unknown view",
+			query:        "view=short",
+			stream:       &watchCatalogStream{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid argument from server",
+			query:        "after_sequence=-1",
+			stream:       &watchCatalogStream{err: status.Error(codes.InvalidArgument, "invalid after_sequence")},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "success",
+			query:        "after_sequence=3&entity=book&entity=Author&view=full",
+			lastEventID:  "11",
+			stream:       &watchCatalogStream{header: metadata.MD{}, changes: testChanges(), err: status.Error(codes.Unavailable, "shutting down")},
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := &watchCatalogClient{stream: tt.stream}
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, CatalogEventsPath+"?"+tt.query, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+
+			CatalogEvents(client)(recorder, req, nil)
+
+			require.Equal(t, tt.expectedCode, recorder.Code)
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+
+			require.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+			require.Equal(t, int64(11), client.request.GetAfterSequence())
+			require.Equal(t, []generated.CatalogEntity{
+				generated.CatalogEntity_CATALOG_ENTITY_BOOK,
+				generated.CatalogEntity_CATALOG_ENTITY_AUTHOR,
+			}, client.request.GetEntities())
+			require.Equal(t, generated.BookView_BOOK_VIEW_FULL, client.request.GetView())
+
+			events := strings.Split(strings.TrimSuffix(recorder.Body.String(), "\n\n"), "\n\n")
+			require.Len(t, events, 3)
+			require.True(t, strings.HasPrefix(events[0], "id: 12\ndata: "))
+			require.True(t, strings.HasPrefix(events[1], "id: 13\ndata: "))
+			require.Equal(t, "event: error\ndata: shutting down", events[2])
+
+			var change generated.WatchCatalogResponse
+			require.NoError(t, protojson.Unmarshal([]byte(strings.TrimPrefix(events[0], "id: 12\ndata: ")), &change))
+			require.Equal(t, "Mort", change.GetBook().GetName())
+		})
+	}
+}
+
+func TestCatalogEventsWebSocket(t *testing.T) {
+	t.Parallel()
+
+	t.Run("changes are sent as messages", func(t *testing.T) {
+		t.Parallel()
+		client := &watchCatalogClient{stream: &watchCatalogStream{
+			header:  metadata.MD{},
+			changes: testChanges(),
+			err:     status.Error(codes.Unavailable, "shutting down"),
+		}}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			CatalogEventsWebSocket(client, nil)(w, r, nil)
+		}))
+		defer server.Close()
+
+		conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+CatalogEventsWebSocketPath+"?after_sequence=11&entity=book", "", server.URL)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		for _, sequence := range []int64{12, 13} {
+			var message string
+			require.NoError(t, websocket.Message.Receive(conn, &message))
+
+			var change generated.WatchCatalogResponse
+			require.NoError(t, protojson.Unmarshal([]byte(message), &change))
+			require.Equal(t, sequence, change.GetSequence())
+		}
+
+		var message string
+		require.Error(t, websocket.Message.Receive(conn, &message))
+		require.Equal(t, int64(11), client.request.GetAfterSequence())
+		require.Equal(t, []generated.CatalogEntity{generated.CatalogEntity_CATALOG_ENTITY_BOOK}, client.request.GetEntities())
+	})
+
+	t.Run("rejected watch is not upgraded", func(t *testing.T) {
+		t.Parallel()
+		client := &watchCatalogClient{stream: &watchCatalogStream{err: status.Error(codes.InvalidArgument, "invalid after_sequence")}}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, CatalogEventsWebSocketPath+"?after_sequence=-1", nil)
+		req.Header.Set("Origin", "http://"+req.Host)
+
+		CatalogEventsWebSocket(client, nil)(recorder, req, nil)
+
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("foreign origins are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name     string
+			origin   string
+			expected int
+		}{
+			{name: "missing origin", expected: http.StatusForbidden},
+			{name: "other site", origin: "https://evil.example.com", expected: http.StatusForbidden},
+			{name: "allowed origin", origin: "https://library.example.com", expected: http.StatusBadRequest},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+				client := &watchCatalogClient{stream: &watchCatalogStream{err: status.Error(codes.InvalidArgument, "invalid after_sequence")}}
+				recorder := httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodGet, CatalogEventsWebSocketPath+"?after_sequence=-1", nil)
+
+				if tt.origin != "" {
+					req.Header.Set("Origin", tt.origin)
+				}
+
+				CatalogEventsWebSocket(client, []string{"https://library.example.com"})(recorder, req, nil)
+
+				require.Equal(t, tt.expected, recorder.Code)
+			})
+		}
+	})
+}
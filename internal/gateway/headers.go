@@ -0,0 +1,43 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/textproto"
+
+	grpcruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// idempotencyKeyHeader lets a client retry a create request without creating a duplicate.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// actorHeader names the caller the audit log records the changes of a request with.
+	actorHeader = "X-Actor"
+	// authorizationHeader carries the bearer token or the API key of the caller.
+	authorizationHeader = "Authorization"
+)
+
+// IncomingHeaderMatcher forwards the Idempotency-Key, X-Actor and Authorization headers to the grpc metadata as is,
+// the other headers are matched by the default gateway rules.
+func IncomingHeaderMatcher(key string) (string, bool) {
+	switch header := textproto.CanonicalMIMEHeaderKey(key); header {
+	case idempotencyKeyHeader, actorHeader, authorizationHeader:
+		return header, true
+	}
+
+	return grpcruntime.DefaultHeaderMatcher(key)
+}
+
+// outgoingContext forwards the Authorization header of a request served by a custom handler
+// to the grpc call it makes, the generated handlers do it with IncomingHeaderMatcher.
+func outgoingContext(r *http.Request) context.Context {
+	authorization := r.Header.Get(authorizationHeader)
+
+	if authorization == "" {
+		return r.Context()
+	}
+
+	// grpc metadata keys are lower case
+	return metadata.AppendToOutgoingContext(r.Context(), "authorization", authorization)
+}
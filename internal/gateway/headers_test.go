@@ -0,0 +1,52 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestIncomingHeaderMatcher(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header   string
+		expected string
+		ok       bool
+	}{
+		{header: "Idempotency-Key", expected: "Idempotency-Key", ok: true},
+		{header: "idempotency-key", expected: "Idempotency-Key", ok: true},
+		{header: "X-Actor", expected: "X-Actor", ok: true},
+		{header: "x-actor", expected: "X-Actor", ok: true},
+		{header: "Authorization", expected: "Authorization", ok: true},
+		{header: "authorization", expected: "Authorization", ok: true},
+		{header: "Grpc-Metadata-Trace", expected: "Trace", ok: true},
+		{header: "X-Unknown", expected: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			t.Parallel()
+
+			key, ok := IncomingHeaderMatcher(tt.header)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.expected, key)
+		})
+	}
+}
+
+func TestOutgoingContext(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet, CatalogDownloadPath, nil)
+	_, ok := metadata.FromOutgoingContext(outgoingContext(r))
+	require.False(t, ok)
+
+	r.Header.Set("Authorization", "Bearer token")
+	md, ok := metadata.FromOutgoingContext(outgoingContext(r))
+	require.True(t, ok)
+	require.Equal(t, []string{"Bearer token"}, md.Get("authorization"))
+}
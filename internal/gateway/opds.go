@@ -0,0 +1,512 @@
+package gateway
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	grpcruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	OPDSPath = "/opds"
+
+	opdsPageSize = 50
+
+	opdsNavigationType  = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	opdsAcquisitionType = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	openSearchType      = "application/opensearchdescription+xml"
+
+	opdsBorrowRel = "http://opds-spec.org/acquisition/borrow"
+	opdsNewRel    = "http://opds-spec.org/sort/new"
+)
+
+type opdsLink struct {
+	Rel   string `xml:"rel,attr"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+type opdsAuthor struct {
+	Name string `xml:"name"`
+}
+
+type opdsContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type opdsEntry struct {
+	Title      string       `xml:"title"`
+	ID         string       `xml:"id"`
+	Updated    string       `xml:"updated"`
+	Authors    []opdsAuthor `xml:"author"`
+	Identifier string       `xml:"dc:identifier,omitempty"`
+	Publisher  string       `xml:"dc:publisher,omitempty"`
+	Issued     string       `xml:"dc:issued,omitempty"`
+	Content    *opdsContent `xml:"content,omitempty"`
+	Links      []opdsLink   `xml:"link"`
+}
+
+type opdsFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	DC      string      `xml:"xmlns:dc,attr"`
+	OPDS    string      `xml:"xmlns:opds,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []opdsLink  `xml:"link"`
+	Entries []opdsEntry `xml:"entry"`
+}
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Template string `xml:"template,attr"`
+}
+
+type openSearchDescription struct {
+	XMLName     xml.Name      `xml:"OpenSearchDescription"`
+	Xmlns       string        `xml:"xmlns,attr"`
+	ShortName   string        `xml:"ShortName"`
+	Description string        `xml:"Description"`
+	URL         openSearchURL `xml:"Url"`
+}
+
+// Authenticator checks the Authorization header value of a request.
+type Authenticator interface {
+	Authenticate(authorization string) (entity.Principal, error)
+}
+
+// OPDS serves the catalog as OPDS 1.2 feeds for e-reader apps. Navigation feeds
+// lead to acquisition feeds, every book can be borrowed via the availability endpoint.
+type OPDS struct {
+	logger  *zap.Logger
+	authors repository.AuthorRepository
+	catalog repository.CatalogRepository
+	// authenticator is nil when authentication is off
+	authenticator Authenticator
+	now           func() time.Time
+}
+
+// NewOPDS creates the catalog feeds. The feeds read the repositories directly, bypassing the
+// grpc interceptors, so with a non nil authenticator they check the credentials themselves.
+func NewOPDS(
+	logger *zap.Logger,
+	authorRepository repository.AuthorRepository,
+	catalogRepository repository.CatalogRepository,
+	authenticator Authenticator,
+) *OPDS {
+	return &OPDS{
+		logger:        logger,
+		authors:       authorRepository,
+		catalog:       catalogRepository,
+		authenticator: authenticator,
+		now:           time.Now,
+	}
+}
+
+func (o *OPDS) Register(mux *grpcruntime.ServeMux) error {
+	routes := map[string]grpcruntime.HandlerFunc{
+		OPDSPath:                         o.root,
+		OPDSPath + "/opensearch":         o.openSearch,
+		OPDSPath + "/search":             o.search,
+		OPDSPath + "/new":                o.newest,
+		OPDSPath + "/authors":            o.authorList,
+		OPDSPath + "/authors/{id}":       o.authorBooks,
+		OPDSPath + "/subjects":           o.subjectList,
+		OPDSPath + "/subjects/{subject}": o.subjectBooks,
+		OPDSPath + "/books/{id}":         o.book,
+	}
+
+	for pattern, handler := range routes {
+		if err := mux.HandlePath(http.MethodGet, pattern, o.authenticate(handler)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// authenticate rejects the requests without valid credentials in the Authorization header.
+func (o *OPDS) authenticate(next grpcruntime.HandlerFunc) grpcruntime.HandlerFunc {
+	if o.authenticator == nil {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if _, err := o.authenticator.Authenticate(r.Header.Get("Authorization")); err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="library"`)
+			http.Error(w, "unauthenticated", http.StatusUnauthorized)
+
+			return
+		}
+
+		next(w, r, pathParams)
+	}
+}
+
+func (o *OPDS) root(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	feed := o.newFeed("urn:library:opds", "Library", OPDSPath, opdsNavigationType)
+	feed.Entries = []opdsEntry{
+		o.navigationEntry("urn:library:opds:new", "New", "Recently added books",
+			opdsLink{Rel: opdsNewRel, Href: OPDSPath + "/new", Type: opdsAcquisitionType}),
+		o.navigationEntry("urn:library:opds:authors", "Authors", "Books by author",
+			opdsLink{Rel: "subsection", Href: OPDSPath + "/authors", Type: opdsNavigationType}),
+		o.navigationEntry("urn:library:opds:subjects", "Subjects", "Books by subject",
+			opdsLink{Rel: "subsection", Href: OPDSPath + "/subjects", Type: opdsNavigationType}),
+	}
+
+	o.write(w, opdsNavigationType, feed)
+}
+
+func (o *OPDS) openSearch(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	o.write(w, openSearchType, openSearchDescription{
+		Xmlns:       "http://a9.com/-/spec/opensearch/1.1/",
+		ShortName:   "Library",
+		Description: "Search books by name",
+		URL: openSearchURL{
+			Type:     opdsAcquisitionType,
+			Template: OPDSPath + "/search?q={searchTerms}",
+		},
+	})
+}
+
+func (o *OPDS) search(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	if query == "" {
+		http.Error(w, "q is required", http.StatusBadRequest)
+		return
+	}
+
+	after, ok := afterID(w, r)
+
+	if !ok {
+		return
+	}
+
+	collapse, err := parseCollapse(r.URL.Query().Get("collapse"))
+
+	if err != nil {
+		http.Error(w, "collapse: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := entity.CatalogFilter{NameQuery: query, CollapseWorks: collapse}
+	books, err := o.catalog.ListCatalogBooks(r.Context(), filter, after, opdsPageSize+1)
+
+	if err != nil {
+		o.fail(w, "cannot search catalog books", err)
+		return
+	}
+
+	self := OPDSPath + "/search?q=" + url.QueryEscape(query)
+	if collapse {
+		self += "&collapse=" + collapseWorks
+	}
+	o.writeBooks(w, "urn:library:opds:search", "Search: "+query, self, books)
+}
+
+func (o *OPDS) newest(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	after, ok := afterID(w, r)
+
+	if !ok {
+		return
+	}
+
+	books, err := o.catalog.ListNewestBooks(r.Context(), after, opdsPageSize+1)
+
+	if err != nil {
+		o.fail(w, "cannot list newest books", err)
+		return
+	}
+
+	o.writeBooks(w, "urn:library:opds:new", "New", OPDSPath+"/new", books)
+}
+
+func (o *OPDS) authorList(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	after, ok := afterID(w, r)
+
+	if !ok {
+		return
+	}
+
+	authors, err := o.catalog.ListCatalogAuthors(r.Context(), after, opdsPageSize+1)
+
+	if err != nil {
+		o.fail(w, "cannot list catalog authors", err)
+		return
+	}
+
+	feed := o.newFeed("urn:library:opds:authors", "Authors", OPDSPath+"/authors", opdsNavigationType)
+
+	if len(authors) > opdsPageSize {
+		authors = authors[:opdsPageSize]
+		feed.Links = append(feed.Links, nextLink(OPDSPath+"/authors", authors[len(authors)-1].Author.ID, opdsNavigationType))
+	}
+
+	for _, author := range authors {
+		feed.Entries = append(feed.Entries, o.navigationEntry(
+			"urn:uuid:"+author.Author.ID,
+			author.Author.Name,
+			booksCount(author.Books),
+			opdsLink{Rel: "subsection", Href: authorPath(author.Author.ID), Type: opdsAcquisitionType},
+		))
+	}
+
+	o.write(w, opdsNavigationType, feed)
+}
+
+func (o *OPDS) authorBooks(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	id := pathParams["id"]
+
+	if uuid.Validate(id) != nil {
+		http.Error(w, "invalid author id", http.StatusBadRequest)
+		return
+	}
+
+	after, ok := afterID(w, r)
+
+	if !ok {
+		return
+	}
+
+	author, err := o.authors.GetAuthor(r.Context(), id)
+
+	if errors.Is(err, entity.ErrAuthorNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		o.fail(w, "cannot get author", err)
+		return
+	}
+
+	books, err := o.catalog.ListCatalogBooks(r.Context(), entity.CatalogFilter{AuthorID: id}, after, opdsPageSize+1)
+
+	if err != nil {
+		o.fail(w, "cannot list author books", err)
+		return
+	}
+
+	o.writeBooks(w, "urn:uuid:"+id, author.Name, authorPath(id), books)
+}
+
+func (o *OPDS) subjectList(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	after := r.URL.Query().Get("after")
+	subjects, err := o.catalog.ListCatalogSubjects(r.Context(), after, opdsPageSize+1)
+
+	if err != nil {
+		o.fail(w, "cannot list catalog subjects", err)
+		return
+	}
+
+	feed := o.newFeed("urn:library:opds:subjects", "Subjects", OPDSPath+"/subjects", opdsNavigationType)
+
+	if len(subjects) > opdsPageSize {
+		subjects = subjects[:opdsPageSize]
+		feed.Links = append(feed.Links, nextLink(OPDSPath+"/subjects", subjects[len(subjects)-1].Name, opdsNavigationType))
+	}
+
+	for _, subject := range subjects {
+		feed.Entries = append(feed.Entries, o.navigationEntry(
+			"urn:library:subject:"+url.PathEscape(subject.Name),
+			subject.Name,
+			booksCount(subject.Books),
+			opdsLink{Rel: "subsection", Href: subjectPath(subject.Name), Type: opdsAcquisitionType},
+		))
+	}
+
+	o.write(w, opdsNavigationType, feed)
+}
+
+func (o *OPDS) subjectBooks(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	subject := strings.ToLower(strings.TrimSpace(pathParams["subject"]))
+	after, ok := afterID(w, r)
+
+	if !ok {
+		return
+	}
+
+	books, err := o.catalog.ListCatalogBooks(r.Context(), entity.CatalogFilter{Subject: subject}, after, opdsPageSize+1)
+
+	if err != nil {
+		o.fail(w, "cannot list subject books", err)
+		return
+	}
+
+	o.writeBooks(w, "urn:library:subject:"+url.PathEscape(subject), subject, subjectPath(subject), books)
+}
+
+func (o *OPDS) book(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	id := pathParams["id"]
+
+	if uuid.Validate(id) != nil {
+		http.Error(w, "invalid book id", http.StatusBadRequest)
+		return
+	}
+
+	books, err := o.catalog.GetCatalogBooks(r.Context(), []string{id})
+
+	if err != nil {
+		o.fail(w, "cannot get catalog book", err)
+		return
+	}
+
+	if len(books) == 0 {
+		http.Error(w, entity.ErrBookNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	feed := o.newFeed("urn:uuid:"+id, books[0].Book.Name, bookPath(id), opdsAcquisitionType)
+	feed.Entries = []opdsEntry{bookEntry(books[0])}
+
+	o.write(w, opdsAcquisitionType, feed)
+}
+
+// writeBooks renders a page of an acquisition feed, books holds one extra book when there is a next page.
+func (o *OPDS) writeBooks(w http.ResponseWriter, id, title, self string, books []entity.CatalogBook) {
+	feed := o.newFeed(id, title, self, opdsAcquisitionType)
+
+	if len(books) > opdsPageSize {
+		books = books[:opdsPageSize]
+		feed.Links = append(feed.Links, nextLink(self, books[len(books)-1].Book.ID, opdsAcquisitionType))
+	}
+
+	for _, book := range books {
+		feed.Entries = append(feed.Entries, bookEntry(book))
+	}
+
+	o.write(w, opdsAcquisitionType, feed)
+}
+
+func (o *OPDS) newFeed(id, title, self, kind string) opdsFeed {
+	return opdsFeed{
+		Xmlns:   "http://www.w3.org/2005/Atom",
+		DC:      "http://purl.org/dc/terms/",
+		OPDS:    "http://opds-spec.org/2010/catalog",
+		ID:      id,
+		Title:   title,
+		Updated: o.now().UTC().Format(time.RFC3339),
+		Links: []opdsLink{
+			{Rel: "self", Href: self, Type: kind},
+			{Rel: "start", Href: OPDSPath, Type: opdsNavigationType},
+			{Rel: "search", Href: OPDSPath + "/opensearch", Type: openSearchType},
+		},
+	}
+}
+
+func (o *OPDS) navigationEntry(id, title, content string, link opdsLink) opdsEntry {
+	return opdsEntry{
+		Title:   title,
+		ID:      id,
+		Updated: o.now().UTC().Format(time.RFC3339),
+		Content: &opdsContent{Type: "text", Value: content},
+		Links:   []opdsLink{link},
+	}
+}
+
+func bookEntry(book entity.CatalogBook) opdsEntry {
+	entry := opdsEntry{
+		Title:     book.Book.Name,
+		ID:        "urn:uuid:" + book.Book.ID,
+		Updated:   book.Book.UpdatedAt.UTC().Format(time.RFC3339),
+		Publisher: book.Book.Publisher,
+		Links: []opdsLink{
+			{Rel: "alternate", Href: bookPath(book.Book.ID), Type: opdsAcquisitionType},
+			{Rel: opdsBorrowRel, Href: "/v1/library/book_availability/" + book.Book.ID, Type: "application/json"},
+		},
+	}
+
+	if book.Book.ISBN != "" {
+		entry.Identifier = "urn:isbn:" + book.Book.ISBN
+	}
+
+	if book.Book.PublicationYear != 0 {
+		entry.Issued = strconv.Itoa(book.Book.PublicationYear)
+	}
+
+	for i, name := range book.AuthorNames {
+		entry.Authors = append(entry.Authors, opdsAuthor{Name: name})
+
+		if i < len(book.Book.AuthorIDs) {
+			entry.Links = append(entry.Links, opdsLink{
+				Rel:   "related",
+				Href:  authorPath(book.Book.AuthorIDs[i]),
+				Type:  opdsAcquisitionType,
+				Title: name,
+			})
+		}
+	}
+
+	return entry
+}
+
+func afterID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	after := r.URL.Query().Get("after")
+
+	if after != "" && uuid.Validate(after) != nil {
+		http.Error(w, "invalid after", http.StatusBadRequest)
+		return "", false
+	}
+
+	return after, true
+}
+
+func (o *OPDS) write(w http.ResponseWriter, contentType string, document any) {
+	data, err := xml.Marshal(document)
+
+	if err != nil {
+		o.fail(w, "cannot marshal opds document", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType+";charset=utf-8")
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(data)
+}
+
+func (o *OPDS) fail(w http.ResponseWriter, msg string, err error) {
+	o.logger.Error(msg, zap.Error(err))
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func nextLink(self, after, kind string) opdsLink {
+	separator := "?"
+	if strings.Contains(self, "?") {
+		separator = "&"
+	}
+
+	return opdsLink{Rel: "next", Href: self + separator + "after=" + url.QueryEscape(after), Type: kind}
+}
+
+func booksCount(count int) string {
+	if count == 1 {
+		return "1 book"
+	}
+
+	return strconv.Itoa(count) + " books"
+}
+
+func authorPath(id string) string {
+	return OPDSPath + "/authors/" + id
+}
+
+func subjectPath(subject string) string {
+	return OPDSPath + "/subjects/" + url.PathEscape(subject)
+}
+
+func bookPath(id string) string {
+	return OPDSPath + "/books/" + id
+}
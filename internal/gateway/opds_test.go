@@ -0,0 +1,311 @@
+package gateway
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	grpcruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+type opdsData struct {
+	mux        *grpcruntime.ServeMux
+	authorRepo *mocks.MockAuthorRepository
+	catalog    *mocks.MockCatalogRepository
+}
+
+// fakeAuthenticator accepts the single configured Authorization header value.
+type fakeAuthenticator string
+
+func (f fakeAuthenticator) Authenticate(authorization string) (entity.Principal, error) {
+	if authorization != string(f) {
+		return entity.Principal{}, entity.ErrUnauthenticated
+	}
+
+	return entity.Principal{Subject: "reader", Method: entity.AuthMethodAPIKey}, nil
+}
+
+func getOPDSData(t *testing.T) *opdsData {
+	t.Helper()
+
+	return getOPDSDataWithAuth(t, nil)
+}
+
+func getOPDSDataWithAuth(t *testing.T, authenticator Authenticator) *opdsData {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	authorRepo := mocks.NewMockAuthorRepository(ctrl)
+	catalog := mocks.NewMockCatalogRepository(ctrl)
+
+	opds := NewOPDS(zap.NewNop(), authorRepo, catalog, authenticator)
+	opds.now = func() time.Time {
+		return time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	mux := grpcruntime.NewServeMux()
+	require.NoError(t, opds.Register(mux))
+
+	return &opdsData{mux: mux, authorRepo: authorRepo, catalog: catalog}
+}
+
+func (d *opdsData) get(t *testing.T, target string) (*httptest.ResponseRecorder, opdsFeed) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	d.mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var feed opdsFeed
+	if recorder.Code == http.StatusOK {
+		require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &feed))
+	}
+
+	return recorder, feed
+}
+
+func findLink(links []opdsLink, rel string) (opdsLink, bool) {
+	for _, link := range links {
+		if link.Rel == rel {
+			return link, true
+		}
+	}
+
+	return opdsLink{}, false
+}
+
+func TestOPDSRoot(t *testing.T) {
+	t.Parallel()
+	data := getOPDSData(t)
+
+	recorder, feed := data.get(t, "/opds")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, opdsNavigationType+";charset=utf-8", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "2024-03-01T12:00:00Z", feed.Updated)
+	require.Len(t, feed.Entries, 3)
+	require.Equal(t, "/opds/new", feed.Entries[0].Links[0].Href)
+
+	search, ok := findLink(feed.Links, "search")
+	require.True(t, ok)
+	require.Equal(t, "/opds/opensearch", search.Href)
+}
+
+func TestOPDSAuthentication(t *testing.T) {
+	t.Parallel()
+	data := getOPDSDataWithAuth(t, fakeAuthenticator("ApiKey reader-key"))
+
+	recorder, _ := data.get(t, "/opds")
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	require.NotEmpty(t, recorder.Header().Get("WWW-Authenticate"))
+
+	request := httptest.NewRequest(http.MethodGet, "/opds", nil)
+	request.Header.Set("Authorization", "ApiKey reader-key")
+	recorder = httptest.NewRecorder()
+	data.mux.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestOPDSOpenSearch(t *testing.T) {
+	t.Parallel()
+	data := getOPDSData(t)
+
+	recorder := httptest.NewRecorder()
+	data.mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/opds/opensearch", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var description openSearchDescription
+	require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &description))
+	require.Equal(t, "/opds/search?q={searchTerms}", description.URL.Template)
+}
+
+func TestOPDSSearch(t *testing.T) {
+	t.Parallel()
+	data := getOPDSData(t)
+
+	books := make([]entity.CatalogBook, opdsPageSize+1)
+	for i := range books {
+		books[i] = entity.CatalogBook{Book: entity.Book{ID: uuid.NewString(), Name: "Book" + strconv.Itoa(i)}}
+	}
+
+	after := uuid.NewString()
+	data.catalog.EXPECT().
+		ListCatalogBooks(gomock.Any(), entity.CatalogFilter{NameQuery: "night watch"}, after, opdsPageSize+1).
+		Return(books, nil)
+
+	recorder, feed := data.get(t, "/opds/search?q=night+watch&after="+after)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Len(t, feed.Entries, opdsPageSize)
+
+	next, ok := findLink(feed.Links, "next")
+	require.True(t, ok)
+	require.Equal(t, "/opds/search?q=night+watch&after="+books[opdsPageSize-1].Book.ID, next.Href)
+
+	recorder, _ = data.get(t, "/opds/search")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestOPDSSearchCollapseWorks(t *testing.T) {
+	t.Parallel()
+	data := getOPDSData(t)
+
+	books := make([]entity.CatalogBook, opdsPageSize+1)
+	for i := range books {
+		books[i] = entity.CatalogBook{Book: entity.Book{ID: uuid.NewString(), Name: "Dune"}}
+	}
+
+	data.catalog.EXPECT().
+		ListCatalogBooks(gomock.Any(), entity.CatalogFilter{NameQuery: "dune", CollapseWorks: true}, "", opdsPageSize+1).
+		Return(books, nil)
+
+	recorder, feed := data.get(t, "/opds/search?q=dune&collapse=works")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	next, ok := findLink(feed.Links, "next")
+	require.True(t, ok)
+	require.Equal(t, "/opds/search?q=dune&collapse=works&after="+books[opdsPageSize-1].Book.ID, next.Href)
+
+	recorder, _ = data.get(t, "/opds/search?q=dune&collapse=editions")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestOPDSAuthors(t *testing.T) {
+	t.Parallel()
+	data := getOPDSData(t)
+
+	author := entity.Author{ID: uuid.NewString(), Name: "Terry Pratchett"}
+	data.catalog.EXPECT().ListCatalogAuthors(gomock.Any(), "", opdsPageSize+1).
+		Return([]entity.CatalogAuthor{{Author: author, Books: 2}}, nil)
+
+	recorder, feed := data.get(t, "/opds/authors")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Len(t, feed.Entries, 1)
+	require.Equal(t, "Terry Pratchett", feed.Entries[0].Title)
+	require.Equal(t, "/opds/authors/"+author.ID, feed.Entries[0].Links[0].Href)
+
+	_, ok := findLink(feed.Links, "next")
+	require.False(t, ok)
+}
+
+func TestOPDSAuthorBooks(t *testing.T) {
+	t.Parallel()
+
+	author := entity.Author{ID: uuid.NewString(), Name: "Terry Pratchett"}
+	book := entity.CatalogBook{
+		Book: entity.Book{
+			ID:              uuid.NewString(),
+			Name:            "Thud",
+			AuthorIDs:       []string{author.ID},
+			ISBN:            "9780060815226",
+			Publisher:       "Doubleday",
+			PublicationYear: 2005,
+			UpdatedAt:       time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+		AuthorNames: []string{author.Name},
+	}
+
+	t.Run("acquisition feed", func(t *testing.T) {
+		t.Parallel()
+		data := getOPDSData(t)
+
+		data.authorRepo.EXPECT().GetAuthor(gomock.Any(), author.ID).Return(author, nil)
+		data.catalog.EXPECT().ListCatalogBooks(gomock.Any(), entity.CatalogFilter{AuthorID: author.ID}, "", opdsPageSize+1).
+			Return([]entity.CatalogBook{book}, nil)
+
+		recorder, feed := data.get(t, "/opds/authors/"+author.ID)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, opdsAcquisitionType+";charset=utf-8", recorder.Header().Get("Content-Type"))
+		require.Equal(t, author.Name, feed.Title)
+		require.Len(t, feed.Entries, 1)
+
+		entry := feed.Entries[0]
+		require.Equal(t, "urn:uuid:"+book.Book.ID, entry.ID)
+		require.Equal(t, "2024-01-02T00:00:00Z", entry.Updated)
+		require.Equal(t, []opdsAuthor{{Name: author.Name}}, entry.Authors)
+
+		borrow, ok := findLink(entry.Links, opdsBorrowRel)
+		require.True(t, ok)
+		require.Equal(t, "/v1/library/book_availability/"+book.Book.ID, borrow.Href)
+	})
+
+	t.Run("author not found", func(t *testing.T) {
+		t.Parallel()
+		data := getOPDSData(t)
+
+		data.authorRepo.EXPECT().GetAuthor(gomock.Any(), author.ID).Return(entity.Author{}, entity.ErrAuthorNotFound)
+
+		recorder, _ := data.get(t, "/opds/authors/"+author.ID)
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("invalid author id", func(t *testing.T) {
+		t.Parallel()
+		data := getOPDSData(t)
+
+		recorder, _ := data.get(t, "/opds/authors/abc")
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestOPDSSubjects(t *testing.T) {
+	t.Parallel()
+	data := getOPDSData(t)
+
+	data.catalog.EXPECT().ListCatalogSubjects(gomock.Any(), "fantasy", opdsPageSize+1).
+		Return([]entity.CatalogSubject{{Name: "science fiction", Books: 1}}, nil)
+	data.catalog.EXPECT().ListCatalogBooks(gomock.Any(), entity.CatalogFilter{Subject: "science fiction"}, "", opdsPageSize+1).
+		Return(nil, nil)
+
+	recorder, feed := data.get(t, "/opds/subjects?after=fantasy")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Len(t, feed.Entries, 1)
+	require.Equal(t, "1 book", feed.Entries[0].Content.Value)
+
+	href := feed.Entries[0].Links[0].Href
+	require.Equal(t, "/opds/subjects/science%20fiction", href)
+
+	recorder, feed = data.get(t, href)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "science fiction", feed.Title)
+	require.Empty(t, feed.Entries)
+}
+
+func TestOPDSNewest(t *testing.T) {
+	t.Parallel()
+	data := getOPDSData(t)
+
+	book := entity.CatalogBook{Book: entity.Book{ID: uuid.NewString(), Name: "Thud"}}
+	data.catalog.EXPECT().ListNewestBooks(gomock.Any(), "", opdsPageSize+1).Return([]entity.CatalogBook{book}, nil)
+
+	recorder, feed := data.get(t, "/opds/new")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Len(t, feed.Entries, 1)
+
+	recorder, _ = data.get(t, "/opds/new?after=abc")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestOPDSBook(t *testing.T) {
+	t.Parallel()
+	data := getOPDSData(t)
+
+	book := entity.CatalogBook{Book: entity.Book{ID: uuid.NewString(), Name: "Thud"}}
+	missing := uuid.NewString()
+
+	data.catalog.EXPECT().GetCatalogBooks(gomock.Any(), []string{book.Book.ID}).Return([]entity.CatalogBook{book}, nil)
+	data.catalog.EXPECT().GetCatalogBooks(gomock.Any(), []string{missing}).Return(nil, nil)
+
+	recorder, feed := data.get(t, "/opds/books/"+book.Book.ID)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "Thud", feed.Title)
+	require.Len(t, feed.Entries, 1)
+
+	recorder, _ = data.get(t, "/opds/books/"+missing)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+const (
+	bearerScheme = "Bearer"
+	apiKeyScheme = "ApiKey"
+
+	// clockSkew is how far the clocks of the token issuer and the server may drift apart.
+	clockSkew = time.Minute
+)
+
+// Authenticator checks the Authorization header of a request: "Bearer <jwt>" or "ApiKey <key>".
+type Authenticator struct {
+	hmacSecret []byte
+	// keys finds the JWKS key of a token by its key id, or tries every key when the token has none
+	keys keyfunc.Keyfunc
+	// methods are the JWS algorithms of the configured keys
+	methods  []string
+	issuer   string
+	audience string
+	// apiKeys maps a key to the name of its owner
+	apiKeys map[string]string
+	admins  map[string]bool
+	now     func() time.Time
+}
+
+// New creates an authenticator accepting JWTs signed with the HMAC secret or a key of the JWKS
+// and the given API keys, keyed by the name of their owner. Empty issuer and audience are not checked.
+// The admins are the subjects and API key names of the principals marked as admins.
+func New(
+	hmacSecret string,
+	jwks []byte,
+	issuer string,
+	audience string,
+	apiKeys map[string]string,
+	admins []string,
+) (*Authenticator, error) {
+	authenticator := &Authenticator{
+		hmacSecret: []byte(hmacSecret),
+		issuer:     issuer,
+		audience:   audience,
+		apiKeys:    make(map[string]string, len(apiKeys)),
+		admins:     make(map[string]bool, len(admins)),
+		now:        time.Now,
+	}
+
+	for _, admin := range admins {
+		authenticator.admins[admin] = true
+	}
+
+	if len(jwks) > 0 {
+		keys, err := parseJWKS(jwks)
+
+		if err != nil {
+			return nil, err
+		}
+
+		authenticator.keys, err = keyfunc.New(keyfunc.Options{Storage: keys})
+
+		if err != nil {
+			return nil, err
+		}
+
+		authenticator.methods = slices.Clone(publicKeyMethods)
+	}
+
+	if len(authenticator.hmacSecret) > 0 {
+		authenticator.methods = append(authenticator.methods, hmacMethods...)
+	}
+
+	for name, key := range apiKeys {
+		if key == "" {
+			return nil, fmt.Errorf("api key of %q is empty", name)
+		}
+
+		if other, ok := authenticator.apiKeys[key]; ok {
+			return nil, fmt.Errorf("api keys of %q and %q are the same", other, name)
+		}
+
+		authenticator.apiKeys[key] = name
+	}
+
+	if len(authenticator.methods) == 0 && len(authenticator.apiKeys) == 0 {
+		return nil, errors.New("no jwt keys or api keys are configured")
+	}
+
+	return authenticator, nil
+}
+
+// Authenticate returns the caller of the Authorization header value,
+// all the failures are entity.ErrUnauthenticated.
+func (a *Authenticator) Authenticate(authorization string) (entity.Principal, error) {
+	scheme, credentials, found := strings.Cut(authorization, " ")
+	credentials = strings.TrimSpace(credentials)
+
+	if !found || credentials == "" {
+		return entity.Principal{}, fmt.Errorf("%w: malformed authorization header", entity.ErrUnauthenticated)
+	}
+
+	switch {
+	case strings.EqualFold(scheme, bearerScheme):
+		subject, err := a.verifyJWT(credentials)
+
+		if err != nil {
+			return entity.Principal{}, fmt.Errorf("%w: %w", entity.ErrUnauthenticated, err)
+		}
+
+		return entity.Principal{Subject: subject, Method: entity.AuthMethodJWT, Admin: a.admins[subject]}, nil
+	case strings.EqualFold(scheme, apiKeyScheme):
+		name, ok := a.apiKey(credentials)
+
+		if !ok {
+			return entity.Principal{}, fmt.Errorf("%w: unknown api key", entity.ErrUnauthenticated)
+		}
+
+		return entity.Principal{Subject: name, Method: entity.AuthMethodAPIKey, Admin: a.admins[name]}, nil
+	default:
+		return entity.Principal{}, fmt.Errorf("%w: unsupported authorization scheme %q", entity.ErrUnauthenticated, scheme)
+	}
+}
+
+// apiKey looks the key up comparing it with every configured key in constant time,
+// so that the response time does not tell how much of a key is right.
+func (a *Authenticator) apiKey(key string) (string, bool) {
+	var (
+		name  string
+		found bool
+	)
+
+	for candidate, owner := range a.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+			name, found = owner, true
+		}
+	}
+
+	return name, found
+}
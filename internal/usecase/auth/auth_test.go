@@ -0,0 +1,332 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSecret   = "test-secret"
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "library"
+)
+
+var testNow = time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub": "alice",
+		"iss": testIssuer,
+		"aud": []string{"other", testAudience},
+		"exp": testNow.Add(time.Hour).Unix(),
+		"nbf": testNow.Add(-time.Hour).Unix(),
+	}
+}
+
+// signToken builds a compact JWS, sign gets the signing input and returns the signature.
+func signToken(t *testing.T, header map[string]any, claims map[string]any, sign func(signed []byte) []byte) string {
+	t.Helper()
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+func hmacSigner(secret string) func([]byte) []byte {
+	return func(signed []byte) []byte {
+		mac := hmac.New(crypto.SHA256.New, []byte(secret))
+		mac.Write(signed)
+
+		return mac.Sum(nil)
+	}
+}
+
+func digest(hash crypto.Hash, signed []byte) []byte {
+	hasher := hash.New()
+	hasher.Write(signed)
+
+	return hasher.Sum(nil)
+}
+
+func rsaSigner(t *testing.T, key *rsa.PrivateKey, pss bool) func([]byte) []byte {
+	t.Helper()
+
+	return func(signed []byte) []byte {
+		var (
+			signature []byte
+			err       error
+		)
+
+		if pss {
+			signature, err = rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest(crypto.SHA256, signed), nil)
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest(crypto.SHA256, signed))
+		}
+
+		require.NoError(t, err)
+
+		return signature
+	}
+}
+
+func ecdsaSigner(t *testing.T, key *ecdsa.PrivateKey) func([]byte) []byte {
+	t.Helper()
+
+	return func(signed []byte) []byte {
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest(crypto.SHA256, signed))
+		require.NoError(t, err)
+
+		signature := make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+
+		return signature
+	}
+}
+
+func encodeInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func testJWKS(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"keys": []map[string]any{
+			{"kty": "RSA", "kid": "rsa", "use": "sig", "n": encodeInt(rsaKey.N), "e": encodeInt(big.NewInt(int64(rsaKey.E)))},
+			{"kty": "EC", "kid": "ec", "alg": "ES256", "crv": "P-256", "x": encodeInt(ecKey.X), "y": encodeInt(ecKey.Y)},
+			{"kty": "RSA", "kid": "enc", "use": "enc", "n": encodeInt(rsaKey.N), "e": "AQAB"},
+			{"kty": "oct", "kid": "oct", "k": "c2VjcmV0"},
+		},
+	})
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	authenticator, err := New(testSecret, testJWKS(t, rsaKey, ecKey), testIssuer, testAudience, map[string]string{
+		"importer": "importer-key",
+		"ci":       "ci-key",
+	}, []string{"importer"})
+	require.NoError(t, err)
+	authenticator.now = func() time.Time { return testNow }
+
+	withClaim := func(name string, value any) map[string]any {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+
+		return claims
+	}
+	hs256 := map[string]any{"alg": "HS256", "typ": "JWT"}
+
+	tests := []struct {
+		name          string
+		authorization string
+		expected      entity.Principal
+		noError       bool
+	}{
+		{
+			name:          "hmac token",
+			authorization: "Bearer " + signToken(t, hs256, validClaims(), hmacSigner(testSecret)),
+			expected:      entity.Principal{Subject: "alice", Method: entity.AuthMethodJWT},
+			noError:       true,
+		},
+		{
+			name:          "lower case scheme and single audience",
+			authorization: "bearer " + signToken(t, hs256, withClaim("aud", testAudience), hmacSigner(testSecret)),
+			expected:      entity.Principal{Subject: "alice", Method: entity.AuthMethodJWT},
+			noError:       true,
+		},
+		{
+			name:          "rsa token",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "RS256", "kid": "rsa"}, validClaims(), rsaSigner(t, rsaKey, false)),
+			expected:      entity.Principal{Subject: "alice", Method: entity.AuthMethodJWT},
+			noError:       true,
+		},
+		{
+			name:          "rsa pss token without key id",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "PS256"}, validClaims(), rsaSigner(t, rsaKey, true)),
+			expected:      entity.Principal{Subject: "alice", Method: entity.AuthMethodJWT},
+			noError:       true,
+		},
+		{
+			name:          "ecdsa token",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "ES256", "kid": "ec"}, validClaims(), ecdsaSigner(t, ecKey)),
+			expected:      entity.Principal{Subject: "alice", Method: entity.AuthMethodJWT},
+			noError:       true,
+		},
+		{
+			name:          "expiration within clock skew",
+			authorization: "Bearer " + signToken(t, hs256, withClaim("exp", testNow.Add(-clockSkew/2).Unix()), hmacSigner(testSecret)),
+			expected:      entity.Principal{Subject: "alice", Method: entity.AuthMethodJWT},
+			noError:       true,
+		},
+		{
+			name:          "api key",
+			authorization: "ApiKey ci-key",
+			expected:      entity.Principal{Subject: "ci", Method: entity.AuthMethodAPIKey},
+			noError:       true,
+		},
+		{
+			name:          "admin api key",
+			authorization: "ApiKey importer-key",
+			expected:      entity.Principal{Subject: "importer", Method: entity.AuthMethodAPIKey, Admin: true},
+			noError:       true,
+		},
+		{
+			name:          "unknown api key",
+			authorization: "ApiKey unknown-key",
+		},
+		{
+			name:          "api key as bearer token",
+			authorization: "Bearer ci-key",
+		},
+		{
+			name:          "no credentials",
+			authorization: "Bearer",
+		},
+		{
+			name:          "unsupported scheme",
+			authorization: "Basic YWxpY2U6c2VjcmV0",
+		},
+		{
+			name:          "wrong hmac secret",
+			authorization: "Bearer " + signToken(t, hs256, validClaims(), hmacSigner("other-secret")),
+		},
+		{
+			name:          "unsigned token",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "none"}, validClaims(), func([]byte) []byte { return nil }),
+		},
+		{
+			name:          "signed with an unknown key",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "RS256", "kid": "rsa"}, validClaims(), rsaSigner(t, otherRSAKey, false)),
+		},
+		{
+			name:          "unknown key id",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "RS256", "kid": "missing"}, validClaims(), rsaSigner(t, rsaKey, false)),
+		},
+		{
+			name:          "encryption key",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "RS256", "kid": "enc"}, validClaims(), rsaSigner(t, rsaKey, false)),
+		},
+		{
+			name:          "algorithm pinned by the key",
+			authorization: "Bearer " + signToken(t, map[string]any{"alg": "ES384", "kid": "ec"}, validClaims(), ecdsaSigner(t, ecKey)),
+		},
+		{
+			name:          "expired",
+			authorization: "Bearer " + signToken(t, hs256, withClaim("exp", testNow.Add(-time.Hour).Unix()), hmacSigner(testSecret)),
+		},
+		{
+			name:          "no expiration",
+			authorization: "Bearer " + signToken(t, hs256, withClaim("exp", nil), hmacSigner(testSecret)),
+		},
+		{
+			name:          "not valid yet",
+			authorization: "Bearer " + signToken(t, hs256, withClaim("nbf", testNow.Add(time.Hour).Unix()), hmacSigner(testSecret)),
+		},
+		{
+			name:          "wrong issuer",
+			authorization: "Bearer " + signToken(t, hs256, withClaim("iss", "https://other.example.com"), hmacSigner(testSecret)),
+		},
+		{
+			name:          "wrong audience",
+			authorization: "Bearer " + signToken(t, hs256, withClaim("aud", "other"), hmacSigner(testSecret)),
+		},
+		{
+			name:          "no subject",
+			authorization: "Bearer " + signToken(t, hs256, withClaim("sub", nil), hmacSigner(testSecret)),
+		},
+		{
+			name:          "malformed token",
+			authorization: "Bearer not.a.token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			principal, err := authenticator.Authenticate(tt.authorization)
+			if tt.noError {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, principal)
+			} else {
+				require.ErrorIs(t, err, entity.ErrUnauthenticated)
+			}
+		})
+	}
+}
+
+func TestAuthenticateWithoutHMACSecret(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	jwks := testJWKS(t, rsaKey, ecKey)
+
+	authenticator, err := New("", jwks, "", "", nil, nil)
+	require.NoError(t, err)
+	authenticator.now = func() time.Time { return testNow }
+
+	// the public key must not work as an HMAC secret
+	token := signToken(t, map[string]any{"alg": "HS256", "kid": "rsa"}, validClaims(), hmacSigner(string(jwks)))
+	_, err = authenticator.Authenticate("Bearer " + token)
+	require.ErrorIs(t, err, entity.ErrUnauthenticated)
+
+	_, err = authenticator.Authenticate("ApiKey ci-key")
+	require.ErrorIs(t, err, entity.ErrUnauthenticated)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New("", nil, "", "", nil, nil)
+	require.Error(t, err)
+
+	_, err = New("", nil, "", "", map[string]string{"ci": ""}, nil)
+	require.Error(t, err)
+
+	_, err = New("", []byte(`{"keys": []}`), "", "", nil, nil)
+	require.Error(t, err)
+
+	_, err = New("", nil, "", "", map[string]string{"ci": "same-key", "importer": "same-key"}, nil)
+	require.Error(t, err)
+
+	_, err = New("", nil, "", "", map[string]string{"ci": "ci-key"}, nil)
+	require.NoError(t, err)
+}
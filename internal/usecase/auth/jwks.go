@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MicahParks/jwkset"
+	"github.com/pkg/errors"
+)
+
+// parseJWKS reads the RSA and EC signing keys of a JSON Web Key Set into an in-memory key store.
+// Encryption keys and key types that can not verify a signature are skipped, so they never
+// reach the verifier; a set without a usable key is an error.
+func parseJWKS(data []byte) (jwkset.Storage, error) {
+	var set jwkset.JWKSMarshal
+
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("cannot parse jwks: %w", err)
+	}
+
+	storage := jwkset.NewMemoryStorage()
+	count := 0
+
+	for i, marshal := range set.Keys {
+		if marshal.USE != "" && marshal.USE != jwkset.UseSig {
+			continue
+		}
+
+		if marshal.KTY != jwkset.KtyRSA && marshal.KTY != jwkset.KtyEC {
+			continue
+		}
+
+		jwk, err := jwkset.NewJWKFromMarshal(marshal, jwkset.JWKMarshalOptions{}, jwkset.JWKValidateOptions{})
+
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %d: %w", i, err)
+		}
+
+		if err = checkPublicKey(jwk.Key()); err != nil {
+			return nil, fmt.Errorf("jwks key %d: %w", i, err)
+		}
+
+		if err = storage.KeyWrite(context.Background(), jwk); err != nil {
+			return nil, fmt.Errorf("jwks key %d: %w", i, err)
+		}
+
+		count++
+	}
+
+	if count == 0 {
+		return nil, errors.New("jwks has no signing keys")
+	}
+
+	return storage, nil
+}
+
+// checkPublicKey rejects the keys jwkset accepts as they are but that can not be trusted:
+// RSA keys with a tiny exponent and EC points off their curve.
+func checkPublicKey(key any) error {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if key.E < 3 {
+			return errors.New("invalid exponent")
+		}
+	case *ecdsa.PublicKey:
+		// ECDH checks that the point is on the curve
+		if _, err := key.ECDH(); err != nil {
+			return fmt.Errorf("invalid point: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJWKS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		jwks    string
+		keys    int
+		noError bool
+	}{
+		{
+			name:    "rsa and ec keys",
+			jwks:    `{"keys": [{"kty": "RSA", "kid": "1", "n": "sXch", "e": "AQAB"}, {"kty": "EC", "crv": "P-256", "x": "MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4", "y": "4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM"}]}`,
+			keys:    2,
+			noError: true,
+		},
+		{
+			name:    "encryption and symmetric keys are skipped",
+			jwks:    `{"keys": [{"kty": "RSA", "use": "enc", "n": "sXch", "e": "AQAB"}, {"kty": "oct", "k": "c2VjcmV0"}, {"kty": "RSA", "n": "sXch", "e": "AQAB"}]}`,
+			keys:    1,
+			noError: true,
+		},
+		{
+			name: "no signing keys",
+			jwks: `{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`,
+		},
+		{
+			name: "not json",
+			jwks: `keys`,
+		},
+		{
+			name: "rsa key without modulus",
+			jwks: `{"keys": [{"kty": "RSA", "e": "AQAB"}]}`,
+		},
+		{
+			name: "rsa key with a small exponent",
+			jwks: `{"keys": [{"kty": "RSA", "n": "sXch", "e": "AQ"}]}`,
+		},
+		{
+			name: "unsupported curve",
+			jwks: `{"keys": [{"kty": "EC", "crv": "P-192", "x": "AQ", "y": "AQ"}]}`,
+		},
+		{
+			name: "point off the curve",
+			jwks: `{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			storage, err := parseJWKS([]byte(tt.jwks))
+			if !tt.noError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			keys, err := storage.KeyReadAll(context.Background())
+			require.NoError(t, err)
+			require.Len(t, keys, tt.keys)
+
+			for _, key := range keys {
+				switch key.Key().(type) {
+				case *rsa.PublicKey, *ecdsa.PublicKey:
+				default:
+					t.Fatalf("unexpected key type %T", key.Key())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+// hmacMethods and publicKeyMethods are the accepted JWS algorithms, "none" is never accepted.
+var (
+	hmacMethods      = []string{"HS256", "HS384", "HS512"}
+	publicKeyMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+)
+
+// verifyJWT checks the signature and the registered claims of a compact JWS and returns its subject.
+// A token must expire, HMAC tokens are only checked with the secret and the others only with the JWKS,
+// so that a public key can not be passed off as an HMAC secret.
+func (a *Authenticator) verifyJWT(token string) (string, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(a.methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(a.now),
+	}
+
+	if a.issuer != "" {
+		options = append(options, jwt.WithIssuer(a.issuer))
+	}
+
+	if a.audience != "" {
+		options = append(options, jwt.WithAudience(a.audience))
+	}
+
+	var claims jwt.RegisteredClaims
+	if _, err := jwt.ParseWithClaims(token, &claims, a.key, options...); err != nil {
+		return "", err
+	}
+
+	if claims.Subject == "" {
+		return "", errors.New("token has no subject")
+	}
+
+	return claims.Subject, nil
+}
+
+// key picks the verification key by the token algorithm family.
+func (a *Authenticator) key(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if len(a.hmacSecret) == 0 {
+			return nil, fmt.Errorf("algorithm %q is not enabled", token.Method.Alg())
+		}
+
+		return a.hmacSecret, nil
+	}
+
+	if a.keys == nil {
+		return nil, fmt.Errorf("algorithm %q is not enabled", token.Method.Alg())
+	}
+
+	return a.keys.Keyfunc(token)
+}
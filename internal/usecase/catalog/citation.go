@@ -0,0 +1,121 @@
+package catalog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/project/library/internal/entity"
+)
+
+var bibTeXEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+)
+
+type bibTeXWriter struct {
+	w io.Writer
+}
+
+func (b *bibTeXWriter) Write(book entity.CatalogBook) error {
+	var entry strings.Builder
+
+	_, _ = fmt.Fprintf(&entry, "@book{%s,\n", bibTeXKey(book))
+	writeBibTeXField(&entry, "title", book.Book.Name)
+
+	authors := make([]string, len(book.AuthorNames))
+	for i, name := range book.AuthorNames {
+		authors[i] = invertName(name)
+	}
+
+	writeBibTeXField(&entry, "author", strings.Join(authors, " and "))
+	writeBibTeXField(&entry, "publisher", book.Book.Publisher)
+	writeBibTeXField(&entry, "year", formatYear(book.Book.PublicationYear))
+	writeBibTeXField(&entry, "isbn", book.Book.ISBN)
+	entry.WriteString("}\n\n")
+
+	_, err := io.WriteString(b.w, entry.String())
+
+	return err
+}
+
+func (b *bibTeXWriter) Close() error {
+	return nil
+}
+
+func writeBibTeXField(entry *strings.Builder, name string, value string) {
+	if value != "" {
+		_, _ = fmt.Fprintf(entry, "  %s = {%s},\n", name, bibTeXEscaper.Replace(value))
+	}
+}
+
+// bibTeXKey builds a citation key like "herbert1965_6b1f4ad2",
+// the id prefix keeps keys unique for books of the same author and year.
+func bibTeXKey(book entity.CatalogBook) string {
+	var key strings.Builder
+
+	if len(book.AuthorNames) > 0 {
+		words := strings.Fields(book.AuthorNames[0])
+		for _, r := range strings.ToLower(words[len(words)-1]) {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				key.WriteRune(r)
+			}
+		}
+	}
+
+	if key.Len() == 0 {
+		key.WriteString("book")
+	}
+
+	key.WriteString(formatYear(book.Book.PublicationYear))
+	id := strings.ReplaceAll(book.Book.ID, "-", "")
+	key.WriteString("_")
+	key.WriteString(id[:min(8, len(id))])
+
+	return key.String()
+}
+
+type risWriter struct {
+	w io.Writer
+}
+
+func (r *risWriter) Write(book entity.CatalogBook) error {
+	var entry strings.Builder
+
+	writeRISField(&entry, "TY", "BOOK")
+	writeRISField(&entry, "ID", book.Book.ID)
+	writeRISField(&entry, "TI", book.Book.Name)
+
+	for _, name := range book.AuthorNames {
+		writeRISField(&entry, "AU", invertName(name))
+	}
+
+	writeRISField(&entry, "PB", book.Book.Publisher)
+	writeRISField(&entry, "PY", formatYear(book.Book.PublicationYear))
+	writeRISField(&entry, "SN", book.Book.ISBN)
+	entry.WriteString("ER  - \n\n")
+
+	_, err := io.WriteString(r.w, entry.String())
+
+	return err
+}
+
+func (r *risWriter) Close() error {
+	return nil
+}
+
+func writeRISField(entry *strings.Builder, tag string, value string) {
+	// a line break would start a new tag
+	value = strings.Join(strings.Fields(value), " ")
+
+	if value != "" {
+		_, _ = fmt.Fprintf(entry, "%s  - %s\n", tag, value)
+	}
+}
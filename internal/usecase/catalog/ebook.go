@@ -0,0 +1,108 @@
+package catalog
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/project/library/internal/entity"
+)
+
+const (
+	EpubContentType = "application/epub+zip"
+	PdfContentType  = "application/pdf"
+
+	// authorRole is the MARC relator code of an author, creators without a role are taken as authors.
+	authorRole = "aut"
+
+	maxEbookCoverSize = 10 << 20
+)
+
+// ReadEbook extracts the metadata of an EPUB or PDF file. Missing fields are left empty,
+// an error is returned only when the file cannot be read as the given type.
+func ReadEbook(contentType string, r io.ReaderAt, size int64) (entity.EbookMetadata, error) {
+	var (
+		metadata entity.EbookMetadata
+		err      error
+	)
+
+	switch contentType {
+	case EpubContentType:
+		metadata, err = readEPUB(r, size)
+	case PdfContentType:
+		metadata, err = readPDF(r, size)
+	default:
+		return entity.EbookMetadata{}, fmt.Errorf("%s: %w", contentType, entity.ErrUnsupportedContentType)
+	}
+
+	if err != nil {
+		return entity.EbookMetadata{}, err
+	}
+
+	metadata.ContentType = contentType
+
+	return metadata, nil
+}
+
+// EbookAuthors picks the authors among the creators and converts their names into the form
+// accepted by RegisterAuthor. Creators with other roles and names that cannot be converted
+// are returned as skipped.
+func EbookAuthors(creators []entity.EbookCreator) (names []string, skipped []string) {
+	names = make([]string, 0, len(creators))
+	skipped = make([]string, 0)
+
+	for _, creator := range creators {
+		if creator.Role != "" && creator.Role != authorRole {
+			skipped = append(skipped, creator.Name)
+			continue
+		}
+
+		name := marcNameToAuthor(creator.Name)
+
+		if !authorNamePattern.MatchString(name) {
+			skipped = append(skipped, creator.Name)
+			continue
+		}
+
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names, skipped
+}
+
+// ebookISBN returns the ISBN of an identifier like "urn:isbn:978-0-441-17271-9",
+// a bare value is accepted when the scheme says it is an ISBN.
+func ebookISBN(scheme, identifier string) string {
+	lower := strings.ToLower(identifier)
+
+	switch {
+	case strings.HasPrefix(lower, "urn:isbn:"):
+		identifier = identifier[len("urn:isbn:"):]
+	case strings.HasPrefix(lower, "isbn:"):
+		identifier = identifier[len("isbn:"):]
+	case !strings.EqualFold(scheme, "isbn"):
+		return ""
+	}
+
+	isbn := normalizeISBN(identifier)
+
+	if len(isbn) != 10 && len(isbn) != 13 {
+		return ""
+	}
+
+	return isbn
+}
+
+func ebookYear(date string) int {
+	year, err := strconv.Atoi(yearPattern.FindString(date))
+
+	if err != nil {
+		return 0
+	}
+
+	return year
+}
@@ -0,0 +1,120 @@
+package catalog
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func readEbookFile(t *testing.T, contentType, name string) (entity.EbookMetadata, error) {
+	t.Helper()
+	content, err := os.ReadFile(name)
+	require.NoError(t, err)
+
+	return ReadEbook(contentType, bytes.NewReader(content), int64(len(content)))
+}
+
+func TestReadEbookEPUB(t *testing.T) {
+	t.Parallel()
+
+	metadata, err := readEbookFile(t, EpubContentType, "testdata/book.epub")
+	require.NoError(t, err)
+	require.Equal(t, EpubContentType, metadata.ContentType)
+	require.Equal(t, "Dune", metadata.Title)
+	require.Equal(t, []entity.EbookCreator{
+		{Name: "Herbert, Frank", Role: "aut"},
+		{Name: "John Smith", Role: "edt"},
+		{Name: "Stanisław Lem"},
+	}, metadata.Creators)
+	require.Equal(t, "en", metadata.Language)
+	require.Equal(t, []string{
+		"urn:uuid:0b7c5e9a-3f5d-4c55-9e1a-6f0a2f7d8b11",
+		"urn:isbn:978-0-441-17271-9",
+	}, metadata.Identifiers)
+	require.Equal(t, "9780441172719", metadata.ISBN)
+	require.Equal(t, "Chilton Books", metadata.Publisher)
+	require.Equal(t, 1965, metadata.PublicationYear)
+	require.Equal(t, "image/png", metadata.CoverContentType)
+	require.True(t, bytes.HasPrefix(metadata.Cover, []byte("\x89PNG")))
+}
+
+func TestReadEbookPDF(t *testing.T) {
+	t.Parallel()
+
+	metadata, err := readEbookFile(t, PdfContentType, "testdata/book.pdf")
+	require.NoError(t, err)
+	require.Equal(t, entity.EbookMetadata{
+		ContentType: PdfContentType,
+		Title:       "Good Omens: the (nice) and accurate prophecies",
+		Creators: []entity.EbookCreator{
+			{Name: "Terry Pratchett"},
+			{Name: "Neil Gaiman"},
+		},
+		Language:        "en-GB",
+		Identifiers:     []string{},
+		PublicationYear: 1990,
+	}, metadata)
+}
+
+func TestReadEbookInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		contentType string
+		data        string
+		wantErr     error
+	}{
+		{
+			name:        "not a zip archive",
+			contentType: EpubContentType,
+			data:        "mimetypeapplication/epub+zip",
+			wantErr:     entity.ErrInvalidEbook,
+		},
+		{
+			name:        "no pdf header",
+			contentType: PdfContentType,
+			data:        "<html></html>",
+			wantErr:     entity.ErrInvalidEbook,
+		},
+		{
+			name:        "encrypted pdf",
+			contentType: PdfContentType,
+			data:        "%PDF-1.7\ntrailer\n<< /Root 1 0 R /Encrypt 5 0 R >>\n%%EOF\n",
+			wantErr:     entity.ErrInvalidEbook,
+		},
+		{
+			name:        "plain text",
+			contentType: "text/plain",
+			data:        "Dune",
+			wantErr:     entity.ErrUnsupportedContentType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ReadEbook(tt.contentType, strings.NewReader(tt.data), int64(len(tt.data)))
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestEbookAuthors(t *testing.T) {
+	t.Parallel()
+
+	names, skipped := EbookAuthors([]entity.EbookCreator{
+		{Name: "Herbert, Frank", Role: "aut"},
+		{Name: "Frank Herbert"},
+		{Name: "Tolkien, J. R. R."},
+		{Name: "John Smith", Role: "edt"},
+		{Name: "Stanisław Lem"},
+	})
+	require.Equal(t, []string{"Frank Herbert", "J R R Tolkien"}, names)
+	require.Equal(t, []string{"John Smith", "Stanisław Lem"}, skipped)
+}
@@ -0,0 +1,255 @@
+package catalog
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/project/library/internal/entity"
+)
+
+const (
+	epubContainerPath   = "META-INF/container.xml"
+	epubPackageType     = "application/oebps-package+xml"
+	maxEpubDocumentSize = 1 << 20
+)
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// opfCreator carries both EPUB 2 opf:role and opf:file-as attributes,
+// EPUB 3 puts them into meta elements refining the creator by its id.
+type opfCreator struct {
+	ID     string `xml:"id,attr"`
+	Role   string `xml:"role,attr"`
+	FileAs string `xml:"file-as,attr"`
+	Name   string `xml:",chardata"`
+}
+
+type opfIdentifier struct {
+	Scheme string `xml:"scheme,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type opfMeta struct {
+	Name     string `xml:"name,attr"`
+	Content  string `xml:"content,attr"`
+	Property string `xml:"property,attr"`
+	Refines  string `xml:"refines,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type opfItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr"`
+}
+
+type opfPackage struct {
+	Metadata struct {
+		Titles      []string        `xml:"title"`
+		Creators    []opfCreator    `xml:"creator"`
+		Languages   []string        `xml:"language"`
+		Identifiers []opfIdentifier `xml:"identifier"`
+		Publishers  []string        `xml:"publisher"`
+		Dates       []string        `xml:"date"`
+		Metas       []opfMeta       `xml:"meta"`
+	} `xml:"metadata"`
+	Items []opfItem `xml:"manifest>item"`
+}
+
+// refinement returns the value of an EPUB 3 meta element refining the element with the given id.
+func (p opfPackage) refinement(id, property string) string {
+	if id == "" {
+		return ""
+	}
+
+	for _, meta := range p.Metadata.Metas {
+		if meta.Refines == "#"+id && meta.Property == property {
+			return strings.TrimSpace(meta.Value)
+		}
+	}
+
+	return ""
+}
+
+func (p opfPackage) item(id string) (opfItem, bool) {
+	for _, item := range p.Items {
+		if item.ID == id {
+			return item, true
+		}
+	}
+
+	return opfItem{}, false
+}
+
+// cover finds the EPUB 3 cover-image item and falls back to the EPUB 2 cover meta element.
+func (p opfPackage) cover() (opfItem, bool) {
+	for _, item := range p.Items {
+		if slices.Contains(strings.Fields(item.Properties), "cover-image") {
+			return item, true
+		}
+	}
+
+	for _, meta := range p.Metadata.Metas {
+		if meta.Name == "cover" {
+			return p.item(meta.Content)
+		}
+	}
+
+	return opfItem{}, false
+}
+
+func readEPUB(r io.ReaderAt, size int64) (entity.EbookMetadata, error) {
+	archive, err := zip.NewReader(r, size)
+
+	if err != nil {
+		return entity.EbookMetadata{}, fmt.Errorf("%w: epub archive: %s", entity.ErrInvalidEbook, err.Error())
+	}
+
+	content, err := readZipFile(archive, epubContainerPath, maxEpubDocumentSize)
+
+	if err != nil {
+		return entity.EbookMetadata{}, err
+	}
+
+	var container epubContainer
+	if err = xml.Unmarshal(content, &container); err != nil {
+		return entity.EbookMetadata{}, fmt.Errorf("%w: %s: %s", entity.ErrInvalidEbook, epubContainerPath, err.Error())
+	}
+
+	packagePath := ""
+	for _, rootfile := range container.Rootfiles {
+		if rootfile.MediaType == epubPackageType {
+			packagePath = rootfile.FullPath
+			break
+		}
+	}
+
+	if packagePath == "" {
+		return entity.EbookMetadata{}, fmt.Errorf("%w: no package document", entity.ErrInvalidEbook)
+	}
+
+	if content, err = readZipFile(archive, packagePath, maxEpubDocumentSize); err != nil {
+		return entity.EbookMetadata{}, err
+	}
+
+	var opf opfPackage
+	if err = xml.Unmarshal(content, &opf); err != nil {
+		return entity.EbookMetadata{}, fmt.Errorf("%w: %s: %s", entity.ErrInvalidEbook, packagePath, err.Error())
+	}
+
+	metadata := convertOPFMetadata(opf)
+
+	if item, ok := opf.cover(); ok {
+		// A broken cover does not make the book unreadable, it is left out.
+		cover, err := readZipFile(archive, epubHref(packagePath, item.Href), maxEbookCoverSize)
+
+		if err == nil {
+			metadata.Cover = cover
+			metadata.CoverContentType = item.MediaType
+		}
+	}
+
+	return metadata, nil
+}
+
+func convertOPFMetadata(opf opfPackage) entity.EbookMetadata {
+	metadata := entity.EbookMetadata{
+		Creators:    make([]entity.EbookCreator, 0, len(opf.Metadata.Creators)),
+		Identifiers: make([]string, 0, len(opf.Metadata.Identifiers)),
+	}
+
+	if len(opf.Metadata.Titles) > 0 {
+		metadata.Title = strings.Join(strings.Fields(opf.Metadata.Titles[0]), " ")
+	}
+
+	for _, creator := range opf.Metadata.Creators {
+		name := strings.TrimSpace(creator.Name)
+		if name == "" {
+			name = strings.TrimSpace(creator.FileAs)
+		}
+
+		if name == "" {
+			continue
+		}
+
+		role := strings.TrimSpace(creator.Role)
+		if role == "" {
+			role = opf.refinement(creator.ID, "role")
+		}
+
+		metadata.Creators = append(metadata.Creators, entity.EbookCreator{
+			Name: name,
+			Role: role,
+		})
+	}
+
+	if len(opf.Metadata.Languages) > 0 {
+		metadata.Language = strings.TrimSpace(opf.Metadata.Languages[0])
+	}
+
+	for _, identifier := range opf.Metadata.Identifiers {
+		value := strings.TrimSpace(identifier.Value)
+		if value == "" {
+			continue
+		}
+
+		metadata.Identifiers = append(metadata.Identifiers, value)
+
+		if metadata.ISBN == "" {
+			metadata.ISBN = ebookISBN(identifier.Scheme, value)
+		}
+	}
+
+	if len(opf.Metadata.Publishers) > 0 {
+		metadata.Publisher = strings.TrimSpace(opf.Metadata.Publishers[0])
+	}
+
+	if len(opf.Metadata.Dates) > 0 {
+		metadata.PublicationYear = ebookYear(opf.Metadata.Dates[0])
+	}
+
+	return metadata
+}
+
+// epubHref resolves a manifest href, which is relative to the package document.
+func epubHref(packagePath, href string) string {
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+
+	return path.Join(path.Dir(packagePath), href)
+}
+
+func readZipFile(archive *zip.Reader, name string, limit int64) ([]byte, error) {
+	file, err := archive.Open(name)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is missing", entity.ErrInvalidEbook, name)
+	}
+
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, limit+1))
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", entity.ErrInvalidEbook, name, err.Error())
+	}
+
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", entity.ErrInvalidEbook, name, limit)
+	}
+
+	return content, nil
+}
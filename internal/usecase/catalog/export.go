@@ -0,0 +1,108 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/project/library/internal/entity"
+)
+
+// Writer encodes books one by one so that exports do not keep the catalog in memory.
+// Close writes the trailer of the format, if it has one.
+type Writer interface {
+	Write(book entity.CatalogBook) error
+	Close() error
+}
+
+func NewWriter(format entity.CatalogFormat, w io.Writer) (Writer, error) {
+	switch format {
+	case entity.CatalogFormatCSV:
+		return newCSVWriter(w)
+	case entity.CatalogFormatJSONL:
+		return &jsonlWriter{encoder: json.NewEncoder(w)}, nil
+	case entity.CatalogFormatMARC21:
+		return &marc21Writer{w: w}, nil
+	case entity.CatalogFormatMARCXML:
+		return newMARCXMLWriter(w)
+	case entity.CatalogFormatBibTeX:
+		return &bibTeXWriter{w: w}, nil
+	case entity.CatalogFormatRIS:
+		return &risWriter{w: w}, nil
+	default:
+		return nil, entity.ErrUnsupportedCatalogFormat
+	}
+}
+
+// csvColumns are compatible with the csv import, the id column is ignored there.
+var csvColumns = []string{"id", "name", "authors", "isbn", "publisher", "publication_year"}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvColumns); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{writer: writer}, nil
+}
+
+func (c *csvWriter) Write(book entity.CatalogBook) error {
+	return c.writer.Write([]string{
+		book.Book.ID,
+		book.Book.Name,
+		strings.Join(book.AuthorNames, authorsSeparator),
+		book.Book.ISBN,
+		book.Book.Publisher,
+		formatYear(book.Book.PublicationYear),
+	})
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type jsonlBook struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Authors         []string `json:"authors"`
+	AuthorIDs       []string `json:"author_ids"`
+	ISBN            string   `json:"isbn,omitempty"`
+	Publisher       string   `json:"publisher,omitempty"`
+	PublicationYear int      `json:"publication_year,omitempty"`
+}
+
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+func (j *jsonlWriter) Write(book entity.CatalogBook) error {
+	return j.encoder.Encode(jsonlBook{
+		ID:              book.Book.ID,
+		Name:            book.Book.Name,
+		Authors:         book.AuthorNames,
+		AuthorIDs:       book.Book.AuthorIDs,
+		ISBN:            book.Book.ISBN,
+		Publisher:       book.Book.Publisher,
+		PublicationYear: book.Book.PublicationYear,
+	})
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
+
+func formatYear(year int) string {
+	if year == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(year)
+}
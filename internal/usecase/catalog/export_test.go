@@ -0,0 +1,98 @@
+package catalog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBooks(t *testing.T, format entity.CatalogFormat, books ...entity.CatalogBook) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	writer, err := NewWriter(format, &out)
+	require.NoError(t, err)
+
+	for _, book := range books {
+		require.NoError(t, writer.Write(book))
+	}
+
+	require.NoError(t, writer.Close())
+
+	return out.String()
+}
+
+func TestWriteCSV(t *testing.T) {
+	t.Parallel()
+
+	out := writeBooks(t, entity.CatalogFormatCSV, exportBook)
+	require.Equal(t, "id,name,authors,isbn,publisher,publication_year\n"+
+		"6b1f4ad2-3a5c-4c36-9d43-0c7b0d2a9f10,Good Omens,Terry Pratchett;Neil Gaiman,0060853980,William Morrow,2006\n", out)
+
+	rows, rowErrors, err := Parse(entity.CatalogFormatCSV, strings.NewReader(out))
+	require.NoError(t, err)
+	require.Empty(t, rowErrors)
+	require.Equal(t, exportBook.AuthorNames, rows[0].AuthorNames)
+	require.Equal(t, exportBook.Book.PublicationYear, rows[0].PublicationYear)
+}
+
+func TestWriteJSONL(t *testing.T) {
+	t.Parallel()
+
+	out := writeBooks(t, entity.CatalogFormatJSONL, exportBook, entity.CatalogBook{
+		Book: entity.Book{ID: "1", Name: "Untitled"},
+	})
+	require.Equal(t, `{"id":"6b1f4ad2-3a5c-4c36-9d43-0c7b0d2a9f10","name":"Good Omens",`+
+		`"authors":["Terry Pratchett","Neil Gaiman"],"author_ids":null,`+
+		`"isbn":"0060853980","publisher":"William Morrow","publication_year":2006}`+"\n"+
+		`{"id":"1","name":"Untitled","authors":null,"author_ids":null}`+"\n", out)
+}
+
+func TestWriteBibTeX(t *testing.T) {
+	t.Parallel()
+	book := exportBook
+	book.Book.Name = "Good Omens & 100% Nice_Witches {2nd}"
+
+	out := writeBooks(t, entity.CatalogFormatBibTeX, book)
+	require.Equal(t, `@book{pratchett2006_6b1f4ad2,
+  title = {Good Omens \& 100\% Nice\_Witches \{2nd\}},
+  author = {Pratchett, Terry and Gaiman, Neil},
+  publisher = {William Morrow},
+  year = {2006},
+  isbn = {0060853980},
+}
+
+`, out)
+}
+
+func TestWriteRIS(t *testing.T) {
+	t.Parallel()
+
+	out := writeBooks(t, entity.CatalogFormatRIS, exportBook)
+	require.Equal(t, `TY  - BOOK
+ID  - 6b1f4ad2-3a5c-4c36-9d43-0c7b0d2a9f10
+TI  - Good Omens
+AU  - Pratchett, Terry
+AU  - Gaiman, Neil
+PB  - William Morrow
+PY  - 2006
+SN  - 0060853980
+ER  - 
+
+`, out)
+}
+
+func TestFormatByName(t *testing.T) {
+	t.Parallel()
+
+	format, err := FormatByName(".BIB")
+	require.NoError(t, err)
+	require.Equal(t, entity.CatalogFormatBibTeX, format)
+	require.Equal(t, "bib", FileExtension(format))
+
+	_, err = FormatByName("pdf")
+	require.ErrorIs(t, err, entity.ErrUnsupportedCatalogFormat)
+}
@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"strings"
+
+	"github.com/project/library/internal/entity"
+)
+
+type formatInfo struct {
+	extension   string
+	contentType string
+}
+
+var formats = map[entity.CatalogFormat]formatInfo{
+	entity.CatalogFormatCSV:     {extension: "csv", contentType: "text/csv; charset=utf-8"},
+	entity.CatalogFormatJSONL:   {extension: "jsonl", contentType: "application/jsonl; charset=utf-8"},
+	entity.CatalogFormatMARC21:  {extension: "mrc", contentType: "application/marc"},
+	entity.CatalogFormatMARCXML: {extension: "xml", contentType: "application/marcxml+xml"},
+	entity.CatalogFormatBibTeX:  {extension: "bib", contentType: "application/x-bibtex; charset=utf-8"},
+	entity.CatalogFormatRIS:     {extension: "ris", contentType: "application/x-research-info-systems"},
+}
+
+var formatAliases = map[string]entity.CatalogFormat{
+	"csv":     entity.CatalogFormatCSV,
+	"jsonl":   entity.CatalogFormatJSONL,
+	"ndjson":  entity.CatalogFormatJSONL,
+	"marc21":  entity.CatalogFormatMARC21,
+	"marc":    entity.CatalogFormatMARC21,
+	"mrc":     entity.CatalogFormatMARC21,
+	"marcxml": entity.CatalogFormatMARCXML,
+	"xml":     entity.CatalogFormatMARCXML,
+	"bibtex":  entity.CatalogFormatBibTeX,
+	"bib":     entity.CatalogFormatBibTeX,
+	"ris":     entity.CatalogFormatRIS,
+}
+
+// FormatByName accepts a format name or a file extension, case-insensitively.
+func FormatByName(name string) (entity.CatalogFormat, error) {
+	format, ok := formatAliases[strings.ToLower(strings.TrimPrefix(name, "."))]
+
+	if !ok {
+		return entity.CatalogFormatUndefined, entity.ErrUnsupportedCatalogFormat
+	}
+
+	return format, nil
+}
+
+func FileExtension(format entity.CatalogFormat) string {
+	return formats[format].extension
+}
+
+func ContentType(format entity.CatalogFormat) string {
+	if info, ok := formats[format]; ok {
+		return info.contentType
+	}
+
+	return "application/octet-stream"
+}
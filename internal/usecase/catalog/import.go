@@ -0,0 +1,308 @@
+package catalog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+const (
+	// MaxImportRows bounds a single import, the row errors are kept in memory until its end.
+	MaxImportRows = 100000
+
+	authorsSeparator = ";"
+	maxLineSize      = 1024 * 1024
+)
+
+// authorNamePattern mirrors the validation of RegisterAuthorRequest.name.
+var authorNamePattern = regexp.MustCompile(`^[A-Za-z0-9]+( [A-Za-z0-9]+)*$`)
+
+// Parse reads catalog rows in the given format. Malformed rows are reported
+// as row errors and skipped, the returned error is set only for unreadable input.
+func Parse(format entity.CatalogFormat, r io.Reader) ([]entity.CatalogRow, []entity.CatalogRowError, error) {
+	rows := make([]entity.CatalogRow, 0)
+
+	rowErrors, err := ParseChunks(format, r, MaxImportRows, func(chunk []entity.CatalogRow) error {
+		rows = append(rows, chunk...)
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rows, rowErrors, nil
+}
+
+// ParseChunks reads catalog rows like Parse but keeps at most chunkSize of them in memory:
+// every full chunk and the rest at the end are passed to handle, an error of handle stops the parsing.
+func ParseChunks(
+	format entity.CatalogFormat,
+	r io.Reader,
+	chunkSize int,
+	handle func(rows []entity.CatalogRow) error,
+) ([]entity.CatalogRowError, error) {
+	p := &parser{
+		chunkSize: chunkSize,
+		handle:    handle,
+		errors:    make([]entity.CatalogRowError, 0),
+	}
+
+	var err error
+	switch format {
+	case entity.CatalogFormatCSV:
+		err = parseCSV(r, p)
+	case entity.CatalogFormatJSONL:
+		err = parseJSONL(r, p)
+	case entity.CatalogFormatMARC21:
+		err = parseMARC21(r, p)
+	case entity.CatalogFormatMARCXML:
+		err = parseMARCXML(r, p)
+	default:
+		err = entity.ErrUnsupportedCatalogFormat
+	}
+
+	if err == nil {
+		err = p.flush()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.errors, nil
+}
+
+type parser struct {
+	chunkSize int
+	handle    func(rows []entity.CatalogRow) error
+	// total counts the rows read so far, including the malformed ones
+	total  int
+	rows   []entity.CatalogRow
+	errors []entity.CatalogRowError
+}
+
+func (p *parser) add(raw entity.CatalogRow) error {
+	if p.total >= MaxImportRows {
+		return entity.ErrCatalogTooLarge
+	}
+
+	p.total++
+
+	row, err := newRow(raw)
+
+	if err != nil {
+		p.errors = append(p.errors, entity.CatalogRowError{
+			Line:    raw.Line,
+			Message: err.Error(),
+		})
+
+		return nil
+	}
+
+	p.rows = append(p.rows, row)
+
+	if len(p.rows) >= p.chunkSize {
+		return p.flush()
+	}
+
+	return nil
+}
+
+// fail reports a row that could not even be read.
+func (p *parser) fail(line int, message string) {
+	p.total++
+	p.errors = append(p.errors, entity.CatalogRowError{
+		Line:    line,
+		Message: message,
+	})
+}
+
+func (p *parser) flush() error {
+	if len(p.rows) == 0 {
+		return nil
+	}
+
+	rows := p.rows
+	p.rows = nil
+
+	return p.handle(rows)
+}
+
+func newRow(raw entity.CatalogRow) (entity.CatalogRow, error) {
+	row := entity.CatalogRow{
+		Line:            raw.Line,
+		Name:            strings.TrimSpace(raw.Name),
+		AuthorNames:     make([]string, 0, len(raw.AuthorNames)),
+		ISBN:            strings.TrimSpace(raw.ISBN),
+		Publisher:       strings.TrimSpace(raw.Publisher),
+		PublicationYear: raw.PublicationYear,
+	}
+
+	if row.Name == "" {
+		return entity.CatalogRow{}, errors.New("book name is empty")
+	}
+
+	if row.PublicationYear < 0 {
+		return entity.CatalogRow{}, fmt.Errorf("invalid publication year %d", row.PublicationYear)
+	}
+
+	for _, authorName := range raw.AuthorNames {
+		authorName = strings.TrimSpace(authorName)
+
+		if authorName == "" {
+			continue
+		}
+
+		if !authorNamePattern.MatchString(authorName) {
+			return entity.CatalogRow{}, fmt.Errorf("invalid author name %q", authorName)
+		}
+
+		// the same author listed twice, even in another case, would link the book to it twice
+		if !slices.ContainsFunc(row.AuthorNames, func(name string) bool {
+			return strings.EqualFold(name, authorName)
+		}) {
+			row.AuthorNames = append(row.AuthorNames, authorName)
+		}
+	}
+
+	return row, nil
+}
+
+// parseCSV expects a header with "name" and optional "authors", "isbn", "publisher"
+// and "publication_year" columns, several authors of a book are separated by a semicolon.
+func parseCSV(r io.Reader, p *parser) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: cannot read csv header: %s", entity.ErrInvalidCatalog, err.Error())
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	if _, ok := columns["name"]; !ok {
+		return fmt.Errorf("%w: csv header has no name column", entity.ErrInvalidCatalog)
+	}
+
+	for {
+		record, err := reader.Read()
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			p.fail(parseErr.StartLine, parseErr.Err.Error())
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return record[i]
+			}
+
+			return ""
+		}
+
+		if columns["name"] >= len(record) {
+			p.fail(line, "name column is missing")
+			continue
+		}
+
+		row := entity.CatalogRow{
+			Line:      line,
+			Name:      field("name"),
+			ISBN:      field("isbn"),
+			Publisher: field("publisher"),
+		}
+
+		if authors := field("authors"); authors != "" {
+			row.AuthorNames = strings.Split(authors, authorsSeparator)
+		}
+
+		if year := strings.TrimSpace(field("publication_year")); year != "" {
+			if row.PublicationYear, err = strconv.Atoi(year); err != nil {
+				p.fail(line, fmt.Sprintf("invalid publication year %q", year))
+				continue
+			}
+		}
+
+		if err = p.add(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type jsonRow struct {
+	Name            string   `json:"name"`
+	Authors         []string `json:"authors"`
+	ISBN            string   `json:"isbn"`
+	Publisher       string   `json:"publisher"`
+	PublicationYear int      `json:"publication_year"`
+}
+
+func parseJSONL(r io.Reader, p *parser) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		data := strings.TrimSpace(scanner.Text())
+
+		if data == "" {
+			continue
+		}
+
+		var row jsonRow
+		if err := json.Unmarshal([]byte(data), &row); err != nil {
+			p.fail(line, err.Error())
+			continue
+		}
+
+		err := p.add(entity.CatalogRow{
+			Line:            line,
+			Name:            row.Name,
+			AuthorNames:     row.Authors,
+			ISBN:            row.ISBN,
+			Publisher:       row.Publisher,
+			PublicationYear: row.PublicationYear,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: cannot read jsonl: %s", entity.ErrInvalidCatalog, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Parallel()
+	data := `name,authors
+Dune,Frank Herbert
+"Good Omens","Terry Pratchett; Neil Gaiman;Terry Pratchett;TERRY PRATCHETT"
+,Nobody
+Anonymous,
+Broken,"Bad-Name"
+`
+
+	rows, rowErrors, err := Parse(entity.CatalogFormatCSV, strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, []entity.CatalogRow{
+		{Line: 2, Name: "Dune", AuthorNames: []string{"Frank Herbert"}},
+		{Line: 3, Name: "Good Omens", AuthorNames: []string{"Terry Pratchett", "Neil Gaiman"}},
+		{Line: 5, Name: "Anonymous", AuthorNames: []string{}},
+	}, rows)
+	require.Len(t, rowErrors, 2)
+	require.Equal(t, 4, rowErrors[0].Line)
+	require.Equal(t, 6, rowErrors[1].Line)
+}
+
+func TestParseCSVWithoutNameColumn(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := Parse(entity.CatalogFormatCSV, strings.NewReader("title,authors\nDune,Frank Herbert\n"))
+	require.ErrorIs(t, err, entity.ErrInvalidCatalog)
+}
+
+func TestParseJSONL(t *testing.T) {
+	t.Parallel()
+	data := `{"name": "Dune", "authors": ["Frank Herbert"]}
+
+{"name": "Good Omens", "authors": ["Terry Pratchett", "Neil Gaiman"]}
+{"name": 
+{"authors": ["Frank Herbert"]}
+`
+
+	rows, rowErrors, err := Parse(entity.CatalogFormatJSONL, strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, []entity.CatalogRow{
+		{Line: 1, Name: "Dune", AuthorNames: []string{"Frank Herbert"}},
+		{Line: 3, Name: "Good Omens", AuthorNames: []string{"Terry Pratchett", "Neil Gaiman"}},
+	}, rows)
+	require.Equal(t, []int{4, 5}, []int{rowErrors[0].Line, rowErrors[1].Line})
+}
+
+func TestParseChunks(t *testing.T) {
+	t.Parallel()
+	data := `{"name": "Dune"}
+{"name": ""}
+{"name": "Emma"}
+{"name": "Ulysses"}
+`
+
+	var chunks [][]string
+	rowErrors, err := ParseChunks(entity.CatalogFormatJSONL, strings.NewReader(data), 2, func(rows []entity.CatalogRow) error {
+		names := make([]string, len(rows))
+		for i, row := range rows {
+			names[i] = row.Name
+		}
+
+		chunks = append(chunks, names)
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"Dune", "Emma"}, {"Ulysses"}}, chunks)
+	require.Len(t, rowErrors, 1)
+	require.Equal(t, 2, rowErrors[0].Line)
+
+	_, err = ParseChunks(entity.CatalogFormatJSONL, strings.NewReader(data), 1, func([]entity.CatalogRow) error {
+		return entity.ErrInvalidCatalog
+	})
+	require.ErrorIs(t, err, entity.ErrInvalidCatalog)
+}
+
+func TestParseUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := Parse(entity.CatalogFormatUndefined, strings.NewReader(""))
+	require.ErrorIs(t, err, entity.ErrUnsupportedCatalogFormat)
+}
@@ -0,0 +1,505 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+const (
+	marcLeaderLength      = 24
+	marcDirectoryEntry    = 12
+	marcFieldTerminator   = 0x1e
+	marcRecordTerminator  = 0x1d
+	marcSubfieldDelimiter = 0x1f
+	marcMaxRecordLength   = 99999
+	marcMaxFieldLength    = 9999
+
+	marcXMLNamespace = "http://www.loc.gov/MARC21/slim"
+
+	// marcLeader describes a monograph encoded in UTF-8, the record length
+	// and the base address of data are filled in when the record is written.
+	marcLeader = "00000nam a2200000 i 4500"
+)
+
+// isbdPunctuation is the trailing punctuation ISBD puts between MARC subfields.
+const isbdPunctuation = " /:;,.="
+
+var yearPattern = regexp.MustCompile(`\d{4}`)
+
+type marcSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+type marcControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type marcDataField struct {
+	Tag       string         `xml:"tag,attr"`
+	Ind1      string         `xml:"ind1,attr"`
+	Ind2      string         `xml:"ind2,attr"`
+	Subfields []marcSubfield `xml:"subfield"`
+}
+
+type marcRecord struct {
+	XMLName       xml.Name           `xml:"record"`
+	Leader        string             `xml:"leader"`
+	ControlFields []marcControlField `xml:"controlfield"`
+	DataFields    []marcDataField    `xml:"datafield"`
+}
+
+func (r marcRecord) fields(tag string) []marcDataField {
+	result := make([]marcDataField, 0)
+	for _, field := range r.DataFields {
+		if field.Tag == tag {
+			result = append(result, field)
+		}
+	}
+
+	return result
+}
+
+func (f marcDataField) subfield(code string) string {
+	for _, subfield := range f.Subfields {
+		if subfield.Code == code {
+			return subfield.Value
+		}
+	}
+
+	return ""
+}
+
+// parseMARC21 reads ISO 2709 records one by one using the length from their leaders.
+func parseMARC21(r io.Reader, p *parser) error {
+	reader := bufio.NewReader(r)
+
+	for number := 1; ; number++ {
+		data, err := readMARC21Record(reader)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("%w: record %d: %s", entity.ErrInvalidCatalog, number, err.Error())
+		}
+
+		record, err := decodeMARC21(data)
+
+		if err != nil {
+			p.fail(number, err.Error())
+			continue
+		}
+
+		if err = p.add(marcRecordToRow(number, record)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readMARC21Record(reader *bufio.Reader) ([]byte, error) {
+	// records are often separated by line breaks when edited by hand
+	for {
+		b, err := reader.ReadByte()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if b != '\n' && b != '\r' {
+			_ = reader.UnreadByte()
+			break
+		}
+	}
+
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, errors.New("truncated record length")
+	}
+
+	length, err := strconv.Atoi(string(header))
+
+	if err != nil || length <= marcLeaderLength {
+		return nil, fmt.Errorf("invalid record length %q", header)
+	}
+
+	data := make([]byte, length)
+	copy(data, header)
+
+	if _, err = io.ReadFull(reader, data[len(header):]); err != nil {
+		return nil, errors.New("truncated record")
+	}
+
+	return data, nil
+}
+
+func decodeMARC21(data []byte) (marcRecord, error) {
+	if data[len(data)-1] != marcRecordTerminator {
+		return marcRecord{}, errors.New("record terminator is missing")
+	}
+
+	record := marcRecord{
+		Leader: string(data[:marcLeaderLength]),
+	}
+
+	base, err := strconv.Atoi(record.Leader[12:17])
+
+	if err != nil || base <= marcLeaderLength || base > len(data) || data[base-1] != marcFieldTerminator {
+		return marcRecord{}, fmt.Errorf("invalid base address %q", record.Leader[12:17])
+	}
+
+	directory := data[marcLeaderLength : base-1]
+
+	if len(directory)%marcDirectoryEntry != 0 {
+		return marcRecord{}, errors.New("invalid directory length")
+	}
+
+	for i := 0; i < len(directory); i += marcDirectoryEntry {
+		entry := string(directory[i : i+marcDirectoryEntry])
+		tag := entry[:3]
+		length, lengthErr := strconv.Atoi(entry[3:7])
+		start, startErr := strconv.Atoi(entry[7:12])
+
+		if lengthErr != nil || startErr != nil || length == 0 || base+start+length > len(data) {
+			return marcRecord{}, fmt.Errorf("invalid directory entry for field %s", tag)
+		}
+
+		field := bytes.TrimSuffix(data[base+start:base+start+length], []byte{marcFieldTerminator})
+
+		if strings.HasPrefix(tag, "00") {
+			record.ControlFields = append(record.ControlFields, marcControlField{
+				Tag:   tag,
+				Value: string(field),
+			})
+			continue
+		}
+
+		if len(field) < 2 {
+			return marcRecord{}, fmt.Errorf("field %s has no indicators", tag)
+		}
+
+		dataField := marcDataField{
+			Tag:  tag,
+			Ind1: string(field[0]),
+			Ind2: string(field[1]),
+		}
+
+		for _, subfield := range bytes.Split(field[2:], []byte{marcSubfieldDelimiter}) {
+			if len(subfield) == 0 {
+				continue
+			}
+
+			dataField.Subfields = append(dataField.Subfields, marcSubfield{
+				Code:  string(subfield[0]),
+				Value: string(subfield[1:]),
+			})
+		}
+
+		record.DataFields = append(record.DataFields, dataField)
+	}
+
+	return record, nil
+}
+
+// parseMARCXML decodes the record elements of a MARCXML collection one at a time.
+func parseMARCXML(r io.Reader, p *parser) error {
+	decoder := xml.NewDecoder(r)
+
+	number := 0
+	for {
+		token, err := decoder.Token()
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("%w: %s", entity.ErrInvalidCatalog, err.Error())
+		}
+
+		start, ok := token.(xml.StartElement)
+
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		number++
+
+		var record marcRecord
+		if err = decoder.DecodeElement(&record, &start); err != nil {
+			return fmt.Errorf("%w: record %d: %s", entity.ErrInvalidCatalog, number, err.Error())
+		}
+
+		if err = p.add(marcRecordToRow(number, record)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// marcRecordToRow takes the title from 245, authors from 100 and 700,
+// the ISBN from 020 and publication from 264 (or 260 in older records).
+func marcRecordToRow(number int, record marcRecord) entity.CatalogRow {
+	row := entity.CatalogRow{
+		Line:        number,
+		AuthorNames: make([]string, 0),
+	}
+
+	if titles := record.fields("245"); len(titles) > 0 {
+		row.Name = trimISBD(titles[0].subfield("a"))
+
+		if subtitle := trimISBD(titles[0].subfield("b")); subtitle != "" {
+			row.Name += ": " + subtitle
+		}
+	}
+
+	for _, tag := range []string{"100", "700"} {
+		for _, field := range record.fields(tag) {
+			if name := marcNameToAuthor(field.subfield("a")); name != "" {
+				row.AuthorNames = append(row.AuthorNames, name)
+			}
+		}
+	}
+
+	if isbns := record.fields("020"); len(isbns) > 0 {
+		row.ISBN = normalizeISBN(isbns[0].subfield("a"))
+	}
+
+	publication := make([]marcDataField, 0)
+	for _, field := range record.fields("264") {
+		if field.Ind2 == "1" {
+			publication = append(publication, field)
+		}
+	}
+	publication = append(publication, record.fields("260")...)
+
+	if len(publication) > 0 {
+		row.Publisher = trimISBD(publication[0].subfield("b"))
+
+		if year := yearPattern.FindString(publication[0].subfield("c")); year != "" {
+			row.PublicationYear, _ = strconv.Atoi(year)
+		}
+	}
+
+	return row
+}
+
+func trimISBD(value string) string {
+	return strings.TrimRight(strings.TrimSpace(value), isbdPunctuation)
+}
+
+// marcNameToAuthor turns an inverted heading like "Tolkien, J. R. R.,"
+// into the "J R R Tolkien" form accepted for author names.
+func marcNameToAuthor(heading string) string {
+	heading = trimISBD(heading)
+
+	if surname, forename, ok := strings.Cut(heading, ", "); ok {
+		heading = forename + " " + surname
+	}
+
+	return strings.Join(strings.Fields(strings.ReplaceAll(heading, ".", " ")), " ")
+}
+
+// invertName turns "Frank Herbert" into "Herbert, Frank", the last word is taken as the surname.
+func invertName(name string) string {
+	words := strings.Fields(name)
+
+	if len(words) < 2 {
+		return name
+	}
+
+	return words[len(words)-1] + ", " + strings.Join(words[:len(words)-1], " ")
+}
+
+func normalizeISBN(value string) string {
+	fields := strings.Fields(value)
+
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == 'X' || r == 'x' {
+			return r
+		}
+
+		return -1
+	}, fields[0])
+}
+
+func bookToMARCRecord(book entity.CatalogBook) marcRecord {
+	record := marcRecord{
+		Leader: marcLeader,
+		ControlFields: []marcControlField{
+			{Tag: "001", Value: book.Book.ID},
+		},
+	}
+
+	if book.Book.ISBN != "" {
+		record.DataFields = append(record.DataFields, marcDataField{
+			Tag: "020", Ind1: " ", Ind2: " ",
+			Subfields: []marcSubfield{{Code: "a", Value: book.Book.ISBN}},
+		})
+	}
+
+	titleInd1 := "0"
+	if len(book.AuthorNames) > 0 {
+		titleInd1 = "1"
+		record.DataFields = append(record.DataFields, marcDataField{
+			Tag: "100", Ind1: "1", Ind2: " ",
+			Subfields: []marcSubfield{{Code: "a", Value: invertName(book.AuthorNames[0])}},
+		})
+	}
+
+	record.DataFields = append(record.DataFields, marcDataField{
+		Tag: "245", Ind1: titleInd1, Ind2: "0",
+		Subfields: []marcSubfield{{Code: "a", Value: book.Book.Name}},
+	})
+
+	if book.Book.Publisher != "" || book.Book.PublicationYear != 0 {
+		publication := marcDataField{Tag: "264", Ind1: " ", Ind2: "1"}
+
+		if book.Book.Publisher != "" {
+			publication.Subfields = append(publication.Subfields, marcSubfield{Code: "b", Value: book.Book.Publisher})
+		}
+
+		if book.Book.PublicationYear != 0 {
+			publication.Subfields = append(publication.Subfields, marcSubfield{Code: "c", Value: strconv.Itoa(book.Book.PublicationYear)})
+		}
+
+		record.DataFields = append(record.DataFields, publication)
+	}
+
+	for _, name := range book.AuthorNames[min(1, len(book.AuthorNames)):] {
+		record.DataFields = append(record.DataFields, marcDataField{
+			Tag: "700", Ind1: "1", Ind2: " ",
+			Subfields: []marcSubfield{{Code: "a", Value: invertName(name)}},
+		})
+	}
+
+	return record
+}
+
+func encodeMARC21(record marcRecord) ([]byte, error) {
+	var directory, fields bytes.Buffer
+
+	add := func(tag string, field []byte) error {
+		if len(field)+1 > marcMaxFieldLength {
+			return fmt.Errorf("field %s is too long", tag)
+		}
+
+		_, _ = fmt.Fprintf(&directory, "%s%04d%05d", tag, len(field)+1, fields.Len())
+		fields.Write(field)
+		fields.WriteByte(marcFieldTerminator)
+
+		return nil
+	}
+
+	for _, field := range record.ControlFields {
+		if err := add(field.Tag, []byte(field.Value)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, field := range record.DataFields {
+		var data bytes.Buffer
+		data.WriteString(field.Ind1)
+		data.WriteString(field.Ind2)
+
+		for _, subfield := range field.Subfields {
+			data.WriteByte(marcSubfieldDelimiter)
+			data.WriteString(subfield.Code)
+			data.WriteString(subfield.Value)
+		}
+
+		if err := add(field.Tag, data.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	directory.WriteByte(marcFieldTerminator)
+
+	base := marcLeaderLength + directory.Len()
+	length := base + fields.Len() + 1
+
+	if length > marcMaxRecordLength {
+		return nil, errors.New("record is too long")
+	}
+
+	result := make([]byte, 0, length)
+	result = fmt.Appendf(result, "%05d%s%05d%s", length, record.Leader[5:12], base, record.Leader[17:])
+	result = append(result, directory.Bytes()...)
+	result = append(result, fields.Bytes()...)
+	result = append(result, marcRecordTerminator)
+
+	return result, nil
+}
+
+type marc21Writer struct {
+	w io.Writer
+}
+
+func (m *marc21Writer) Write(book entity.CatalogBook) error {
+	data, err := encodeMARC21(bookToMARCRecord(book))
+
+	if err != nil {
+		return fmt.Errorf("cannot encode book %s: %w", book.Book.ID, err)
+	}
+
+	_, err = m.w.Write(data)
+
+	return err
+}
+
+func (m *marc21Writer) Close() error {
+	return nil
+}
+
+type marcXMLWriter struct {
+	w       io.Writer
+	encoder *xml.Encoder
+}
+
+func newMARCXMLWriter(w io.Writer) (*marcXMLWriter, error) {
+	if _, err := io.WriteString(w, xml.Header+`<collection xmlns="`+marcXMLNamespace+`">`+"\n"); err != nil {
+		return nil, err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	return &marcXMLWriter{
+		w:       w,
+		encoder: encoder,
+	}, nil
+}
+
+func (m *marcXMLWriter) Write(book entity.CatalogBook) error {
+	if err := m.encoder.Encode(bookToMARCRecord(book)); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(m.w, "\n")
+
+	return err
+}
+
+func (m *marcXMLWriter) Close() error {
+	_, err := io.WriteString(m.w, "</collection>\n")
+
+	return err
+}
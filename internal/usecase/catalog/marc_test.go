@@ -0,0 +1,129 @@
+package catalog
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMARC21(t *testing.T) {
+	t.Parallel()
+	file, err := os.Open("testdata/books.mrc")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = file.Close()
+	})
+
+	rows, rowErrors, err := Parse(entity.CatalogFormatMARC21, file)
+	require.NoError(t, err)
+	require.Equal(t, []entity.CatalogRow{
+		{
+			Line:            1,
+			Name:            "Dune",
+			AuthorNames:     []string{"Frank Herbert"},
+			ISBN:            "9780441013593",
+			Publisher:       "Ace Books",
+			PublicationYear: 1965,
+		},
+		{
+			Line:            2,
+			Name:            "Good omens: the nice and accurate prophecies of Agnes Nutter, witch",
+			AuthorNames:     []string{"Terry Pratchett", "Neil Gaiman"},
+			ISBN:            "0060853980",
+			Publisher:       "William Morrow",
+			PublicationYear: 2006,
+		},
+		{
+			Line:        4,
+			Name:        "The hobbit, or, There and back again",
+			AuthorNames: []string{"J R R Tolkien"},
+		},
+	}, rows)
+	require.Len(t, rowErrors, 1)
+	require.Equal(t, 3, rowErrors[0].Line)
+}
+
+func TestParseMARC21Truncated(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile("testdata/books.mrc")
+	require.NoError(t, err)
+
+	_, _, err = Parse(entity.CatalogFormatMARC21, bytes.NewReader(data[:100]))
+	require.ErrorIs(t, err, entity.ErrInvalidCatalog)
+}
+
+func TestParseMARCXML(t *testing.T) {
+	t.Parallel()
+	file, err := os.Open("testdata/books.xml")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = file.Close()
+	})
+
+	rows, rowErrors, err := Parse(entity.CatalogFormatMARCXML, file)
+	require.NoError(t, err)
+	require.Equal(t, []entity.CatalogRow{
+		{
+			Line:            1,
+			Name:            "Dune",
+			AuthorNames:     []string{"Frank Herbert"},
+			ISBN:            "9780441013593",
+			Publisher:       "Ace Books",
+			PublicationYear: 1965,
+		},
+	}, rows)
+	require.Len(t, rowErrors, 1)
+	require.Equal(t, 2, rowErrors[0].Line)
+}
+
+var exportBook = entity.CatalogBook{
+	Book: entity.Book{
+		ID:              "6b1f4ad2-3a5c-4c36-9d43-0c7b0d2a9f10",
+		Name:            "Good Omens",
+		ISBN:            "0060853980",
+		Publisher:       "William Morrow",
+		PublicationYear: 2006,
+	},
+	AuthorNames: []string{"Terry Pratchett", "Neil Gaiman"},
+}
+
+func TestWriteMARC21(t *testing.T) {
+	t.Parallel()
+	expected, err := os.ReadFile("testdata/export.mrc")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	writer, err := NewWriter(entity.CatalogFormatMARC21, &out)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(exportBook))
+	require.NoError(t, writer.Close())
+	require.Equal(t, expected, out.Bytes())
+}
+
+func TestMARCRoundTrip(t *testing.T) {
+	t.Parallel()
+	expected := entity.CatalogRow{
+		Line:            1,
+		Name:            exportBook.Book.Name,
+		AuthorNames:     exportBook.AuthorNames,
+		ISBN:            exportBook.Book.ISBN,
+		Publisher:       exportBook.Book.Publisher,
+		PublicationYear: exportBook.Book.PublicationYear,
+	}
+
+	for _, format := range []entity.CatalogFormat{entity.CatalogFormatMARC21, entity.CatalogFormatMARCXML} {
+		var out bytes.Buffer
+		writer, err := NewWriter(format, &out)
+		require.NoError(t, err)
+		require.NoError(t, writer.Write(exportBook))
+		require.NoError(t, writer.Close())
+
+		rows, rowErrors, err := Parse(format, &out)
+		require.NoError(t, err)
+		require.Empty(t, rowErrors)
+		require.Equal(t, []entity.CatalogRow{expected}, rows)
+	}
+}
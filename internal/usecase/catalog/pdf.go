@@ -0,0 +1,535 @@
+package catalog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+const (
+	pdfHeader = "%PDF-"
+
+	// pdfTrailerWindow is how much of both ends of the file is searched for the trailer,
+	// linearized files keep the document information reference in the first page trailer.
+	pdfTrailerWindow = 1 << 20
+	pdfScanChunk     = 4 << 20
+	pdfScanOverlap   = 64
+	pdfMaxObjectSize = 64 << 10
+)
+
+var (
+	pdfInfoPattern    = regexp.MustCompile(`/Info\s+(\d+)\s+(\d+)\s+R`)
+	pdfRootPattern    = regexp.MustCompile(`/Root\s+(\d+)\s+(\d+)\s+R`)
+	pdfEncryptPattern = regexp.MustCompile(`/Encrypt\s`)
+
+	// pdfAuthorsSeparator splits the Author entry, which is a free-form string often listing several people.
+	pdfAuthorsSeparator = regexp.MustCompile(`\s*(?:;|&|\band\b)\s*`)
+)
+
+type pdfRef struct {
+	num int
+	gen int
+}
+
+type pdfTokenKind int
+
+const (
+	pdfTokenOther pdfTokenKind = iota
+	pdfTokenName
+	pdfTokenString
+	pdfTokenDictStart
+	pdfTokenDictEnd
+	pdfTokenArrayStart
+	pdfTokenArrayEnd
+)
+
+type pdfToken struct {
+	kind  pdfTokenKind
+	value []byte
+}
+
+// pdfValue is a dictionary entry: a string, a name, an indirect reference or
+// something else this reader does not need, like a number or a nested dictionary.
+type pdfValue struct {
+	kind pdfTokenKind
+	data []byte
+	ref  *pdfRef
+}
+
+// readPDF reads the document information dictionary and the language of the document catalog.
+// Object streams and cross-reference streams are not decoded, so metadata kept only in compressed
+// objects is not found, the result is then empty rather than an error.
+func readPDF(r io.ReaderAt, size int64) (entity.EbookMetadata, error) {
+	head := make([]byte, min(size, pdfTrailerWindow))
+	if _, err := r.ReadAt(head, 0); err != nil && !errors.Is(err, io.EOF) {
+		return entity.EbookMetadata{}, err
+	}
+
+	if !bytes.HasPrefix(head, []byte(pdfHeader)) {
+		return entity.EbookMetadata{}, fmt.Errorf("%w: no pdf header", entity.ErrInvalidEbook)
+	}
+
+	tail := make([]byte, min(size, pdfTrailerWindow))
+	if _, err := r.ReadAt(tail, size-int64(len(tail))); err != nil && !errors.Is(err, io.EOF) {
+		return entity.EbookMetadata{}, err
+	}
+
+	if pdfEncryptPattern.Match(tail) {
+		return entity.EbookMetadata{}, fmt.Errorf("%w: pdf is encrypted", entity.ErrInvalidEbook)
+	}
+
+	reader := &pdfReader{r: r, size: size}
+	metadata := entity.EbookMetadata{
+		Creators:    make([]entity.EbookCreator, 0),
+		Identifiers: make([]string, 0),
+	}
+
+	if ref, ok := findPDFRef(pdfInfoPattern, tail, head); ok {
+		info, err := reader.dictionary(ref)
+
+		if err != nil {
+			return entity.EbookMetadata{}, err
+		}
+
+		metadata.Title = strings.Join(strings.Fields(reader.text(info["Title"])), " ")
+
+		for _, name := range pdfAuthorsSeparator.Split(reader.text(info["Author"]), -1) {
+			if name = strings.TrimSpace(name); name != "" {
+				metadata.Creators = append(metadata.Creators, entity.EbookCreator{Name: name})
+			}
+		}
+
+		metadata.PublicationYear = ebookYear(reader.text(info["CreationDate"]))
+	}
+
+	if ref, ok := findPDFRef(pdfRootPattern, tail, head); ok {
+		catalog, err := reader.dictionary(ref)
+
+		if err != nil {
+			return entity.EbookMetadata{}, err
+		}
+
+		metadata.Language = strings.TrimSpace(reader.text(catalog["Lang"]))
+	}
+
+	return metadata, nil
+}
+
+// findPDFRef returns the last reference in the first window that has one,
+// an incremental update appends a trailer that supersedes the previous ones.
+func findPDFRef(pattern *regexp.Regexp, windows ...[]byte) (pdfRef, bool) {
+	for _, window := range windows {
+		matches := pattern.FindAllSubmatch(window, -1)
+
+		if len(matches) == 0 {
+			continue
+		}
+
+		match := matches[len(matches)-1]
+		num, _ := strconv.Atoi(string(match[1]))
+		gen, _ := strconv.Atoi(string(match[2]))
+
+		return pdfRef{num: num, gen: gen}, true
+	}
+
+	return pdfRef{}, false
+}
+
+type pdfReader struct {
+	r    io.ReaderAt
+	size int64
+}
+
+// object finds the body of an indirect object by scanning the file for its header,
+// the last definition wins. Nil is returned for a missing object.
+func (p *pdfReader) object(ref pdfRef) ([]byte, error) {
+	pattern := regexp.MustCompile(fmt.Sprintf(`(?:^|\D)%d\s+%d\s+obj\b`, ref.num, ref.gen))
+	buf := make([]byte, pdfScanChunk+pdfScanOverlap)
+	found := int64(-1)
+
+	for offset := int64(0); offset < p.size; offset += pdfScanChunk {
+		n, err := p.r.ReadAt(buf, offset)
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		if matches := pattern.FindAllIndex(buf[:n], -1); len(matches) > 0 {
+			found = offset + int64(matches[len(matches)-1][1])
+		}
+	}
+
+	if found < 0 {
+		return nil, nil
+	}
+
+	body := make([]byte, min(p.size-found, pdfMaxObjectSize))
+	if _, err := p.r.ReadAt(body, found); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if end := bytes.Index(body, []byte("endobj")); end >= 0 {
+		body = body[:end]
+	}
+
+	return body, nil
+}
+
+func (p *pdfReader) dictionary(ref pdfRef) (map[string]pdfValue, error) {
+	body, err := p.object(ref)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePDFDictionary(body), nil
+}
+
+// text decodes a string value, following one indirect reference. Anything else is empty.
+func (p *pdfReader) text(value pdfValue) string {
+	if value.ref != nil {
+		body, err := p.object(*value.ref)
+
+		if err != nil || body == nil {
+			return ""
+		}
+
+		lexer := &pdfLexer{data: body}
+		token, ok := lexer.next()
+
+		if !ok {
+			return ""
+		}
+
+		value = pdfValue{kind: token.kind, data: token.value}
+	}
+
+	if value.kind != pdfTokenString {
+		return ""
+	}
+
+	return decodePDFText(value.data)
+}
+
+// parsePDFDictionary reads the top level entries of the first dictionary in data.
+func parsePDFDictionary(data []byte) map[string]pdfValue {
+	entries := make(map[string]pdfValue)
+	lexer := &pdfLexer{data: data}
+
+	for {
+		token, ok := lexer.next()
+
+		if !ok {
+			return entries
+		}
+
+		if token.kind == pdfTokenDictStart {
+			break
+		}
+	}
+
+	for {
+		key, ok := lexer.next()
+
+		if !ok || key.kind == pdfTokenDictEnd {
+			return entries
+		}
+
+		if key.kind != pdfTokenName {
+			continue
+		}
+
+		value, ok := lexer.value()
+
+		if !ok {
+			return entries
+		}
+
+		entries[string(key.value)] = value
+	}
+}
+
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+// value reads a dictionary value, nested dictionaries and arrays are skipped.
+func (l *pdfLexer) value() (pdfValue, bool) {
+	token, ok := l.next()
+
+	if !ok {
+		return pdfValue{}, false
+	}
+
+	switch token.kind {
+	case pdfTokenDictStart, pdfTokenArrayStart:
+		return pdfValue{kind: token.kind}, l.skipNested()
+	case pdfTokenOther:
+		if ref, ok := l.ref(token); ok {
+			return pdfValue{ref: &ref}, true
+		}
+	}
+
+	return pdfValue{kind: token.kind, data: token.value}, true
+}
+
+// ref checks whether a number is followed by a generation and the R keyword.
+func (l *pdfLexer) ref(num pdfToken) (pdfRef, bool) {
+	pos := l.pos
+	gen, genOK := l.next()
+	keyword, keywordOK := l.next()
+
+	if genOK && keywordOK && keyword.kind == pdfTokenOther && string(keyword.value) == "R" {
+		n, numErr := strconv.Atoi(string(num.value))
+		g, genErr := strconv.Atoi(string(gen.value))
+
+		if numErr == nil && genErr == nil {
+			return pdfRef{num: n, gen: g}, true
+		}
+	}
+
+	l.pos = pos
+
+	return pdfRef{}, false
+}
+
+func (l *pdfLexer) skipNested() bool {
+	depth := 1
+
+	for depth > 0 {
+		token, ok := l.next()
+
+		if !ok {
+			return false
+		}
+
+		switch token.kind {
+		case pdfTokenDictStart, pdfTokenArrayStart:
+			depth++
+		case pdfTokenDictEnd, pdfTokenArrayEnd:
+			depth--
+		}
+	}
+
+	return true
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *pdfLexer) next() (pdfToken, bool) {
+	l.skipSpace()
+
+	if l.pos >= len(l.data) {
+		return pdfToken{}, false
+	}
+
+	rest := l.data[l.pos:]
+
+	switch {
+	case bytes.HasPrefix(rest, []byte("<<")):
+		l.pos += 2
+		return pdfToken{kind: pdfTokenDictStart}, true
+	case bytes.HasPrefix(rest, []byte(">>")):
+		l.pos += 2
+		return pdfToken{kind: pdfTokenDictEnd}, true
+	case rest[0] == '[':
+		l.pos++
+		return pdfToken{kind: pdfTokenArrayStart}, true
+	case rest[0] == ']':
+		l.pos++
+		return pdfToken{kind: pdfTokenArrayEnd}, true
+	case rest[0] == '(':
+		l.pos++
+		return pdfToken{kind: pdfTokenString, value: l.literalString()}, true
+	case rest[0] == '<':
+		l.pos++
+		return pdfToken{kind: pdfTokenString, value: l.hexString()}, true
+	case rest[0] == '/':
+		l.pos++
+		return pdfToken{kind: pdfTokenName, value: l.regular()}, true
+	default:
+		value := l.regular()
+
+		if len(value) == 0 {
+			// A stray delimiter like ')' or '>', it is skipped.
+			value = l.data[l.pos : l.pos+1]
+			l.pos++
+		}
+
+		return pdfToken{kind: pdfTokenOther, value: value}, true
+	}
+}
+
+func (l *pdfLexer) regular() []byte {
+	start := l.pos
+
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+
+	return l.data[start:l.pos]
+}
+
+// literalString reads a string in parentheses, balanced parentheses are part of the string.
+func (l *pdfLexer) literalString() []byte {
+	result := make([]byte, 0)
+	depth := 1
+
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+
+			if depth == 0 {
+				return result
+			}
+		case '\\':
+			if escaped, ok := l.escape(); ok {
+				result = append(result, escaped)
+			}
+
+			continue
+		}
+
+		result = append(result, c)
+	}
+
+	return result
+}
+
+func (l *pdfLexer) escape() (byte, bool) {
+	if l.pos >= len(l.data) {
+		return 0, false
+	}
+
+	c := l.data[l.pos]
+	l.pos++
+
+	switch c {
+	case 'n':
+		return '\n', true
+	case 'r':
+		return '\r', true
+	case 't':
+		return '\t', true
+	case 'b':
+		return '\b', true
+	case 'f':
+		return '\f', true
+	case '\r':
+		// A backslash at the end of a line continues the string on the next one.
+		if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+			l.pos++
+		}
+
+		return 0, false
+	case '\n':
+		return 0, false
+	}
+
+	if c < '0' || c > '7' {
+		return c, true
+	}
+
+	value := int(c - '0')
+	for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+		value = value*8 + int(l.data[l.pos]-'0')
+		l.pos++
+	}
+
+	return byte(value), true
+}
+
+// hexString reads a string in angle brackets, a missing final digit is taken as zero.
+func (l *pdfLexer) hexString() []byte {
+	digits := make([]byte, 0)
+
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; !isPDFSpace(c) {
+			digits = append(digits, c)
+		}
+
+		l.pos++
+	}
+
+	l.pos++
+
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	result := make([]byte, 0, len(digits)/2)
+	for i := 0; i < len(digits); i += 2 {
+		value, err := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+
+		if err != nil {
+			return result
+		}
+
+		result = append(result, byte(value))
+	}
+
+	return result
+}
+
+func isPDFSpace(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+// decodePDFText decodes a text string, which is UTF-16BE with a byte order mark, UTF-8 with
+// a byte order mark or PDFDocEncoding. The latter matches Latin-1 in its printable range.
+func decodePDFText(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xfe, 0xff}):
+		data = data[2:]
+		units := make([]uint16, 0, len(data)/2)
+
+		for i := 0; i+1 < len(data); i += 2 {
+			units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+		}
+
+		return string(utf16.Decode(units))
+	case bytes.HasPrefix(data, []byte{0xef, 0xbb, 0xbf}):
+		return strings.ToValidUTF8(string(data[3:]), string(utf8.RuneError))
+	default:
+		runes := make([]rune, len(data))
+		for i, c := range data {
+			runes[i] = rune(c)
+		}
+
+		return string(runes)
+	}
+}
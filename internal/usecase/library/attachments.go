@@ -0,0 +1,255 @@
+package library
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/media"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxCoverSize      = 10 << 20
+	maxAttachmentSize = 100 << 20
+	thumbnailSide     = 256
+)
+
+func convertAttachmentToResponse(attachment entity.Attachment) *library.Attachment {
+	return &library.Attachment{
+		Id:           attachment.ID,
+		BookId:       attachment.BookID,
+		Kind:         library.AttachmentKind(attachment.Kind),
+		Name:         attachment.Name,
+		ContentType:  attachment.ContentType,
+		Size:         attachment.Size,
+		HasThumbnail: attachment.ThumbnailKey != "",
+		CreatedAt:    timestamppb.New(attachment.CreatedAt),
+	}
+}
+
+// UploadAttachment spools the upload into a temporary file to check its size and content type
+// before anything is stored. A new cover replaces the previous one, blobs of removed attachments
+// are deleted by the outbox worker.
+func (l *libraryImpl) UploadAttachment(
+	ctx context.Context,
+	bookID string,
+	kind entity.AttachmentKind,
+	name string,
+	data io.Reader,
+) (*library.UploadAttachmentResponse, error) {
+	if _, err := l.bookRepository.GetBook(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	limit := int64(maxAttachmentSize)
+	if kind == entity.AttachmentKindCover {
+		limit = maxCoverSize
+	}
+
+	upload, err := l.spool(data, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer upload.close()
+
+	if !media.Allowed(kind, upload.contentType) {
+		return nil, fmt.Errorf("%s: %w", upload.contentType, entity.ErrUnsupportedContentType)
+	}
+
+	attachment := entity.Attachment{
+		ID:          uuid.NewString(),
+		BookID:      bookID,
+		Kind:        kind,
+		Name:        name,
+		ContentType: upload.contentType,
+		Size:        upload.size,
+	}
+	attachment.BlobKey = "books/" + bookID + "/" + attachment.ID
+
+	if attachment.Name == "" {
+		attachment.Name = attachment.ID
+	}
+
+	var thumbnail []byte
+	if kind == entity.AttachmentKindCover {
+		if thumbnail, err = media.Thumbnail(upload.reader(), thumbnailSide); err != nil {
+			return nil, err
+		}
+
+		attachment.ThumbnailKey = attachment.BlobKey + "_thumbnail"
+	}
+
+	if err = l.putBlobs(ctx, attachment, upload.reader(), thumbnail); err != nil {
+		l.logger.Error("cannot store attachment", zap.Error(err))
+		l.deleteBlobs(ctx, attachment)
+		return nil, err
+	}
+
+	err = l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if kind == entity.AttachmentKindCover {
+			if err := l.attachmentRepository.DeleteCover(ctx, bookID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		attachment, err = l.attachmentRepository.CreateAttachment(ctx, attachment)
+
+		return err
+	})
+
+	if err != nil {
+		l.deleteBlobs(ctx, attachment)
+		return nil, err
+	}
+
+	return &library.UploadAttachmentResponse{
+		Attachment: convertAttachmentToResponse(attachment),
+	}, nil
+}
+
+// spooledUpload is an upload copied into a temporary file, so that it can be read more than once.
+type spooledUpload struct {
+	file        *os.File
+	size        int64
+	contentType string
+}
+
+func (u *spooledUpload) reader() *io.SectionReader {
+	return io.NewSectionReader(u.file, 0, u.size)
+}
+
+func (u *spooledUpload) close() {
+	_ = u.file.Close()
+	_ = os.Remove(u.file.Name())
+}
+
+// spool stores data into a temporary file and sniffs its content type, the caller closes the upload.
+func (l *libraryImpl) spool(data io.Reader, limit int64) (*spooledUpload, error) {
+	file, err := os.CreateTemp("", "attachment-*")
+
+	if err != nil {
+		l.logger.Error("cannot create temporary file", zap.Error(err))
+		return nil, err
+	}
+
+	upload := &spooledUpload{file: file}
+
+	if upload.size, err = io.Copy(file, io.LimitReader(data, limit+1)); err != nil {
+		upload.close()
+		return nil, err
+	}
+
+	if upload.size > limit {
+		upload.close()
+		return nil, fmt.Errorf("limit is %d bytes: %w", limit, entity.ErrAttachmentTooLarge)
+	}
+
+	if upload.size == 0 {
+		upload.close()
+		return nil, fmt.Errorf("empty file: %w", entity.ErrInvalidAttachment)
+	}
+
+	head := make([]byte, media.SniffLength)
+	n, err := file.ReadAt(head, 0)
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		upload.close()
+		return nil, err
+	}
+
+	upload.contentType = media.DetectContentType(head[:n])
+
+	return upload, nil
+}
+
+func (l *libraryImpl) putBlobs(ctx context.Context, attachment entity.Attachment, data io.Reader, thumbnail []byte) error {
+	if err := l.blobStore.Put(ctx, attachment.BlobKey, data, attachment.Size, attachment.ContentType); err != nil {
+		return err
+	}
+
+	if attachment.ThumbnailKey == "" {
+		return nil
+	}
+
+	return l.blobStore.Put(ctx, attachment.ThumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), media.ThumbnailContentType)
+}
+
+// deleteBlobs cleans up after a failed upload, nothing references these blobs yet.
+func (l *libraryImpl) deleteBlobs(ctx context.Context, attachment entity.Attachment) {
+	for _, key := range []string{attachment.BlobKey, attachment.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+
+		if err := l.blobStore.Delete(ctx, key); err != nil {
+			l.logger.Error("cannot delete blob", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// DownloadAttachment opens the attachment or its thumbnail, the caller closes the returned reader.
+func (l *libraryImpl) DownloadAttachment(
+	ctx context.Context,
+	attachmentID string,
+	thumbnail bool,
+) (*library.Attachment, io.ReadCloser, error) {
+	attachment, err := l.attachmentRepository.GetAttachment(ctx, attachmentID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := attachment.BlobKey
+	if thumbnail {
+		if attachment.ThumbnailKey == "" {
+			return nil, nil, fmt.Errorf("attachment has no thumbnail: %w", entity.ErrAttachmentNotFound)
+		}
+
+		key = attachment.ThumbnailKey
+	}
+
+	data, err := l.blobStore.Get(ctx, key)
+
+	if err != nil {
+		l.logger.Error("cannot get attachment blob", zap.String("key", key), zap.Error(err))
+		return nil, nil, err
+	}
+
+	return convertAttachmentToResponse(attachment), data, nil
+}
+
+func (l *libraryImpl) ListAttachments(ctx context.Context, bookID string) (*library.ListAttachmentsResponse, error) {
+	if _, err := l.bookRepository.GetBook(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := l.attachmentRepository.ListAttachments(ctx, bookID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*library.Attachment, len(attachments))
+	for i, attachment := range attachments {
+		response[i] = convertAttachmentToResponse(attachment)
+	}
+
+	return &library.ListAttachmentsResponse{
+		Attachments: response,
+	}, nil
+}
+
+func (l *libraryImpl) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	return l.attachmentRepository.DeleteAttachment(ctx, attachmentID)
+}
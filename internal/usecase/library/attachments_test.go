@@ -0,0 +1,251 @@
+package library
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func pngImage(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, width, height))))
+
+	return buf.Bytes()
+}
+
+func TestUseCaseUploadAttachment(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.NewString()
+	cover := pngImage(t, 600, 900)
+
+	t.Run("cover replaces the previous one", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		var stored, thumbnail []byte
+		data.bookRepository.EXPECT().GetBook(ctx, bookID).Return(entity.Book{ID: bookID}, nil)
+		data.blobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), int64(len(cover)), "image/png").
+			DoAndReturn(func(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+				require.True(t, strings.HasPrefix(key, "books/"+bookID+"/"))
+
+				var err error
+				stored, err = io.ReadAll(r)
+
+				return err
+			})
+		data.blobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "image/jpeg").
+			DoAndReturn(func(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+				require.True(t, strings.HasSuffix(key, "_thumbnail"))
+
+				var err error
+				thumbnail, err = io.ReadAll(r)
+
+				return err
+			})
+		prepareTransactor(ctx, data)
+		data.attachmentRepo.EXPECT().DeleteCover(ctx, bookID).Return(nil)
+		data.attachmentRepo.EXPECT().CreateAttachment(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, attachment entity.Attachment) (entity.Attachment, error) {
+				require.Equal(t, entity.AttachmentKindCover, attachment.Kind)
+				require.Equal(t, "image/png", attachment.ContentType)
+				require.Equal(t, "books/"+bookID+"/"+attachment.ID, attachment.BlobKey)
+				return attachment, nil
+			})
+
+		resp, err := data.impl.UploadAttachment(ctx, bookID, entity.AttachmentKindCover, "cover.png", bytes.NewReader(cover))
+		require.NoError(t, err)
+		require.Equal(t, library.AttachmentKind_ATTACHMENT_KIND_COVER, resp.GetAttachment().GetKind())
+		require.Equal(t, "cover.png", resp.GetAttachment().GetName())
+		require.True(t, resp.GetAttachment().GetHasThumbnail())
+		require.Equal(t, cover, stored)
+
+		decoded, _, err := image.Decode(bytes.NewReader(thumbnail))
+		require.NoError(t, err)
+		require.Equal(t, image.Rect(0, 0, 170, 256), decoded.Bounds())
+	})
+
+	t.Run("file without thumbnail", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		file := "%PDF-1.7\n"
+
+		data.bookRepository.EXPECT().GetBook(ctx, bookID).Return(entity.Book{ID: bookID}, nil)
+		data.blobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), int64(len(file)), "application/pdf").Return(nil)
+		prepareTransactor(ctx, data)
+		data.attachmentRepo.EXPECT().CreateAttachment(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, attachment entity.Attachment) (entity.Attachment, error) {
+				return attachment, nil
+			})
+
+		resp, err := data.impl.UploadAttachment(ctx, bookID, entity.AttachmentKindFile, "", strings.NewReader(file))
+		require.NoError(t, err)
+		require.False(t, resp.GetAttachment().GetHasThumbnail())
+		require.Equal(t, resp.GetAttachment().GetId(), resp.GetAttachment().GetName())
+	})
+
+	t.Run("blobs are deleted when the attachment is not saved", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.bookRepository.EXPECT().GetBook(ctx, bookID).Return(entity.Book{ID: bookID}, nil)
+		data.blobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		prepareTransactor(ctx, data)
+		data.attachmentRepo.EXPECT().DeleteCover(ctx, bookID).Return(errors.New("db is down"))
+		data.blobStore.EXPECT().Delete(ctx, gomock.Any()).Return(nil).Times(2)
+
+		_, err := data.impl.UploadAttachment(ctx, bookID, entity.AttachmentKindCover, "", bytes.NewReader(cover))
+		require.Error(t, err)
+	})
+
+	tests := []struct {
+		name    string
+		kind    entity.AttachmentKind
+		data    io.Reader
+		wantErr error
+	}{
+		{
+			name:    "pdf is not a cover",
+			kind:    entity.AttachmentKindCover,
+			data:    strings.NewReader("%PDF-1.7\n"),
+			wantErr: entity.ErrUnsupportedContentType,
+		},
+		{
+			name:    "cover is too large",
+			kind:    entity.AttachmentKindCover,
+			data:    io.LimitReader(zeroReader{}, maxCoverSize+1),
+			wantErr: entity.ErrAttachmentTooLarge,
+		},
+		{
+			name:    "empty file",
+			kind:    entity.AttachmentKindFile,
+			data:    strings.NewReader(""),
+			wantErr: entity.ErrInvalidAttachment,
+		},
+		{
+			name:    "broken image",
+			kind:    entity.AttachmentKindCover,
+			data:    bytes.NewReader(cover[:100]),
+			wantErr: entity.ErrInvalidAttachment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getUseCaseData(t)
+			data.bookRepository.EXPECT().GetBook(ctx, bookID).Return(entity.Book{ID: bookID}, nil)
+
+			_, err := data.impl.UploadAttachment(ctx, bookID, tt.kind, "", tt.data)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("book not found", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.bookRepository.EXPECT().GetBook(ctx, bookID).Return(entity.Book{}, entity.ErrBookNotFound)
+
+		_, err := data.impl.UploadAttachment(ctx, bookID, entity.AttachmentKindCover, "", bytes.NewReader(cover))
+		require.ErrorIs(t, err, entity.ErrBookNotFound)
+	})
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func TestUseCaseDownloadAttachment(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	attachment := entity.Attachment{
+		ID:          uuid.NewString(),
+		BookID:      uuid.NewString(),
+		Kind:        entity.AttachmentKindFile,
+		ContentType: "application/pdf",
+		BlobKey:     "books/1/2",
+	}
+
+	t.Run("attachment downloaded", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.attachmentRepo.EXPECT().GetAttachment(ctx, attachment.ID).Return(attachment, nil)
+		data.blobStore.EXPECT().Get(ctx, attachment.BlobKey).Return(io.NopCloser(strings.NewReader("pdf")), nil)
+
+		resp, reader, err := data.impl.DownloadAttachment(ctx, attachment.ID, false)
+		require.NoError(t, err)
+		require.Equal(t, "application/pdf", resp.GetContentType())
+
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Equal(t, "pdf", string(content))
+	})
+
+	t.Run("file has no thumbnail", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.attachmentRepo.EXPECT().GetAttachment(ctx, attachment.ID).Return(attachment, nil)
+
+		_, _, err := data.impl.DownloadAttachment(ctx, attachment.ID, true)
+		require.ErrorIs(t, err, entity.ErrAttachmentNotFound)
+	})
+
+	t.Run("blob is missing", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.attachmentRepo.EXPECT().GetAttachment(ctx, attachment.ID).Return(attachment, nil)
+		data.blobStore.EXPECT().Get(ctx, attachment.BlobKey).Return(nil, entity.ErrBlobNotFound)
+
+		_, _, err := data.impl.DownloadAttachment(ctx, attachment.ID, false)
+		require.ErrorIs(t, err, entity.ErrBlobNotFound)
+	})
+}
+
+func TestUseCaseListAttachments(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	data := getUseCaseData(t)
+	bookID := uuid.NewString()
+
+	data.bookRepository.EXPECT().GetBook(ctx, bookID).Return(entity.Book{ID: bookID}, nil)
+	data.attachmentRepo.EXPECT().ListAttachments(ctx, bookID).Return([]entity.Attachment{
+		{ID: uuid.NewString(), BookID: bookID, Kind: entity.AttachmentKindCover, ThumbnailKey: "thumbnail"},
+		{ID: uuid.NewString(), BookID: bookID, Kind: entity.AttachmentKindFile},
+	}, nil)
+
+	resp, err := data.impl.ListAttachments(ctx, bookID)
+	require.NoError(t, err)
+	require.Len(t, resp.GetAttachments(), 2)
+	require.True(t, resp.GetAttachments()[0].GetHasThumbnail())
+	require.Equal(t, library.AttachmentKind_ATTACHMENT_KIND_FILE, resp.GetAttachments()[1].GetKind())
+}
+
+func TestUseCaseDeleteAttachment(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	data := getUseCaseData(t)
+	attachmentID := uuid.NewString()
+
+	data.attachmentRepo.EXPECT().DeleteAttachment(ctx, attachmentID).Return(entity.ErrAttachmentNotFound)
+
+	require.ErrorIs(t, data.impl.DeleteAttachment(ctx, attachmentID), entity.ErrAttachmentNotFound)
+}
@@ -0,0 +1,90 @@
+package library
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.uber.org/zap"
+)
+
+func convertAuditEventToResponse(event entity.AuditEvent) (*library.AuditEvent, error) {
+	result := &library.AuditEvent{
+		Id:        event.ID,
+		Actor:     event.Actor,
+		Entity:    event.Entity,
+		EntityId:  event.EntityID,
+		Action:    library.ChangeKind(event.Action),
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+
+	var err error
+	if result.Before, err = convertAuditColumns(event.Before); err != nil {
+		return nil, err
+	}
+
+	if result.After, err = convertAuditColumns(event.After); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// convertAuditColumns turns the JSON columns of an audit event into a struct, nil when there are none.
+func convertAuditColumns(columns []byte) (*structpb.Struct, error) {
+	if columns == nil {
+		return nil, nil
+	}
+
+	result := &structpb.Struct{}
+	if err := result.UnmarshalJSON(columns); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (l *libraryImpl) ListAuditEvents(
+	ctx context.Context,
+	filter entity.AuditFilter,
+	pageSize int,
+	pageToken string,
+) (*library.ListAuditEventsResponse, error) {
+	cursor, err := decodePageToken(pageToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	limit := getPageSize(pageSize)
+	events, err := l.auditRepository.ListAuditEvents(ctx, filter, cursor, limit+1)
+
+	if err != nil {
+		l.logger.Error("cannot list audit events", zap.Error(err))
+		return nil, err
+	}
+
+	events, nextPageToken, err := trimPage(events, limit, func(event entity.AuditEvent) entity.PageCursor {
+		return entity.PageCursor{CreatedAt: event.CreatedAt, ID: event.ID}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*library.AuditEvent, len(events))
+	for i, event := range events {
+		if res[i], err = convertAuditEventToResponse(event); err != nil {
+			l.logger.Error("cannot convert audit event", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	return &library.ListAuditEventsResponse{
+		Events:        res,
+		NextPageToken: nextPageToken,
+	}, nil
+}
@@ -0,0 +1,94 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseListAuditEvents(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	filter := entity.AuditFilter{Entity: "book", EntityID: bookID}
+	now := time.Now().UTC()
+	events := []entity.AuditEvent{
+		{
+			ID:        uuid.New().String(),
+			Actor:     "librarian",
+			Entity:    "book",
+			EntityID:  bookID,
+			Action:    entity.ChangeKindCreated,
+			After:     []byte(`{"id": "` + bookID + `", "name": "Dune"}`),
+			CreatedAt: now,
+		},
+		{
+			ID:        uuid.New().String(),
+			Entity:    "book",
+			EntityID:  bookID,
+			Action:    entity.ChangeKindUpdated,
+			Before:    []byte(`{"name": "Dune"}`),
+			After:     []byte(`{"name": "Dune Messiah"}`),
+			CreatedAt: now.Add(time.Second),
+		},
+		{
+			ID:        uuid.New().String(),
+			Entity:    "book",
+			EntityID:  bookID,
+			Action:    entity.ChangeKindDeleted,
+			Before:    []byte(`{"id": "` + bookID + `", "name": "Dune Messiah"}`),
+			CreatedAt: now.Add(2 * time.Second),
+		},
+	}
+
+	t.Run("pages through audit events", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.auditRepository.EXPECT().ListAuditEvents(ctx, filter, (*entity.PageCursor)(nil), 3).Return(events, nil)
+
+		first, err := data.impl.ListAuditEvents(ctx, filter, 2, "")
+		require.NoError(t, err)
+		require.Len(t, first.GetEvents(), 2)
+		require.NotEmpty(t, first.GetNextPageToken())
+
+		created := first.GetEvents()[0]
+		require.Equal(t, "librarian", created.GetActor())
+		require.Nil(t, created.GetBefore())
+		require.Equal(t, "Dune", created.GetAfter().GetFields()["name"].GetStringValue())
+
+		updated := first.GetEvents()[1]
+		require.Equal(t, "Dune", updated.GetBefore().GetFields()["name"].GetStringValue())
+		require.Equal(t, "Dune Messiah", updated.GetAfter().GetFields()["name"].GetStringValue())
+
+		data.auditRepository.EXPECT().ListAuditEvents(ctx, filter, gomock.Any(), 3).Return(events[2:], nil)
+
+		second, err := data.impl.ListAuditEvents(ctx, filter, 2, first.GetNextPageToken())
+		require.NoError(t, err)
+		require.Len(t, second.GetEvents(), 1)
+		require.Nil(t, second.GetEvents()[0].GetAfter())
+		require.Empty(t, second.GetNextPageToken())
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		_, err := data.impl.ListAuditEvents(ctx, filter, 2, "not a token!")
+		require.ErrorIs(t, err, entity.ErrInvalidPageToken)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.auditRepository.EXPECT().ListAuditEvents(ctx, filter, (*entity.PageCursor)(nil), defaultPageSize+1).Return(nil, errors.New("connection refused"))
+
+		_, err := data.impl.ListAuditEvents(ctx, filter, 0, "")
+		require.Error(t, err)
+	})
+}
@@ -3,67 +3,100 @@ package library
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/project/library/generated/api/library"
 	"github.com/project/library/internal/entity"
 	"github.com/project/library/internal/usecase/repository"
 
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func (l *libraryImpl) RegisterAuthor(ctx context.Context, authorName string) (*library.RegisterAuthorResponse, error) {
-	var author entity.Author
+func (l *libraryImpl) RegisterAuthor(
+	ctx context.Context,
+	authorName string,
+	idempotencyKey entity.IdempotencyKey,
+) (*library.RegisterAuthorResponse, error) {
+	response := &library.RegisterAuthorResponse{}
 
-	err := l.transactor.WithTx(ctx, func(ctx context.Context) error {
-		var err error
-		author, err = l.authorRepository.CreateAuthor(ctx, entity.Author{
-			Name: authorName,
-		})
+	err := l.idempotent(ctx, idempotencyKey, response, func(ctx context.Context) error {
+		author, err := l.createAuthor(ctx, authorName)
+		response.Id = author.ID
+		return err
+	})
 
-		if err != nil {
-			l.logger.Error("cannot create author", zap.Error(err))
-			return err
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		serialized, err := json.Marshal(author)
+	return response, nil
+}
 
-		if err != nil {
-			l.logger.Error("cannot serialize author", zap.Error(err))
-			return err
-		}
+// createAuthor stores the author and its outbox message, it must run in a transaction.
+func (l *libraryImpl) createAuthor(ctx context.Context, authorName string) (entity.Author, error) {
+	author, err := l.authorRepository.CreateAuthor(ctx, entity.Author{
+		Name: authorName,
+	})
 
-		idempotencyKey := repository.OutboxKindAuthor.String() + "_" + author.ID
-		err = l.outboxRepository.SendMessage(ctx, idempotencyKey, repository.OutboxKindAuthor, serialized)
+	if err != nil {
+		l.logger.Error("cannot create author", zap.Error(err))
+		return entity.Author{}, err
+	}
 
-		if err != nil {
-			l.logger.Error("cannot send message to outbox", zap.Error(err))
-			return err
-		}
+	serialized, err := json.Marshal(author)
 
-		return nil
-	})
+	if err != nil {
+		l.logger.Error("cannot serialize author", zap.Error(err))
+		return entity.Author{}, err
+	}
+
+	idempotencyKey := repository.OutboxKindAuthor.String() + "_" + author.ID
+	err = l.outboxRepository.SendMessage(ctx, idempotencyKey, repository.OutboxKindAuthor, serialized)
 
 	if err != nil {
-		return nil, err
+		l.logger.Error("cannot send message to outbox", zap.Error(err))
+		return entity.Author{}, err
 	}
 
-	return &library.RegisterAuthorResponse{
-		Id: author.ID,
-	}, nil
+	return author, nil
 }
 
-func (l *libraryImpl) GetAuthor(ctx context.Context, authorID string) (*library.GetAuthorInfoResponse, error) {
-	author, err := l.authorRepository.GetAuthor(ctx, authorID)
+func (l *libraryImpl) GetAuthor(
+	ctx context.Context,
+	authorID string,
+	asOf time.Time,
+	includeDeleted bool,
+) (*library.GetAuthorInfoResponse, error) {
+	var (
+		author entity.Author
+		err    error
+	)
+
+	switch {
+	case !asOf.IsZero():
+		author, err = l.historyRepository.GetAuthorAsOf(ctx, authorID, asOf)
+	case includeDeleted:
+		author, err = l.trashRepository.GetAuthorIncludingDeleted(ctx, authorID)
+	default:
+		author, err = l.authorRepository.GetAuthor(ctx, authorID)
+	}
 
 	if err != nil {
 		l.logger.Error("cannot get author", zap.Error(err))
 		return nil, err
 	}
 
-	return &library.GetAuthorInfoResponse{
+	response := &library.GetAuthorInfoResponse{
 		Id:   author.ID,
 		Name: author.Name,
-	}, nil
+	}
+
+	if !author.DeletedAt.IsZero() {
+		response.DeletedAt = timestamppb.New(author.DeletedAt)
+	}
+
+	return response, nil
 }
 
 func (l *libraryImpl) ChangeAuthorInfo(ctx context.Context, authorID string, newName string) error {
@@ -3,6 +3,7 @@ package library
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/project/library/internal/entity"
@@ -35,7 +36,7 @@ func TestAuthorUseCase(t *testing.T) {
 				})
 			},
 			apply: func(data *useCaseData) (entity.Author, error) {
-				resp, err := data.impl.RegisterAuthor(ctx, author.Name)
+				resp, err := data.impl.RegisterAuthor(ctx, author.Name, entity.IdempotencyKey{})
 				return entity.Author{
 					ID: resp.GetId(),
 				}, err
@@ -51,7 +52,7 @@ func TestAuthorUseCase(t *testing.T) {
 				data.authorRepository.EXPECT().GetAuthor(ctx, author.ID).Return(author, nil)
 			},
 			apply: func(data *useCaseData) (entity.Author, error) {
-				resp, err := data.impl.GetAuthor(ctx, author.ID)
+				resp, err := data.impl.GetAuthor(ctx, author.ID, time.Time{}, false)
 				return entity.Author{
 					ID:   resp.GetId(),
 					Name: resp.GetName(),
@@ -66,7 +67,7 @@ func TestAuthorUseCase(t *testing.T) {
 				data.authorRepository.EXPECT().GetAuthor(ctx, author.ID).Return(entity.Author{}, entity.ErrAuthorNotFound)
 			},
 			apply: func(data *useCaseData) (entity.Author, error) {
-				resp, err := data.impl.GetAuthor(ctx, author.ID)
+				resp, err := data.impl.GetAuthor(ctx, author.ID, time.Time{}, false)
 				return entity.Author{
 					ID:   resp.GetId(),
 					Name: resp.GetName(),
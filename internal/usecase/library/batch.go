@@ -0,0 +1,96 @@
+package library
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+
+	"go.uber.org/zap"
+)
+
+func (l *libraryImpl) checkBatchSize(ids []string) error {
+	if len(ids) > l.maxBatchIDs {
+		return fmt.Errorf("%w: %d ids, at most %d are allowed", entity.ErrTooManyIDs, len(ids), l.maxBatchIDs)
+	}
+
+	return nil
+}
+
+// BatchGetBooks reads the books with one query and returns them in the order of ids,
+// ids of books that do not exist are listed as missing instead of failing the call.
+func (l *libraryImpl) BatchGetBooks(ctx context.Context, ids []string, view entity.BookView) (*library.BatchGetBooksResponse, error) {
+	if err := l.checkBatchSize(ids); err != nil {
+		return nil, err
+	}
+
+	books, err := l.bookRepository.GetBooks(ctx, ids)
+
+	if err != nil {
+		l.logger.Error("cannot get books", zap.Error(err))
+		return nil, err
+	}
+
+	found := make(map[string]entity.Book, len(books))
+	for _, book := range books {
+		found[book.ID] = book
+	}
+
+	response := &library.BatchGetBooksResponse{
+		Books:      make([]*library.Book, 0, len(books)),
+		MissingIds: make([]string, 0),
+	}
+
+	for _, id := range ids {
+		if book, ok := found[id]; ok {
+			response.Books = append(response.Books, convertBookToResponse(book))
+		} else {
+			response.MissingIds = append(response.MissingIds, id)
+		}
+	}
+
+	if err = l.expandAuthors(ctx, view, response.GetBooks()...); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// BatchGetAuthors reads the authors with one query and returns them in the order of ids,
+// ids of authors that do not exist are listed as missing instead of failing the call.
+func (l *libraryImpl) BatchGetAuthors(ctx context.Context, ids []string) (*library.BatchGetAuthorsResponse, error) {
+	if err := l.checkBatchSize(ids); err != nil {
+		return nil, err
+	}
+
+	authors, err := l.authorRepository.GetAuthors(ctx, ids)
+
+	if err != nil {
+		l.logger.Error("cannot get authors", zap.Error(err))
+		return nil, err
+	}
+
+	found := make(map[string]entity.Author, len(authors))
+	for _, author := range authors {
+		found[author.ID] = author
+	}
+
+	response := &library.BatchGetAuthorsResponse{
+		Authors:    make([]*library.AuthorSummary, 0, len(authors)),
+		MissingIds: make([]string, 0),
+	}
+
+	for _, id := range ids {
+		if author, ok := found[id]; ok {
+			response.Authors = append(response.Authors, &library.AuthorSummary{
+				Id:   author.ID,
+				Name: author.Name,
+			})
+		} else {
+			response.MissingIds = append(response.MissingIds, id)
+		}
+	}
+
+	return response, nil
+}
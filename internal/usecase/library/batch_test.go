@@ -0,0 +1,93 @@
+package library
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCaseBatchGetBooks(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	author := entity.Author{ID: uuid.NewString(), Name: "Terry Pratchett"}
+	first := entity.Book{
+		ID:           uuid.NewString(),
+		Name:         "Mort",
+		AuthorIDs:    []string{author.ID},
+		Contributors: []entity.Contributor{{AuthorID: author.ID, Role: entity.ContributorRoleAuthor}},
+	}
+	second := entity.Book{ID: uuid.NewString(), Name: "Small Gods"}
+	missingID := uuid.NewString()
+	ids := []string{second.ID, missingID, first.ID}
+
+	t.Run("books keep the request order", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.bookRepository.EXPECT().GetBooks(ctx, ids).Return([]entity.Book{first, second}, nil)
+		data.authorRepository.EXPECT().GetAuthors(ctx, []string{author.ID}).Return([]entity.Author{author}, nil)
+
+		resp, err := data.impl.BatchGetBooks(ctx, ids, entity.BookViewFull)
+		require.NoError(t, err)
+		require.Len(t, resp.GetBooks(), 2)
+		require.Equal(t, second.ID, resp.GetBooks()[0].GetId())
+		require.Equal(t, first.ID, resp.GetBooks()[1].GetId())
+		require.Equal(t, author.Name, resp.GetBooks()[1].GetAuthors()[0].GetName())
+		require.Equal(t, []string{missingID}, resp.GetMissingIds())
+	})
+
+	t.Run("too many ids", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		_, err := data.impl.BatchGetBooks(ctx, append(ids, uuid.NewString()), entity.BookViewBasic)
+		require.ErrorIs(t, err, entity.ErrTooManyIDs)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.bookRepository.EXPECT().GetBooks(ctx, ids).Return(nil, errors.New("connection refused"))
+
+		_, err := data.impl.BatchGetBooks(ctx, ids, entity.BookViewBasic)
+		require.Error(t, err)
+	})
+}
+
+func TestUseCaseBatchGetAuthors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	first := entity.Author{ID: uuid.NewString(), Name: "Terry Pratchett"}
+	second := entity.Author{ID: uuid.NewString(), Name: "Neil Gaiman"}
+	missingID := uuid.NewString()
+	ids := []string{missingID, second.ID, first.ID}
+
+	t.Run("authors keep the request order", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.authorRepository.EXPECT().GetAuthors(ctx, ids).Return([]entity.Author{first, second}, nil)
+
+		resp, err := data.impl.BatchGetAuthors(ctx, ids)
+		require.NoError(t, err)
+		require.Len(t, resp.GetAuthors(), 2)
+		require.Equal(t, second.Name, resp.GetAuthors()[0].GetName())
+		require.Equal(t, first.Name, resp.GetAuthors()[1].GetName())
+		require.Equal(t, []string{missingID}, resp.GetMissingIds())
+	})
+
+	t.Run("too many ids", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		_, err := data.impl.BatchGetAuthors(ctx, append(ids, uuid.NewString()))
+		require.ErrorIs(t, err, entity.ErrTooManyIDs)
+	})
+}
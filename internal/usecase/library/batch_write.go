@@ -0,0 +1,218 @@
+package library
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+
+	"go.uber.org/zap"
+)
+
+// batchIDs resolves the temporary ids of a batch into the uuids of the created authors and books.
+type batchIDs map[string]string
+
+func (b batchIDs) add(tempID string, id string) error {
+	if tempID == "" {
+		return nil
+	}
+
+	if _, ok := b[tempID]; ok {
+		return fmt.Errorf("%w: temporary id %q is used twice", entity.ErrInvalidBatch, tempID)
+	}
+
+	if uuid.Validate(tempID) == nil {
+		return fmt.Errorf("%w: temporary id %q is a uuid", entity.ErrInvalidBatch, tempID)
+	}
+
+	b[tempID] = id
+
+	return nil
+}
+
+func (b batchIDs) resolve(id string) (string, error) {
+	if resolved, ok := b[id]; ok {
+		return resolved, nil
+	}
+
+	if uuid.Validate(id) != nil {
+		return "", fmt.Errorf("%w: %q is neither a uuid nor an earlier temporary id", entity.ErrInvalidBatch, id)
+	}
+
+	return id, nil
+}
+
+func (b batchIDs) resolveAll(ids []string) ([]string, error) {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		var err error
+		if result[i], err = b.resolve(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (b batchIDs) resolveContributors(contributors []entity.Contributor) ([]entity.Contributor, error) {
+	result := make([]entity.Contributor, len(contributors))
+	for i, contributor := range contributors {
+		id, err := b.resolve(contributor.AuthorID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = entity.Contributor{AuthorID: id, Role: contributor.Role}
+	}
+
+	return result, nil
+}
+
+// BatchWrite runs the operations in order in one transaction, a failed operation rolls back
+// the whole batch and the error names its index. Authors and books created by the batch
+// may be referenced by later operations through their temporary ids.
+func (l *libraryImpl) BatchWrite(ctx context.Context, operations []entity.BatchOperation) (*library.BatchWriteResponse, error) {
+	var results []*library.BatchOperationResult
+
+	err := l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		ids := make(batchIDs)
+		results = make([]*library.BatchOperationResult, len(operations))
+
+		for i, operation := range operations {
+			result, err := l.batchOperation(ctx, ids, operation)
+
+			if err != nil {
+				return fmt.Errorf("operation %d: %w", i, err)
+			}
+
+			results[i] = result
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		l.logger.Error("cannot write batch", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.BatchWriteResponse{
+		Results: results,
+	}, nil
+}
+
+func (l *libraryImpl) batchOperation(
+	ctx context.Context,
+	ids batchIDs,
+	operation entity.BatchOperation,
+) (*library.BatchOperationResult, error) {
+	result := &library.BatchOperationResult{
+		TempId: operation.TempID,
+	}
+
+	switch operation.Kind {
+	case entity.BatchOperationCreateAuthor:
+		author, err := l.createAuthor(ctx, operation.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = ids.add(operation.TempID, author.ID); err != nil {
+			return nil, err
+		}
+
+		result.Id = author.ID
+		result.Author = &library.AuthorSummary{Id: author.ID, Name: author.Name}
+	case entity.BatchOperationUpdateAuthor:
+		id, err := ids.resolve(operation.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		author, err := l.authorRepository.ChangeAuthorInfo(ctx, id, entity.Author{ID: id, Name: operation.Name})
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Id = author.ID
+		result.Author = &library.AuthorSummary{Id: author.ID, Name: author.Name}
+	case entity.BatchOperationDeleteAuthor:
+		id, err := ids.resolve(operation.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = l.authorRepository.DeleteAuthor(ctx, id); err != nil {
+			return nil, err
+		}
+
+		result.Id = id
+	case entity.BatchOperationCreateBook, entity.BatchOperationUpdateBook:
+		book, err := l.batchBook(ctx, ids, operation)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Id = book.ID
+		result.Book = convertBookToResponse(book)
+	case entity.BatchOperationDeleteBook:
+		id, err := ids.resolve(operation.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = l.bookRepository.DeleteBook(ctx, id); err != nil {
+			return nil, err
+		}
+
+		result.Id = id
+	default:
+		return nil, fmt.Errorf("%w: unknown operation", entity.ErrInvalidBatch)
+	}
+
+	return result, nil
+}
+
+func (l *libraryImpl) batchBook(ctx context.Context, ids batchIDs, operation entity.BatchOperation) (entity.Book, error) {
+	authorIDs, err := ids.resolveAll(operation.AuthorIDs)
+
+	if err != nil {
+		return entity.Book{}, err
+	}
+
+	contributors, err := ids.resolveContributors(operation.Contributors)
+
+	if err != nil {
+		return entity.Book{}, err
+	}
+
+	authorIDs, contributors = bookContributors(authorIDs, contributors)
+	book := entity.Book{
+		Name:         operation.Name,
+		AuthorIDs:    authorIDs,
+		Contributors: contributors,
+	}
+
+	if operation.Kind == entity.BatchOperationCreateBook {
+		if book, err = l.createBook(ctx, book); err != nil {
+			return entity.Book{}, err
+		}
+
+		return book, ids.add(operation.TempID, book.ID)
+	}
+
+	if book.ID, err = ids.resolve(operation.ID); err != nil {
+		return entity.Book{}, err
+	}
+
+	return l.bookRepository.ChangeBookInfo(ctx, book.ID, book)
+}
@@ -0,0 +1,104 @@
+package library
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseBatchWrite(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	author := entity.Author{ID: uuid.NewString(), Name: "Terry Pratchett"}
+	book := entity.Book{
+		ID:           uuid.NewString(),
+		Name:         "Mort",
+		AuthorIDs:    []string{author.ID},
+		Contributors: []entity.Contributor{{AuthorID: author.ID, Role: entity.ContributorRoleAuthor}},
+	}
+	removedID := uuid.NewString()
+
+	withTx := func(data *useCaseData) {
+		data.transactor.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, x func(context.Context) error) error {
+			return x(ctx)
+		})
+	}
+
+	t.Run("temporary ids are resolved", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		withTx(data)
+
+		data.authorRepository.EXPECT().CreateAuthor(ctx, entity.Author{Name: author.Name}).Return(author, nil)
+		data.bookRepository.EXPECT().CreateBook(ctx, entity.Book{
+			Name:         book.Name,
+			AuthorIDs:    book.AuthorIDs,
+			Contributors: book.Contributors,
+		}).Return(book, nil)
+		data.outboxRepository.EXPECT().SendMessage(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		data.bookRepository.EXPECT().DeleteBook(ctx, removedID).Return(nil)
+
+		resp, err := data.impl.BatchWrite(ctx, []entity.BatchOperation{
+			{Kind: entity.BatchOperationCreateAuthor, TempID: "pratchett", Name: author.Name},
+			{Kind: entity.BatchOperationCreateBook, TempID: "mort", Name: book.Name, AuthorIDs: []string{"pratchett"}},
+			{Kind: entity.BatchOperationDeleteBook, ID: removedID},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetResults(), 3)
+		require.Equal(t, author.ID, resp.GetResults()[0].GetId())
+		require.Equal(t, "pratchett", resp.GetResults()[0].GetTempId())
+		require.Equal(t, book.ID, resp.GetResults()[1].GetBook().GetId())
+		require.Equal(t, []string{author.ID}, resp.GetResults()[1].GetBook().GetAuthorId())
+		require.Equal(t, removedID, resp.GetResults()[2].GetId())
+	})
+
+	t.Run("unknown temporary id", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		withTx(data)
+
+		_, err := data.impl.BatchWrite(ctx, []entity.BatchOperation{
+			{Kind: entity.BatchOperationCreateBook, Name: book.Name, AuthorIDs: []string{"pratchett"}},
+		})
+		require.ErrorIs(t, err, entity.ErrInvalidBatch)
+		require.ErrorContains(t, err, "operation 0")
+	})
+
+	t.Run("duplicate temporary id", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		withTx(data)
+
+		data.authorRepository.EXPECT().CreateAuthor(ctx, gomock.Any()).Return(author, nil).Times(2)
+		data.outboxRepository.EXPECT().SendMessage(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		_, err := data.impl.BatchWrite(ctx, []entity.BatchOperation{
+			{Kind: entity.BatchOperationCreateAuthor, TempID: "pratchett", Name: author.Name},
+			{Kind: entity.BatchOperationCreateAuthor, TempID: "pratchett", Name: author.Name},
+		})
+		require.ErrorIs(t, err, entity.ErrInvalidBatch)
+		require.ErrorContains(t, err, "operation 1")
+	})
+
+	t.Run("failed operation names its index", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		withTx(data)
+
+		data.bookRepository.EXPECT().DeleteBook(ctx, removedID).Return(nil)
+		data.authorRepository.EXPECT().DeleteAuthor(ctx, author.ID).Return(entity.ErrAuthorNotFound)
+
+		_, err := data.impl.BatchWrite(ctx, []entity.BatchOperation{
+			{Kind: entity.BatchOperationDeleteBook, ID: removedID},
+			{Kind: entity.BatchOperationDeleteAuthor, ID: author.ID},
+			{Kind: entity.BatchOperationDeleteBook, ID: book.ID},
+		})
+		require.ErrorIs(t, err, entity.ErrAuthorNotFound)
+		require.ErrorContains(t, err, "operation 1")
+	})
+}
@@ -3,6 +3,8 @@ package library
 import (
 	"context"
 	"encoding/json"
+	"slices"
+	"time"
 
 	"github.com/project/library/generated/api/library"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -14,75 +16,247 @@ import (
 )
 
 func convertBookToResponse(book entity.Book) *library.Book {
-	return &library.Book{
-		Id:        book.ID,
-		Name:      book.Name,
-		AuthorId:  book.AuthorIDs,
-		CreatedAt: timestamppb.New(book.CreatedAt),
-		UpdatedAt: timestamppb.New(book.UpdatedAt),
+	contributors := make([]*library.Contributor, len(book.Contributors))
+	for i, contributor := range book.Contributors {
+		contributors[i] = &library.Contributor{
+			AuthorId: contributor.AuthorID,
+			Role:     library.ContributorRole(contributor.Role),
+		}
+	}
+
+	result := &library.Book{
+		Id:              book.ID,
+		Name:            book.Name,
+		AuthorId:        book.AuthorIDs,
+		CreatedAt:       timestamppb.New(book.CreatedAt),
+		UpdatedAt:       timestamppb.New(book.UpdatedAt),
+		RatingAverage:   book.RatingAverage,
+		RatingCount:     int32(book.RatingCount),
+		Isbn:            book.ISBN,
+		Publisher:       book.Publisher,
+		PublicationYear: int32(book.PublicationYear),
+		WorkId:          book.WorkID,
+		Language:        book.Language,
+		Translator:      book.Translator,
+		Contributors:    contributors,
+	}
+
+	if !book.DeletedAt.IsZero() {
+		result.DeletedAt = timestamppb.New(book.DeletedAt)
 	}
+
+	return result
 }
 
-func (l *libraryImpl) RegisterBook(ctx context.Context, name string, authorIDs []string) (*library.AddBookResponse, error) {
-	var book entity.Book
+// expandAuthors adds the names of the authors and contributors to the books for BookViewFull,
+// the authors of all the books are read with one query.
+func (l *libraryImpl) expandAuthors(ctx context.Context, view entity.BookView, books ...*library.Book) error {
+	if view != entity.BookViewFull {
+		return nil
+	}
 
-	err := l.transactor.WithTx(ctx, func(ctx context.Context) error {
-		var err error
-		book, err = l.bookRepository.CreateBook(ctx, entity.Book{
-			Name:      name,
-			AuthorIDs: authorIDs,
-		})
+	ids := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, book := range books {
+		for _, contributor := range book.GetContributors() {
+			if _, ok := seen[contributor.GetAuthorId()]; !ok {
+				seen[contributor.GetAuthorId()] = struct{}{}
+				ids = append(ids, contributor.GetAuthorId())
+			}
+		}
+	}
+
+	names := make(map[string]string, len(ids))
+
+	if len(ids) > 0 {
+		authors, err := l.authorRepository.GetAuthors(ctx, ids)
 
 		if err != nil {
-			l.logger.Error("cannot create book", zap.Error(err))
+			l.logger.Error("cannot get book authors", zap.Error(err))
 			return err
 		}
 
-		serialized, err := json.Marshal(book)
+		for _, author := range authors {
+			names[author.ID] = author.Name
+		}
+	}
 
-		if err != nil {
-			l.logger.Error("cannot serialize book", zap.Error(err))
-			return err
+	for _, book := range books {
+		book.Authors = make([]*library.AuthorSummary, len(book.GetAuthorId()))
+		for i, authorID := range book.GetAuthorId() {
+			book.Authors[i] = &library.AuthorSummary{
+				Id:   authorID,
+				Name: names[authorID],
+			}
 		}
 
-		idempotencyKey := repository.OutboxKindBook.String() + "_" + book.ID
-		err = l.outboxRepository.SendMessage(ctx, idempotencyKey, repository.OutboxKindBook, serialized)
+		for _, contributor := range book.GetContributors() {
+			contributor.Name = names[contributor.GetAuthorId()]
+		}
+	}
 
-		if err != nil {
-			l.logger.Error("cannot send message to outbox", zap.Error(err))
-			return err
+	return nil
+}
+
+// bookContributors adds the authors given by id to the contributors as the first ones,
+// the same person with the same role is listed once.
+func bookContributors(authorIDs []string, contributors []entity.Contributor) ([]string, []entity.Contributor) {
+	authors := make([]string, 0, len(authorIDs))
+	result := make([]entity.Contributor, 0, len(authorIDs)+len(contributors))
+
+	add := func(contributor entity.Contributor) {
+		if slices.Contains(result, contributor) {
+			return
 		}
 
-		return nil
+		if contributor.Role == entity.ContributorRoleAuthor {
+			authors = append(authors, contributor.AuthorID)
+		}
+
+		result = append(result, contributor)
+	}
+
+	for _, authorID := range authorIDs {
+		add(entity.Contributor{AuthorID: authorID, Role: entity.ContributorRoleAuthor})
+	}
+
+	for _, contributor := range contributors {
+		add(contributor)
+	}
+
+	return authors, result
+}
+
+func (l *libraryImpl) RegisterBook(
+	ctx context.Context,
+	name string,
+	authorIDs []string,
+	contributors []entity.Contributor,
+	idempotencyKey entity.IdempotencyKey,
+) (*library.AddBookResponse, error) {
+	response := &library.AddBookResponse{}
+
+	authorIDs, contributors = bookContributors(authorIDs, contributors)
+
+	err := l.idempotent(ctx, idempotencyKey, response, func(ctx context.Context) error {
+		book, err := l.createBook(ctx, entity.Book{
+			Name:         name,
+			AuthorIDs:    authorIDs,
+			Contributors: contributors,
+		})
+		response.Book = convertBookToResponse(book)
+		return err
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &library.AddBookResponse{
-		Book: convertBookToResponse(book),
-	}, nil
+	return response, nil
+}
+
+// createBook stores the book and its outbox message, it must run in a transaction.
+func (l *libraryImpl) createBook(ctx context.Context, book entity.Book) (entity.Book, error) {
+	book, err := l.bookRepository.CreateBook(ctx, book)
+
+	if err != nil {
+		l.logger.Error("cannot create book", zap.Error(err))
+		return entity.Book{}, err
+	}
+
+	serialized, err := json.Marshal(book)
+
+	if err != nil {
+		l.logger.Error("cannot serialize book", zap.Error(err))
+		return entity.Book{}, err
+	}
+
+	idempotencyKey := repository.OutboxKindBook.String() + "_" + book.ID
+	err = l.outboxRepository.SendMessage(ctx, idempotencyKey, repository.OutboxKindBook, serialized)
+
+	if err != nil {
+		l.logger.Error("cannot send message to outbox", zap.Error(err))
+		return entity.Book{}, err
+	}
+
+	return book, nil
 }
 
-func (l *libraryImpl) GetBook(ctx context.Context, bookID string) (*library.GetBookInfoResponse, error) {
-	book, err := l.bookRepository.GetBook(ctx, bookID)
+func (l *libraryImpl) GetBook(
+	ctx context.Context,
+	bookID string,
+	includeEditions bool,
+	view entity.BookView,
+	asOf time.Time,
+	includeDeleted bool,
+) (*library.GetBookInfoResponse, error) {
+	var (
+		book entity.Book
+		err  error
+	)
+
+	switch {
+	case !asOf.IsZero():
+		book, err = l.historyRepository.GetBookAsOf(ctx, bookID, asOf)
+	case includeDeleted:
+		book, err = l.trashRepository.GetBookIncludingDeleted(ctx, bookID)
+	default:
+		book, err = l.bookRepository.GetBook(ctx, bookID)
+	}
 
 	if err != nil {
 		l.logger.Error("cannot get book", zap.Error(err))
 		return nil, err
 	}
 
-	return &library.GetBookInfoResponse{
+	response := &library.GetBookInfoResponse{
 		Book: convertBookToResponse(book),
-	}, nil
+	}
+
+	// Editions are only listed for the current state of a book.
+	if !includeEditions || book.WorkID == "" || !asOf.IsZero() {
+		if err = l.expandAuthors(ctx, view, response.GetBook()); err != nil {
+			return nil, err
+		}
+
+		return response, nil
+	}
+
+	editions, err := l.workRepository.GetEditions(ctx, book.WorkID)
+
+	if err != nil {
+		l.logger.Error("cannot get editions", zap.Error(err))
+		return nil, err
+	}
+
+	response.Editions = make([]*library.Book, 0, len(editions))
+	for _, edition := range editions {
+		if edition.ID != book.ID {
+			response.Editions = append(response.Editions, convertBookToResponse(edition))
+		}
+	}
+
+	if err = l.expandAuthors(ctx, view, append([]*library.Book{response.GetBook()}, response.GetEditions()...)...); err != nil {
+		return nil, err
+	}
+
+	return response, nil
 }
 
-func (l *libraryImpl) ChangeBookInfo(ctx context.Context, bookID string, name string, authorIDs []string) error {
+func (l *libraryImpl) ChangeBookInfo(
+	ctx context.Context,
+	bookID string,
+	name string,
+	authorIDs []string,
+	contributors []entity.Contributor,
+) error {
+	authorIDs, contributors = bookContributors(authorIDs, contributors)
+
 	_, err := l.bookRepository.ChangeBookInfo(ctx, bookID, entity.Book{
-		ID:        bookID,
-		Name:      name,
-		AuthorIDs: authorIDs,
+		ID:           bookID,
+		Name:         name,
+		AuthorIDs:    authorIDs,
+		Contributors: contributors,
 	})
 
 	if err != nil {
@@ -93,8 +267,13 @@ func (l *libraryImpl) ChangeBookInfo(ctx context.Context, bookID string, name st
 	return nil
 }
 
-func (l *libraryImpl) GetBooksByAuthor(ctx context.Context, authorID string) ([]*library.Book, error) {
-	books, err := l.bookRepository.GetBooksByAuthor(ctx, authorID)
+func (l *libraryImpl) GetBooksByAuthor(
+	ctx context.Context,
+	authorID string,
+	role entity.ContributorRole,
+	view entity.BookView,
+) ([]*library.Book, error) {
+	books, err := l.bookRepository.GetBooksByAuthor(ctx, authorID, role)
 
 	if err != nil {
 		l.logger.Error("cannot get author books", zap.Error(err))
@@ -106,5 +285,9 @@ func (l *libraryImpl) GetBooksByAuthor(ctx context.Context, authorID string) ([]
 		res[i] = convertBookToResponse(book)
 	}
 
+	if err = l.expandAuthors(ctx, view, res...); err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/project/library/generated/api/library"
 	"github.com/project/library/internal/entity"
 	"github.com/stretchr/testify/require"
@@ -15,10 +17,15 @@ import (
 func TestBookUseCase(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
+	authorIDs := []string{uuid.New().String(), uuid.New().String()}
 	book := entity.Book{
 		ID:        uuid.New().String(),
 		Name:      "Book1",
-		AuthorIDs: []string{uuid.New().String(), uuid.New().String()},
+		AuthorIDs: authorIDs,
+		Contributors: []entity.Contributor{
+			{AuthorID: authorIDs[0], Role: entity.ContributorRoleAuthor},
+			{AuthorID: authorIDs[1], Role: entity.ContributorRoleAuthor},
+		},
 	}
 
 	tests := []struct {
@@ -38,7 +45,7 @@ func TestBookUseCase(t *testing.T) {
 				})
 			},
 			apply: func(data *useCaseData) (*library.Book, error) {
-				resp, err := data.impl.RegisterBook(ctx, book.Name, book.AuthorIDs)
+				resp, err := data.impl.RegisterBook(ctx, book.Name, book.AuthorIDs, nil, entity.IdempotencyKey{})
 				return resp.GetBook(), err
 			},
 			requireNonNilResult: true,
@@ -53,7 +60,7 @@ func TestBookUseCase(t *testing.T) {
 				})
 			},
 			apply: func(data *useCaseData) (*library.Book, error) {
-				resp, err := data.impl.RegisterBook(ctx, book.Name, book.AuthorIDs)
+				resp, err := data.impl.RegisterBook(ctx, book.Name, book.AuthorIDs, nil, entity.IdempotencyKey{})
 				return resp.GetBook(), err
 			},
 			requireNonNilResult: false,
@@ -65,7 +72,7 @@ func TestBookUseCase(t *testing.T) {
 				data.bookRepository.EXPECT().GetBook(ctx, book.ID).Return(book, nil)
 			},
 			apply: func(data *useCaseData) (*library.Book, error) {
-				resp, err := data.impl.GetBook(ctx, book.ID)
+				resp, err := data.impl.GetBook(ctx, book.ID, false, entity.BookViewBasic, time.Time{}, false)
 				return resp.GetBook(), err
 			},
 			requireNonNilResult: true,
@@ -77,7 +84,7 @@ func TestBookUseCase(t *testing.T) {
 				data.bookRepository.EXPECT().GetBook(ctx, book.ID).Return(entity.Book{}, entity.ErrBookNotFound)
 			},
 			apply: func(data *useCaseData) (*library.Book, error) {
-				resp, err := data.impl.GetBook(ctx, book.ID)
+				resp, err := data.impl.GetBook(ctx, book.ID, false, entity.BookViewBasic, time.Time{}, false)
 				return resp.GetBook(), err
 			},
 			requireNonNilResult: false,
@@ -89,7 +96,7 @@ func TestBookUseCase(t *testing.T) {
 				data.bookRepository.EXPECT().ChangeBookInfo(ctx, book.ID, book).Return(book, nil)
 			},
 			apply: func(data *useCaseData) (*library.Book, error) {
-				return nil, data.impl.ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs)
+				return nil, data.impl.ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs, nil)
 			},
 			requireNonNilResult: false,
 			wantErr:             nil,
@@ -100,7 +107,7 @@ func TestBookUseCase(t *testing.T) {
 				data.bookRepository.EXPECT().ChangeBookInfo(ctx, book.ID, book).Return(entity.Book{}, entity.ErrBookNotFound)
 			},
 			apply: func(data *useCaseData) (*library.Book, error) {
-				return nil, data.impl.ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs)
+				return nil, data.impl.ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs, nil)
 			},
 			requireNonNilResult: false,
 			wantErr:             entity.ErrBookNotFound,
@@ -111,11 +118,22 @@ func TestBookUseCase(t *testing.T) {
 				data.bookRepository.EXPECT().ChangeBookInfo(ctx, book.ID, book).Return(entity.Book{}, entity.ErrAuthorNotFound)
 			},
 			apply: func(data *useCaseData) (*library.Book, error) {
-				return nil, data.impl.ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs)
+				return nil, data.impl.ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs, nil)
 			},
 			requireNonNilResult: false,
 			wantErr:             entity.ErrAuthorNotFound,
 		},
+		{
+			testName: "changeBook with authors among contributors",
+			prepare: func(data *useCaseData) {
+				data.bookRepository.EXPECT().ChangeBookInfo(ctx, book.ID, book).Return(book, nil)
+			},
+			apply: func(data *useCaseData) (*library.Book, error) {
+				return nil, data.impl.ChangeBookInfo(ctx, book.ID, book.Name, book.AuthorIDs[:1], book.Contributors)
+			},
+			requireNonNilResult: false,
+			wantErr:             nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -133,7 +151,7 @@ func TestBookUseCase(t *testing.T) {
 				if tt.requireNonNilResult {
 					require.Equal(t, book.ID, result.GetId())
 					require.Equal(t, book.Name, result.GetName())
-					require.ElementsMatch(t, book.AuthorIDs, result.GetAuthorId())
+					require.Equal(t, book.AuthorIDs, result.GetAuthorId())
 				} else {
 					require.Nil(t, result)
 				}
@@ -164,9 +182,9 @@ func TestUseCaseGetBooksByAuthor(t *testing.T) {
 
 	t.Run("get books by author successfully", func(t *testing.T) {
 		t.Parallel()
-		data.bookRepository.EXPECT().GetBooksByAuthor(ctx, author1.ID).Return(books, nil)
+		data.bookRepository.EXPECT().GetBooksByAuthor(ctx, author1.ID, entity.ContributorRoleUndefined).Return(books, nil)
 
-		result, err := data.impl.GetBooksByAuthor(ctx, author1.ID)
+		result, err := data.impl.GetBooksByAuthor(ctx, author1.ID, entity.ContributorRoleUndefined, entity.BookViewBasic)
 		require.NoError(t, err)
 
 		resultIDs := make([]string, 10)
@@ -178,9 +196,105 @@ func TestUseCaseGetBooksByAuthor(t *testing.T) {
 	})
 	t.Run("get books by non existing author", func(t *testing.T) {
 		t.Parallel()
-		data.bookRepository.EXPECT().GetBooksByAuthor(ctx, author1.ID).Return(nil, entity.ErrAuthorNotFound)
+		data.bookRepository.EXPECT().GetBooksByAuthor(ctx, author1.ID, entity.ContributorRoleUndefined).Return(nil, entity.ErrAuthorNotFound)
 
-		_, err := data.impl.GetBooksByAuthor(ctx, author1.ID)
+		_, err := data.impl.GetBooksByAuthor(ctx, author1.ID, entity.ContributorRoleUndefined, entity.BookViewBasic)
 		require.Equal(t, entity.ErrAuthorNotFound, err)
 	})
 }
+
+func TestBookContributors(t *testing.T) {
+	t.Parallel()
+
+	author := uuid.New().String()
+	translator := uuid.New().String()
+
+	authors, contributors := bookContributors([]string{author}, []entity.Contributor{
+		{AuthorID: translator, Role: entity.ContributorRoleTranslator},
+		{AuthorID: author, Role: entity.ContributorRoleAuthor},
+		{AuthorID: author, Role: entity.ContributorRoleIllustrator},
+		{AuthorID: translator, Role: entity.ContributorRoleAuthor},
+	})
+
+	require.Equal(t, []string{author, translator}, authors)
+	require.Equal(t, []entity.Contributor{
+		{AuthorID: author, Role: entity.ContributorRoleAuthor},
+		{AuthorID: translator, Role: entity.ContributorRoleTranslator},
+		{AuthorID: author, Role: entity.ContributorRoleIllustrator},
+		{AuthorID: translator, Role: entity.ContributorRoleAuthor},
+	}, contributors)
+
+	book := convertBookToResponse(entity.Book{AuthorIDs: authors, Contributors: contributors})
+	require.Equal(t, authors, book.GetAuthorId())
+	require.Len(t, book.GetContributors(), 4)
+	require.Equal(t, library.ContributorRole_CONTRIBUTOR_ROLE_TRANSLATOR, book.GetContributors()[1].GetRole())
+}
+
+func TestUseCaseGetBookFullView(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	author := entity.Author{ID: uuid.NewString(), Name: "Frank Herbert"}
+	illustrator := entity.Author{ID: uuid.NewString(), Name: "John Schoenherr"}
+	workID := uuid.NewString()
+	book := entity.Book{
+		ID:        uuid.NewString(),
+		Name:      "Dune",
+		AuthorIDs: []string{author.ID},
+		WorkID:    workID,
+		Contributors: []entity.Contributor{
+			{AuthorID: author.ID, Role: entity.ContributorRoleAuthor},
+			{AuthorID: illustrator.ID, Role: entity.ContributorRoleIllustrator},
+		},
+	}
+	edition := entity.Book{
+		ID:           uuid.NewString(),
+		Name:         "Dune",
+		AuthorIDs:    []string{author.ID},
+		WorkID:       workID,
+		Contributors: []entity.Contributor{{AuthorID: author.ID, Role: entity.ContributorRoleAuthor}},
+	}
+
+	t.Run("authors of the book and its editions are read at once", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.bookRepository.EXPECT().GetBook(ctx, book.ID).Return(book, nil)
+		data.workRepository.EXPECT().GetEditions(ctx, workID).Return([]entity.Book{book, edition}, nil)
+		data.authorRepository.EXPECT().GetAuthors(ctx, []string{author.ID, illustrator.ID}).
+			Return([]entity.Author{illustrator, author}, nil)
+
+		resp, err := data.impl.GetBook(ctx, book.ID, true, entity.BookViewFull, time.Time{}, false)
+		require.NoError(t, err)
+
+		require.Len(t, resp.GetBook().GetAuthors(), 1)
+		require.Equal(t, author.Name, resp.GetBook().GetAuthors()[0].GetName())
+		require.Equal(t, illustrator.Name, resp.GetBook().GetContributors()[1].GetName())
+		require.Len(t, resp.GetEditions(), 1)
+		require.Equal(t, author.ID, resp.GetEditions()[0].GetAuthors()[0].GetId())
+		require.Equal(t, author.Name, resp.GetEditions()[0].GetAuthors()[0].GetName())
+	})
+
+	t.Run("basic view does not read authors", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.bookRepository.EXPECT().GetBook(ctx, book.ID).Return(book, nil)
+
+		resp, err := data.impl.GetBook(ctx, book.ID, false, entity.BookViewBasic, time.Time{}, false)
+		require.NoError(t, err)
+		require.Empty(t, resp.GetBook().GetAuthors())
+		require.Empty(t, resp.GetBook().GetContributors()[1].GetName())
+	})
+
+	t.Run("cannot read authors", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.bookRepository.EXPECT().GetBook(ctx, book.ID).Return(book, nil)
+		data.authorRepository.EXPECT().GetAuthors(ctx, gomock.Any()).Return(nil, errors.New("connection refused"))
+
+		_, err := data.impl.GetBook(ctx, book.ID, false, entity.BookViewFull, time.Time{}, false)
+		require.Error(t, err)
+	})
+}
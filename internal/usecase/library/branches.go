@@ -0,0 +1,374 @@
+package library
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/project/library/internal/entity"
+
+	"go.uber.org/zap"
+)
+
+func convertBranchToResponse(branch entity.Branch) *library.Branch {
+	return &library.Branch{
+		Id:      branch.ID,
+		Name:    branch.Name,
+		Address: branch.Address,
+	}
+}
+
+func convertPatronToResponse(patron entity.Patron) *library.Patron {
+	return &library.Patron{
+		Id:           patron.ID,
+		Name:         patron.Name,
+		HomeBranchId: patron.HomeBranchID,
+	}
+}
+
+func convertCopyToResponse(bookCopy entity.BookCopy) *library.BookCopy {
+	return &library.BookCopy{
+		Id:       bookCopy.ID,
+		BookId:   bookCopy.BookID,
+		BranchId: bookCopy.BranchID,
+		Status:   library.CopyStatus(bookCopy.Status),
+	}
+}
+
+func convertTransferToResponse(transfer entity.Transfer) *library.Transfer {
+	result := &library.Transfer{
+		Id:           transfer.ID,
+		CopyId:       transfer.CopyID,
+		FromBranchId: transfer.FromBranchID,
+		ToBranchId:   transfer.ToBranchID,
+		Status:       library.TransferStatus(transfer.Status),
+		CreatedAt:    timestamppb.New(transfer.CreatedAt),
+	}
+
+	if !transfer.ReceivedAt.IsZero() {
+		result.ReceivedAt = timestamppb.New(transfer.ReceivedAt)
+	}
+
+	return result
+}
+
+func convertLoanToResponse(loan entity.Loan) *library.Loan {
+	result := &library.Loan{
+		Id:         loan.ID,
+		CopyId:     loan.CopyID,
+		BookId:     loan.BookID,
+		PatronId:   loan.PatronID,
+		BorrowedAt: timestamppb.New(loan.BorrowedAt),
+	}
+
+	if !loan.ReturnedAt.IsZero() {
+		result.ReturnedAt = timestamppb.New(loan.ReturnedAt)
+	}
+
+	return result
+}
+
+func (l *libraryImpl) CreateBranch(
+	ctx context.Context,
+	name string,
+	address string,
+	idempotencyKey entity.IdempotencyKey,
+) (*library.CreateBranchResponse, error) {
+	response := &library.CreateBranchResponse{}
+
+	err := l.idempotent(ctx, idempotencyKey, response, func(ctx context.Context) error {
+		branch, err := l.branchRepository.CreateBranch(ctx, entity.Branch{
+			Name:    name,
+			Address: address,
+		})
+
+		if err != nil {
+			l.logger.Error("cannot create branch", zap.Error(err))
+			return err
+		}
+
+		response.Branch = convertBranchToResponse(branch)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (l *libraryImpl) GetBranch(ctx context.Context, branchID string) (*library.GetBranchInfoResponse, error) {
+	branch, err := l.branchRepository.GetBranch(ctx, branchID)
+
+	if err != nil {
+		l.logger.Error("cannot get branch", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.GetBranchInfoResponse{
+		Branch: convertBranchToResponse(branch),
+	}, nil
+}
+
+func (l *libraryImpl) RegisterPatron(
+	ctx context.Context,
+	name string,
+	homeBranchID string,
+	idempotencyKey entity.IdempotencyKey,
+) (*library.RegisterPatronResponse, error) {
+	response := &library.RegisterPatronResponse{}
+
+	err := l.idempotent(ctx, idempotencyKey, response, func(ctx context.Context) error {
+		patron, err := l.branchRepository.CreatePatron(ctx, entity.Patron{
+			Name:         name,
+			HomeBranchID: homeBranchID,
+		})
+
+		if err != nil {
+			l.logger.Error("cannot register patron", zap.Error(err))
+			return err
+		}
+
+		response.Patron = convertPatronToResponse(patron)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (l *libraryImpl) GetPatron(ctx context.Context, patronID string) (*library.GetPatronInfoResponse, error) {
+	patron, err := l.branchRepository.GetPatron(ctx, patronID)
+
+	if err != nil {
+		l.logger.Error("cannot get patron", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.GetPatronInfoResponse{
+		Patron: convertPatronToResponse(patron),
+	}, nil
+}
+
+func (l *libraryImpl) AddBookCopy(
+	ctx context.Context,
+	bookID string,
+	branchID string,
+	idempotencyKey entity.IdempotencyKey,
+) (*library.AddBookCopyResponse, error) {
+	response := &library.AddBookCopyResponse{}
+
+	err := l.idempotent(ctx, idempotencyKey, response, func(ctx context.Context) error {
+		bookCopy, err := l.branchRepository.CreateCopy(ctx, entity.BookCopy{
+			BookID:   bookID,
+			BranchID: branchID,
+			Status:   entity.CopyStatusAvailable,
+		})
+
+		if err != nil {
+			l.logger.Error("cannot add book copy", zap.Error(err))
+			return err
+		}
+
+		response.Copy = convertCopyToResponse(bookCopy)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// RequestTransfer moves an available copy to the destination branch in the in-transit state,
+// so that it is counted as incoming there until ReceiveTransfer is called.
+func (l *libraryImpl) RequestTransfer(ctx context.Context, copyID string, toBranchID string) (*library.RequestTransferResponse, error) {
+	var transfer entity.Transfer
+
+	err := l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		bookCopy, err := l.branchRepository.GetCopyForUpdate(ctx, copyID)
+
+		if err != nil {
+			return err
+		}
+
+		if bookCopy.BranchID == toBranchID {
+			return entity.ErrCopyAlreadyAtBranch
+		}
+
+		if bookCopy.Status != entity.CopyStatusAvailable {
+			return entity.ErrCopyNotAvailable
+		}
+
+		transfer, err = l.branchRepository.CreateTransfer(ctx, entity.Transfer{
+			CopyID:       bookCopy.ID,
+			FromBranchID: bookCopy.BranchID,
+			ToBranchID:   toBranchID,
+			Status:       entity.TransferStatusInTransit,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		bookCopy.BranchID = toBranchID
+		bookCopy.Status = entity.CopyStatusInTransit
+
+		return l.branchRepository.UpdateCopy(ctx, bookCopy)
+	})
+
+	if err != nil {
+		l.logger.Error("cannot request transfer", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.RequestTransferResponse{
+		Transfer: convertTransferToResponse(transfer),
+	}, nil
+}
+
+func (l *libraryImpl) ReceiveTransfer(ctx context.Context, transferID string) (*library.ReceiveTransferResponse, error) {
+	var transfer entity.Transfer
+
+	err := l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		current, err := l.branchRepository.GetTransferForUpdate(ctx, transferID)
+
+		if err != nil {
+			return err
+		}
+
+		if current.Status == entity.TransferStatusReceived {
+			return entity.ErrTransferAlreadyReceived
+		}
+
+		transfer, err = l.branchRepository.MarkTransferReceived(ctx, transferID)
+
+		if err != nil {
+			return err
+		}
+
+		return l.branchRepository.UpdateCopy(ctx, entity.BookCopy{
+			ID:       transfer.CopyID,
+			BranchID: transfer.ToBranchID,
+			Status:   entity.CopyStatusAvailable,
+		})
+	})
+
+	if err != nil {
+		l.logger.Error("cannot receive transfer", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.ReceiveTransferResponse{
+		Transfer: convertTransferToResponse(transfer),
+	}, nil
+}
+
+func (l *libraryImpl) CheckoutCopy(ctx context.Context, copyID string, patronID string) (*library.CheckoutCopyResponse, error) {
+	var loan entity.Loan
+
+	err := l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		bookCopy, err := l.branchRepository.GetCopyForUpdate(ctx, copyID)
+
+		if err != nil {
+			return err
+		}
+
+		if bookCopy.Status != entity.CopyStatusAvailable {
+			return entity.ErrCopyNotAvailable
+		}
+
+		loan, err = l.branchRepository.CreateLoan(ctx, entity.Loan{
+			CopyID:   bookCopy.ID,
+			BookID:   bookCopy.BookID,
+			PatronID: patronID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		bookCopy.Status = entity.CopyStatusCheckedOut
+
+		return l.branchRepository.UpdateCopy(ctx, bookCopy)
+	})
+
+	if err != nil {
+		l.logger.Error("cannot checkout copy", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.CheckoutCopyResponse{
+		Loan: convertLoanToResponse(loan),
+	}, nil
+}
+
+func (l *libraryImpl) ReturnCopy(ctx context.Context, copyID string) (*library.ReturnCopyResponse, error) {
+	var loan entity.Loan
+
+	err := l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		bookCopy, err := l.branchRepository.GetCopyForUpdate(ctx, copyID)
+
+		if err != nil {
+			return err
+		}
+
+		if bookCopy.Status != entity.CopyStatusCheckedOut {
+			return entity.ErrCopyNotCheckedOut
+		}
+
+		loan, err = l.branchRepository.CloseLoan(ctx, copyID)
+
+		if err != nil {
+			return err
+		}
+
+		bookCopy.Status = entity.CopyStatusAvailable
+
+		return l.branchRepository.UpdateCopy(ctx, bookCopy)
+	})
+
+	if err != nil {
+		l.logger.Error("cannot return copy", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.ReturnCopyResponse{
+		Loan: convertLoanToResponse(loan),
+	}, nil
+}
+
+func (l *libraryImpl) GetBookAvailability(
+	ctx context.Context,
+	bookID string,
+	branchID string,
+) (*library.GetBookAvailabilityResponse, error) {
+	availability, err := l.branchRepository.GetBookAvailability(ctx, bookID, branchID)
+
+	if err != nil {
+		l.logger.Error("cannot get book availability", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]*library.BranchAvailability, len(availability))
+	for i, branch := range availability {
+		res[i] = &library.BranchAvailability{
+			BranchId:  branch.BranchID,
+			Available: int32(branch.Available),
+			InTransit: int32(branch.InTransit),
+		}
+	}
+
+	return &library.GetBookAvailabilityResponse{
+		Branches: res,
+	}, nil
+}
@@ -0,0 +1,270 @@
+package library
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func prepareTransactor(ctx context.Context, data *useCaseData) {
+	data.transactor.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, x func(context.Context) error) error {
+		return x(ctx)
+	})
+}
+
+func TestUseCaseRequestTransfer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fromBranchID := uuid.New().String()
+	toBranchID := uuid.New().String()
+	bookCopy := entity.BookCopy{
+		ID:       uuid.New().String(),
+		BookID:   uuid.New().String(),
+		BranchID: fromBranchID,
+		Status:   entity.CopyStatusAvailable,
+	}
+
+	tests := []struct {
+		testName string
+		prepare  func(*useCaseData)
+		toBranch string
+		wantErr  error
+	}{
+		{
+			testName: "transfer requested successfully",
+			prepare: func(data *useCaseData) {
+				prepareTransactor(ctx, data)
+				data.branchRepository.EXPECT().GetCopyForUpdate(ctx, bookCopy.ID).Return(bookCopy, nil)
+				data.branchRepository.EXPECT().CreateTransfer(ctx, entity.Transfer{
+					CopyID:       bookCopy.ID,
+					FromBranchID: fromBranchID,
+					ToBranchID:   toBranchID,
+					Status:       entity.TransferStatusInTransit,
+				}).Return(entity.Transfer{ID: uuid.New().String(), CopyID: bookCopy.ID}, nil)
+				data.branchRepository.EXPECT().UpdateCopy(ctx, entity.BookCopy{
+					ID:       bookCopy.ID,
+					BookID:   bookCopy.BookID,
+					BranchID: toBranchID,
+					Status:   entity.CopyStatusInTransit,
+				}).Return(nil)
+			},
+			toBranch: toBranchID,
+		},
+		{
+			testName: "copy already in transit",
+			prepare: func(data *useCaseData) {
+				prepareTransactor(ctx, data)
+				inTransit := bookCopy
+				inTransit.Status = entity.CopyStatusInTransit
+				data.branchRepository.EXPECT().GetCopyForUpdate(ctx, bookCopy.ID).Return(inTransit, nil)
+			},
+			toBranch: toBranchID,
+			wantErr:  entity.ErrCopyNotAvailable,
+		},
+		{
+			testName: "copy already at branch",
+			prepare: func(data *useCaseData) {
+				prepareTransactor(ctx, data)
+				data.branchRepository.EXPECT().GetCopyForUpdate(ctx, bookCopy.ID).Return(bookCopy, nil)
+			},
+			toBranch: fromBranchID,
+			wantErr:  entity.ErrCopyAlreadyAtBranch,
+		},
+		{
+			testName: "copy not found",
+			prepare: func(data *useCaseData) {
+				prepareTransactor(ctx, data)
+				data.branchRepository.EXPECT().GetCopyForUpdate(ctx, bookCopy.ID).Return(entity.BookCopy{}, entity.ErrCopyNotFound)
+			},
+			toBranch: toBranchID,
+			wantErr:  entity.ErrCopyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Parallel()
+			data := getUseCaseData(t)
+			tt.prepare(data)
+
+			resp, err := data.impl.RequestTransfer(ctx, bookCopy.ID, tt.toBranch)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, bookCopy.ID, resp.GetTransfer().GetCopyId())
+		})
+	}
+}
+
+func TestUseCaseReceiveTransfer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	transfer := entity.Transfer{
+		ID:           uuid.New().String(),
+		CopyID:       uuid.New().String(),
+		FromBranchID: uuid.New().String(),
+		ToBranchID:   uuid.New().String(),
+		Status:       entity.TransferStatusInTransit,
+	}
+
+	t.Run("transfer received successfully", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+
+		received := transfer
+		received.Status = entity.TransferStatusReceived
+
+		data.branchRepository.EXPECT().GetTransferForUpdate(ctx, transfer.ID).Return(transfer, nil)
+		data.branchRepository.EXPECT().MarkTransferReceived(ctx, transfer.ID).Return(received, nil)
+		data.branchRepository.EXPECT().UpdateCopy(ctx, entity.BookCopy{
+			ID:       transfer.CopyID,
+			BranchID: transfer.ToBranchID,
+			Status:   entity.CopyStatusAvailable,
+		}).Return(nil)
+
+		resp, err := data.impl.ReceiveTransfer(ctx, transfer.ID)
+		require.NoError(t, err)
+		require.Equal(t, transfer.ID, resp.GetTransfer().GetId())
+	})
+	t.Run("transfer already received", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+
+		received := transfer
+		received.Status = entity.TransferStatusReceived
+		data.branchRepository.EXPECT().GetTransferForUpdate(ctx, transfer.ID).Return(received, nil)
+
+		_, err := data.impl.ReceiveTransfer(ctx, transfer.ID)
+		require.ErrorIs(t, err, entity.ErrTransferAlreadyReceived)
+	})
+}
+
+func TestUseCaseGetBookAvailability(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	data := getUseCaseData(t)
+	bookID := uuid.New().String()
+	availability := []entity.BranchAvailability{
+		{BranchID: uuid.New().String(), Available: 2, InTransit: 1},
+		{BranchID: uuid.New().String(), Available: 0, InTransit: 3},
+	}
+
+	data.branchRepository.EXPECT().GetBookAvailability(ctx, bookID, "").Return(availability, nil)
+
+	resp, err := data.impl.GetBookAvailability(ctx, bookID, "")
+	require.NoError(t, err)
+	require.Len(t, resp.GetBranches(), 2)
+	require.Equal(t, int32(2), resp.GetBranches()[0].GetAvailable())
+	require.Equal(t, int32(3), resp.GetBranches()[1].GetInTransit())
+}
+
+func TestUseCaseRegisterPatron(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	data := getUseCaseData(t)
+	patron := entity.Patron{
+		Name:         "Patron1",
+		HomeBranchID: uuid.New().String(),
+	}
+
+	data.transactor.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, x func(context.Context) error) error {
+		return x(ctx)
+	})
+	data.branchRepository.EXPECT().CreatePatron(ctx, patron).Return(entity.Patron{}, entity.ErrBranchNotFound)
+
+	_, err := data.impl.RegisterPatron(ctx, patron.Name, patron.HomeBranchID, entity.IdempotencyKey{})
+	require.ErrorIs(t, err, entity.ErrBranchNotFound)
+}
+
+func TestUseCaseCheckoutCopy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	patronID := uuid.New().String()
+	bookCopy := entity.BookCopy{
+		ID:       uuid.New().String(),
+		BookID:   uuid.New().String(),
+		BranchID: uuid.New().String(),
+		Status:   entity.CopyStatusAvailable,
+	}
+
+	t.Run("copy checked out successfully", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		data.branchRepository.EXPECT().GetCopyForUpdate(ctx, bookCopy.ID).Return(bookCopy, nil)
+		data.branchRepository.EXPECT().CreateLoan(ctx, entity.Loan{
+			CopyID:   bookCopy.ID,
+			BookID:   bookCopy.BookID,
+			PatronID: patronID,
+		}).Return(entity.Loan{ID: uuid.New().String(), CopyID: bookCopy.ID, PatronID: patronID}, nil)
+		checkedOut := bookCopy
+		checkedOut.Status = entity.CopyStatusCheckedOut
+		data.branchRepository.EXPECT().UpdateCopy(ctx, checkedOut).Return(nil)
+
+		resp, err := data.impl.CheckoutCopy(ctx, bookCopy.ID, patronID)
+		require.NoError(t, err)
+		require.Equal(t, patronID, resp.GetLoan().GetPatronId())
+		require.Nil(t, resp.GetLoan().GetReturnedAt())
+	})
+
+	t.Run("copy already checked out", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		checkedOut := bookCopy
+		checkedOut.Status = entity.CopyStatusCheckedOut
+		data.branchRepository.EXPECT().GetCopyForUpdate(ctx, bookCopy.ID).Return(checkedOut, nil)
+
+		_, err := data.impl.CheckoutCopy(ctx, bookCopy.ID, patronID)
+		require.ErrorIs(t, err, entity.ErrCopyNotAvailable)
+	})
+}
+
+func TestUseCaseReturnCopy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookCopy := entity.BookCopy{
+		ID:       uuid.New().String(),
+		BookID:   uuid.New().String(),
+		BranchID: uuid.New().String(),
+		Status:   entity.CopyStatusCheckedOut,
+	}
+
+	t.Run("copy returned successfully", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		data.branchRepository.EXPECT().GetCopyForUpdate(ctx, bookCopy.ID).Return(bookCopy, nil)
+		data.branchRepository.EXPECT().CloseLoan(ctx, bookCopy.ID).
+			Return(entity.Loan{ID: uuid.New().String(), CopyID: bookCopy.ID, ReturnedAt: time.Now()}, nil)
+		available := bookCopy
+		available.Status = entity.CopyStatusAvailable
+		data.branchRepository.EXPECT().UpdateCopy(ctx, available).Return(nil)
+
+		resp, err := data.impl.ReturnCopy(ctx, bookCopy.ID)
+		require.NoError(t, err)
+		require.NotNil(t, resp.GetLoan().GetReturnedAt())
+	})
+
+	t.Run("copy is not checked out", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		available := bookCopy
+		available.Status = entity.CopyStatusAvailable
+		data.branchRepository.EXPECT().GetCopyForUpdate(ctx, bookCopy.ID).Return(available, nil)
+
+		_, err := data.impl.ReturnCopy(ctx, bookCopy.ID)
+		require.ErrorIs(t, err, entity.ErrCopyNotCheckedOut)
+	})
+}
@@ -0,0 +1,324 @@
+package library
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/catalog"
+	"github.com/project/library/internal/usecase/repository"
+
+	"go.uber.org/zap"
+)
+
+const (
+	exportBatchSize = 500
+	// importChunkSize bounds the rows buffered and written in one transaction by ImportCatalog
+	importChunkSize = 1000
+)
+
+// ImportCatalog reads the input in chunks of importChunkSize rows and imports every chunk
+// in its own transaction, so a large catalog is never kept in memory or locked at once.
+// Rows that fail to parse are reported back and skipped, a chunk that fails to import
+// is reported row by row and the next chunks are still imported.
+// Authors are matched by name, unknown ones are created.
+func (l *libraryImpl) ImportCatalog(
+	ctx context.Context,
+	format entity.CatalogFormat,
+	dryRun bool,
+	data io.Reader,
+) (*library.ImportCatalogResponse, error) {
+	response := &library.ImportCatalogResponse{
+		DryRun: dryRun,
+	}
+
+	// a dry run creates nothing, the authors missing in several chunks are counted once
+	plannedAuthors := make(map[string]bool)
+	var chunkErrors []entity.CatalogRowError
+
+	rowErrors, err := catalog.ParseChunks(format, data, importChunkSize, func(rows []entity.CatalogRow) error {
+		response.TotalRows += int32(len(rows))
+
+		created, err := l.importCatalogChunk(ctx, dryRun, rows, plannedAuthors)
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		if err != nil {
+			for _, row := range rows {
+				chunkErrors = append(chunkErrors, entity.CatalogRowError{
+					Line:    row.Line,
+					Message: "not imported: " + err.Error(),
+				})
+			}
+
+			return nil
+		}
+
+		response.ImportedBooks += int32(len(rows))
+		response.CreatedAuthors += int32(created)
+
+		return nil
+	})
+
+	if err != nil {
+		l.logger.Error("cannot import catalog", zap.Error(err))
+		return nil, err
+	}
+
+	response.TotalRows += int32(len(rowErrors))
+
+	rowErrors = append(rowErrors, chunkErrors...)
+	slices.SortStableFunc(rowErrors, func(a, b entity.CatalogRowError) int {
+		return a.Line - b.Line
+	})
+
+	response.Errors = convertRowErrorsToResponse(rowErrors)
+
+	return response, nil
+}
+
+// importCatalogChunk writes the books of the rows and their new authors in one transaction
+// and returns the number of authors created, or that would be created by a dry run.
+func (l *libraryImpl) importCatalogChunk(
+	ctx context.Context,
+	dryRun bool,
+	rows []entity.CatalogRow,
+	plannedAuthors map[string]bool,
+) (int, error) {
+	authorIDs, missing, err := l.resolveAuthors(ctx, rows)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if dryRun {
+		created := 0
+		for _, name := range missing {
+			if !plannedAuthors[name] {
+				plannedAuthors[name] = true
+				created++
+			}
+		}
+
+		return created, nil
+	}
+
+	err = l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		var messages []repository.OutboxData
+
+		if len(missing) > 0 {
+			authors, err := l.catalogRepository.CreateAuthors(ctx, missing)
+
+			if err != nil {
+				l.logger.Error("cannot create authors", zap.Error(err))
+				return err
+			}
+
+			for _, author := range authors {
+				authorIDs[author.Name] = author.ID
+			}
+
+			if messages, err = appendOutboxMessages(messages, repository.OutboxKindAuthor, authors, func(author entity.Author) string {
+				return author.ID
+			}); err != nil {
+				l.logger.Error("cannot serialize author", zap.Error(err))
+				return err
+			}
+		}
+
+		books := make([]entity.Book, len(rows))
+		for i, row := range rows {
+			books[i] = entity.Book{
+				Name:            row.Name,
+				AuthorIDs:       make([]string, 0, len(row.AuthorNames)),
+				ISBN:            row.ISBN,
+				Publisher:       row.Publisher,
+				PublicationYear: row.PublicationYear,
+			}
+
+			for _, name := range row.AuthorNames {
+				if id := authorIDs[name]; !slices.Contains(books[i].AuthorIDs, id) {
+					books[i].AuthorIDs = append(books[i].AuthorIDs, id)
+				}
+			}
+		}
+
+		books, err := l.catalogRepository.CreateBooks(ctx, books)
+
+		if err != nil {
+			l.logger.Error("cannot create books", zap.Error(err))
+			return err
+		}
+
+		if messages, err = appendOutboxMessages(messages, repository.OutboxKindBook, books, func(book entity.Book) string {
+			return book.ID
+		}); err != nil {
+			l.logger.Error("cannot serialize book", zap.Error(err))
+			return err
+		}
+
+		if err = l.outboxRepository.SendMessages(ctx, messages); err != nil {
+			l.logger.Error("cannot send messages to outbox", zap.Error(err))
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(missing), nil
+}
+
+// resolveAuthors maps every author name of the rows to an existing author id
+// and returns the names that have no author yet, in order of appearance.
+func (l *libraryImpl) resolveAuthors(ctx context.Context, rows []entity.CatalogRow) (map[string]string, []string, error) {
+	authorIDs := make(map[string]string)
+	names := make([]string, 0)
+	for _, row := range rows {
+		for _, name := range row.AuthorNames {
+			if _, ok := authorIDs[name]; !ok {
+				authorIDs[name] = ""
+				names = append(names, name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return authorIDs, nil, nil
+	}
+
+	authors, err := l.catalogRepository.GetAuthorsByNames(ctx, names)
+
+	if err != nil {
+		l.logger.Error("cannot get authors by names", zap.Error(err))
+		return nil, nil, err
+	}
+
+	for _, author := range authors {
+		authorIDs[author.Name] = author.ID
+	}
+
+	missing := make([]string, 0)
+	for _, name := range names {
+		if authorIDs[name] == "" {
+			missing = append(missing, name)
+		}
+	}
+
+	return authorIDs, missing, nil
+}
+
+func appendOutboxMessages[T any](
+	messages []repository.OutboxData,
+	kind repository.OutboxKind,
+	items []T,
+	id func(T) string,
+) ([]repository.OutboxData, error) {
+	for _, item := range items {
+		serialized, err := json.Marshal(item)
+
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, repository.OutboxData{
+			IdempotencyKey: kind.String() + "_" + id(item),
+			Kind:           kind,
+			RawData:        serialized,
+		})
+	}
+
+	return messages, nil
+}
+
+func convertRowErrorsToResponse(rowErrors []entity.CatalogRowError) []*library.ImportRowError {
+	res := make([]*library.ImportRowError, len(rowErrors))
+	for i, rowError := range rowErrors {
+		res[i] = &library.ImportRowError{
+			Line:    int32(rowError.Line),
+			Message: rowError.Message,
+		}
+	}
+
+	return res
+}
+
+// ExportCatalog streams the books matching the filter in batches,
+// only one batch is kept in memory at a time.
+func (l *libraryImpl) ExportCatalog(
+	ctx context.Context,
+	format entity.CatalogFormat,
+	filter entity.CatalogFilter,
+	w io.Writer,
+) error {
+	writer, err := catalog.NewWriter(format, w)
+
+	if err != nil {
+		return err
+	}
+
+	filter.Subject = strings.ToLower(strings.TrimSpace(filter.Subject))
+
+	afterID := ""
+	for {
+		books, err := l.catalogRepository.ListCatalogBooks(ctx, filter, afterID, exportBatchSize)
+
+		if err != nil {
+			l.logger.Error("cannot list catalog books", zap.Error(err))
+			return err
+		}
+
+		for _, book := range books {
+			if err = writer.Write(book); err != nil {
+				l.logger.Error("cannot write catalog book", zap.Error(err))
+				return err
+			}
+		}
+
+		if len(books) < exportBatchSize {
+			break
+		}
+
+		afterID = books[len(books)-1].Book.ID
+	}
+
+	return writer.Close()
+}
+
+// ExportMarc writes the requested books as MARC records in the order of bookIDs.
+func (l *libraryImpl) ExportMarc(ctx context.Context, format entity.CatalogFormat, bookIDs []string, w io.Writer) error {
+	books, err := l.catalogRepository.GetCatalogBooks(ctx, bookIDs)
+
+	if err != nil {
+		l.logger.Error("cannot get catalog books", zap.Error(err))
+		return err
+	}
+
+	if len(books) != len(bookIDs) {
+		return entity.ErrBookNotFound
+	}
+
+	writer, err := catalog.NewWriter(format, w)
+
+	if err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		if err = writer.Write(book); err != nil {
+			l.logger.Error("cannot write marc record", zap.Error(err))
+			return err
+		}
+	}
+
+	return writer.Close()
+}
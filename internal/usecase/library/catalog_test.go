@@ -0,0 +1,197 @@
+package library
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/repository"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseImportCatalog(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	data := `name,authors
+Dune,Frank Herbert
+Good Omens,Terry Pratchett;Neil Gaiman
+,Nobody
+`
+	herbert := entity.Author{ID: uuid.New().String(), Name: "Frank Herbert"}
+	pratchett := entity.Author{ID: uuid.New().String(), Name: "Terry Pratchett"}
+	gaiman := entity.Author{ID: uuid.New().String(), Name: "Neil Gaiman"}
+
+	t.Run("catalog imported successfully", func(t *testing.T) {
+		t.Parallel()
+		useCaseData := getUseCaseData(t)
+		prepareTransactor(ctx, useCaseData)
+
+		useCaseData.catalogRepo.EXPECT().
+			GetAuthorsByNames(ctx, []string{"Frank Herbert", "Terry Pratchett", "Neil Gaiman"}).
+			Return([]entity.Author{herbert, pratchett}, nil)
+		useCaseData.catalogRepo.EXPECT().CreateAuthors(ctx, []string{"Neil Gaiman"}).Return([]entity.Author{gaiman}, nil)
+		useCaseData.catalogRepo.EXPECT().CreateBooks(ctx, []entity.Book{
+			{Name: "Dune", AuthorIDs: []string{herbert.ID}},
+			{Name: "Good Omens", AuthorIDs: []string{pratchett.ID, gaiman.ID}},
+		}).Return([]entity.Book{
+			{ID: uuid.New().String(), Name: "Dune", AuthorIDs: []string{herbert.ID}},
+			{ID: uuid.New().String(), Name: "Good Omens", AuthorIDs: []string{pratchett.ID, gaiman.ID}},
+		}, nil)
+		useCaseData.outboxRepository.EXPECT().SendMessages(ctx, gomock.Len(3)).
+			DoAndReturn(func(_ context.Context, messages []repository.OutboxData) error {
+				require.Equal(t, repository.OutboxKindAuthor, messages[0].Kind)
+				require.Equal(t, repository.OutboxKindBook, messages[1].Kind)
+				return nil
+			})
+
+		resp, err := useCaseData.impl.ImportCatalog(ctx, entity.CatalogFormatCSV, false, strings.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, int32(3), resp.GetTotalRows())
+		require.Equal(t, int32(2), resp.GetImportedBooks())
+		require.Equal(t, int32(1), resp.GetCreatedAuthors())
+		require.Len(t, resp.GetErrors(), 1)
+		require.Equal(t, int32(4), resp.GetErrors()[0].GetLine())
+	})
+
+	t.Run("dry run does not write", func(t *testing.T) {
+		t.Parallel()
+		useCaseData := getUseCaseData(t)
+
+		useCaseData.catalogRepo.EXPECT().
+			GetAuthorsByNames(ctx, []string{"Frank Herbert", "Terry Pratchett", "Neil Gaiman"}).
+			Return([]entity.Author{herbert}, nil)
+
+		resp, err := useCaseData.impl.ImportCatalog(ctx, entity.CatalogFormatCSV, true, strings.NewReader(data))
+		require.NoError(t, err)
+		require.True(t, resp.GetDryRun())
+		require.Equal(t, int32(2), resp.GetImportedBooks())
+		require.Equal(t, int32(2), resp.GetCreatedAuthors())
+	})
+
+	t.Run("failed chunk is reported row by row", func(t *testing.T) {
+		t.Parallel()
+		useCaseData := getUseCaseData(t)
+		prepareTransactor(ctx, useCaseData)
+
+		useCaseData.catalogRepo.EXPECT().
+			GetAuthorsByNames(ctx, []string{"Frank Herbert", "Terry Pratchett", "Neil Gaiman"}).
+			Return([]entity.Author{herbert, pratchett, gaiman}, nil)
+		useCaseData.catalogRepo.EXPECT().CreateBooks(ctx, gomock.Len(2)).Return(nil, errors.New("copy failed"))
+
+		resp, err := useCaseData.impl.ImportCatalog(ctx, entity.CatalogFormatCSV, false, strings.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, int32(3), resp.GetTotalRows())
+		require.Equal(t, int32(0), resp.GetImportedBooks())
+		require.Equal(t, []int32{2, 3, 4}, []int32{
+			resp.GetErrors()[0].GetLine(),
+			resp.GetErrors()[1].GetLine(),
+			resp.GetErrors()[2].GetLine(),
+		})
+		require.Contains(t, resp.GetErrors()[0].GetMessage(), "not imported")
+	})
+
+	t.Run("large catalog is imported in chunks", func(t *testing.T) {
+		t.Parallel()
+		useCaseData := getUseCaseData(t)
+
+		var large strings.Builder
+		large.WriteString("name,authors\n")
+		for range importChunkSize + 1 {
+			large.WriteString("Dune,Frank Herbert\n")
+		}
+
+		useCaseData.transactor.EXPECT().WithTx(ctx, gomock.Any()).Times(2).
+			DoAndReturn(func(ctx context.Context, x func(context.Context) error) error {
+				return x(ctx)
+			})
+		useCaseData.catalogRepo.EXPECT().GetAuthorsByNames(ctx, []string{"Frank Herbert"}).
+			Times(2).Return([]entity.Author{herbert}, nil)
+		gomock.InOrder(
+			useCaseData.catalogRepo.EXPECT().CreateBooks(ctx, gomock.Len(importChunkSize)).
+				DoAndReturn(func(_ context.Context, books []entity.Book) ([]entity.Book, error) {
+					return books, nil
+				}),
+			useCaseData.catalogRepo.EXPECT().CreateBooks(ctx, gomock.Len(1)).
+				DoAndReturn(func(_ context.Context, books []entity.Book) ([]entity.Book, error) {
+					return books, nil
+				}),
+		)
+		useCaseData.outboxRepository.EXPECT().SendMessages(ctx, gomock.Any()).Times(2).Return(nil)
+
+		resp, err := useCaseData.impl.ImportCatalog(ctx, entity.CatalogFormatCSV, false, strings.NewReader(large.String()))
+		require.NoError(t, err)
+		require.Equal(t, int32(importChunkSize+1), resp.GetTotalRows())
+		require.Equal(t, int32(importChunkSize+1), resp.GetImportedBooks())
+		require.Empty(t, resp.GetErrors())
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		t.Parallel()
+		useCaseData := getUseCaseData(t)
+
+		_, err := useCaseData.impl.ImportCatalog(ctx, entity.CatalogFormatUndefined, false, strings.NewReader(data))
+		require.ErrorIs(t, err, entity.ErrUnsupportedCatalogFormat)
+	})
+}
+
+func TestUseCaseExportMarc(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	book := entity.CatalogBook{
+		Book: entity.Book{
+			ID:   uuid.New().String(),
+			Name: "Dune",
+		},
+		AuthorNames: []string{"Frank Herbert"},
+	}
+
+	t.Run("books exported successfully", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.catalogRepo.EXPECT().GetCatalogBooks(ctx, []string{book.Book.ID}).Return([]entity.CatalogBook{book}, nil)
+
+		var out strings.Builder
+		err := data.impl.ExportMarc(ctx, entity.CatalogFormatMARCXML, []string{book.Book.ID}, &out)
+		require.NoError(t, err)
+		require.Contains(t, out.String(), `<subfield code="a">Herbert, Frank</subfield>`)
+		require.True(t, strings.HasSuffix(out.String(), "</collection>\n"))
+	})
+
+	t.Run("book not found", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.catalogRepo.EXPECT().GetCatalogBooks(ctx, []string{book.Book.ID, uuid.Nil.String()}).Return([]entity.CatalogBook{book}, nil)
+
+		err := data.impl.ExportMarc(ctx, entity.CatalogFormatMARC21, []string{book.Book.ID, uuid.Nil.String()}, io.Discard)
+		require.ErrorIs(t, err, entity.ErrBookNotFound)
+	})
+}
+
+func TestUseCaseExportCatalog(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	data := getUseCaseData(t)
+	filter := entity.CatalogFilter{Subject: "fantasy"}
+
+	firstBatch := make([]entity.CatalogBook, exportBatchSize)
+	for i := range firstBatch {
+		firstBatch[i] = entity.CatalogBook{Book: entity.Book{ID: uuid.New().String(), Name: "Book"}}
+	}
+	lastID := firstBatch[exportBatchSize-1].Book.ID
+
+	gomock.InOrder(
+		data.catalogRepo.EXPECT().ListCatalogBooks(ctx, filter, "", exportBatchSize).Return(firstBatch, nil),
+		data.catalogRepo.EXPECT().ListCatalogBooks(ctx, filter, lastID, exportBatchSize).
+			Return([]entity.CatalogBook{{Book: entity.Book{ID: uuid.New().String(), Name: "Last"}}}, nil),
+	)
+
+	var out strings.Builder
+	err := data.impl.ExportCatalog(ctx, entity.CatalogFormatJSONL, entity.CatalogFilter{Subject: " Fantasy "}, &out)
+	require.NoError(t, err)
+	require.Equal(t, exportBatchSize+1, strings.Count(out.String(), "\n"))
+}
@@ -0,0 +1,81 @@
+package library
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCollaborationDepth = 6
+)
+
+func (l *libraryImpl) GetCoAuthors(ctx context.Context, authorID string) (*library.GetCoAuthorsResponse, error) {
+	if _, err := l.authorRepository.GetAuthor(ctx, authorID); err != nil {
+		l.logger.Error("cannot get author", zap.Error(err))
+		return nil, err
+	}
+
+	coAuthors, err := l.authorRepository.GetCoAuthors(ctx, authorID)
+
+	if err != nil {
+		l.logger.Error("cannot get co-authors", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]*library.CoAuthor, len(coAuthors))
+	for i, coAuthor := range coAuthors {
+		res[i] = &library.CoAuthor{
+			Id:          coAuthor.Author.ID,
+			Name:        coAuthor.Author.Name,
+			SharedBooks: int32(coAuthor.SharedBooks),
+		}
+	}
+
+	return &library.GetCoAuthorsResponse{
+		CoAuthors: res,
+	}, nil
+}
+
+// GetCollaborationPath returns the shortest chain of co-authors between two authors,
+// maxDepth limits the number of hops and defaults to defaultCollaborationDepth.
+func (l *libraryImpl) GetCollaborationPath(
+	ctx context.Context,
+	fromAuthorID string,
+	toAuthorID string,
+	maxDepth int,
+) (*library.GetCollaborationPathResponse, error) {
+	for _, authorID := range []string{fromAuthorID, toAuthorID} {
+		if _, err := l.authorRepository.GetAuthor(ctx, authorID); err != nil {
+			l.logger.Error("cannot get author", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	if maxDepth <= 0 {
+		maxDepth = defaultCollaborationDepth
+	}
+
+	steps, err := l.authorRepository.GetCollaborationPath(ctx, fromAuthorID, toAuthorID, maxDepth)
+
+	if err != nil {
+		l.logger.Error("cannot get collaboration path", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]*library.CollaborationStep, len(steps))
+	for i, step := range steps {
+		res[i] = &library.CollaborationStep{
+			AuthorId:   step.Author.ID,
+			AuthorName: step.Author.Name,
+			BookId:     step.BookID,
+		}
+	}
+
+	return &library.GetCollaborationPathResponse{
+		Distance: int32(len(steps) - 1),
+		Steps:    res,
+	}, nil
+}
@@ -0,0 +1,112 @@
+package library
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCaseGetCoAuthors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	author := entity.Author{ID: uuid.New().String(), Name: "Author1"}
+	coAuthor := entity.CoAuthor{
+		Author:      entity.Author{ID: uuid.New().String(), Name: "Author2"},
+		SharedBooks: 3,
+	}
+
+	t.Run("co-authors returned successfully", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.authorRepository.EXPECT().GetAuthor(ctx, author.ID).Return(author, nil)
+		data.authorRepository.EXPECT().GetCoAuthors(ctx, author.ID).Return([]entity.CoAuthor{coAuthor}, nil)
+
+		resp, err := data.impl.GetCoAuthors(ctx, author.ID)
+		require.NoError(t, err)
+		require.Len(t, resp.GetCoAuthors(), 1)
+		require.Equal(t, coAuthor.Author.ID, resp.GetCoAuthors()[0].GetId())
+		require.Equal(t, int32(3), resp.GetCoAuthors()[0].GetSharedBooks())
+	})
+
+	t.Run("author not found", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.authorRepository.EXPECT().GetAuthor(ctx, author.ID).Return(entity.Author{}, entity.ErrAuthorNotFound)
+
+		_, err := data.impl.GetCoAuthors(ctx, author.ID)
+		require.ErrorIs(t, err, entity.ErrAuthorNotFound)
+	})
+}
+
+func TestUseCaseGetCollaborationPath(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	from := entity.Author{ID: uuid.New().String(), Name: "Author1"}
+	middle := entity.Author{ID: uuid.New().String(), Name: "Author2"}
+	to := entity.Author{ID: uuid.New().String(), Name: "Author3"}
+	steps := []entity.CollaborationStep{
+		{Author: from},
+		{Author: middle, BookID: uuid.New().String()},
+		{Author: to, BookID: uuid.New().String()},
+	}
+
+	tests := []struct {
+		testName string
+		maxDepth int
+		depth    int
+		returned []entity.CollaborationStep
+		err      error
+	}{
+		{
+			testName: "path with default depth",
+			maxDepth: 0,
+			depth:    defaultCollaborationDepth,
+			returned: steps,
+		},
+		{
+			testName: "path with explicit depth",
+			maxDepth: 2,
+			depth:    2,
+			returned: steps,
+		},
+		{
+			testName: "authors are not connected",
+			maxDepth: 1,
+			depth:    1,
+			err:      entity.ErrCollaborationPathNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Parallel()
+			data := getUseCaseData(t)
+			data.authorRepository.EXPECT().GetAuthor(ctx, from.ID).Return(from, nil)
+			data.authorRepository.EXPECT().GetAuthor(ctx, to.ID).Return(to, nil)
+			data.authorRepository.EXPECT().GetCollaborationPath(ctx, from.ID, to.ID, tt.depth).Return(tt.returned, tt.err)
+
+			resp, err := data.impl.GetCollaborationPath(ctx, from.ID, to.ID, tt.maxDepth)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int32(2), resp.GetDistance())
+			require.Equal(t, middle.Name, resp.GetSteps()[1].GetAuthorName())
+			require.Empty(t, resp.GetSteps()[0].GetBookId())
+		})
+	}
+
+	t.Run("unknown author", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.authorRepository.EXPECT().GetAuthor(ctx, from.ID).Return(entity.Author{}, entity.ErrAuthorNotFound)
+
+		_, err := data.impl.GetCollaborationPath(ctx, from.ID, to.ID, 0)
+		require.ErrorIs(t, err, entity.ErrAuthorNotFound)
+	})
+}
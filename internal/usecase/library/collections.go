@@ -0,0 +1,278 @@
+package library
+
+import (
+	"context"
+	"slices"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/project/library/internal/entity"
+
+	"go.uber.org/zap"
+)
+
+func convertCollectionToResponse(collection entity.Collection) *library.Collection {
+	items := make([]*library.CollectionItem, len(collection.Items))
+	for i, item := range collection.Items {
+		items[i] = &library.CollectionItem{
+			Book: convertBookToResponse(item.Book),
+			Note: item.Note,
+		}
+	}
+
+	return &library.Collection{
+		Id:          collection.ID,
+		OwnerId:     collection.OwnerID,
+		Name:        collection.Name,
+		Description: collection.Description,
+		Visibility:  library.CollectionVisibility(collection.Visibility),
+		Items:       items,
+		SharedWith:  collection.SharedWith,
+		CreatedAt:   timestamppb.New(collection.CreatedAt),
+		UpdatedAt:   timestamppb.New(collection.UpdatedAt),
+	}
+}
+
+// expandCollectionAuthors expands the authors of the books in all the collections at once.
+func (l *libraryImpl) expandCollectionAuthors(ctx context.Context, view entity.BookView, collections ...*library.Collection) error {
+	books := make([]*library.Book, 0)
+	for _, collection := range collections {
+		for _, item := range collection.GetItems() {
+			books = append(books, item.GetBook())
+		}
+	}
+
+	return l.expandAuthors(ctx, view, books...)
+}
+
+func (l *libraryImpl) CreateCollection(
+	ctx context.Context,
+	collection entity.Collection,
+	idempotencyKey entity.IdempotencyKey,
+) (*library.CreateCollectionResponse, error) {
+	response := &library.CreateCollectionResponse{}
+
+	err := l.idempotent(ctx, idempotencyKey, response, func(ctx context.Context) error {
+		result, err := l.collectionRepository.CreateCollection(ctx, collection)
+
+		if err != nil {
+			l.logger.Error("cannot create collection", zap.Error(err))
+			return err
+		}
+
+		response.Collection = convertCollectionToResponse(result)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetCollection hides private collections from everyone except the owner and patrons it was shared with.
+func (l *libraryImpl) GetCollection(
+	ctx context.Context,
+	collectionID string,
+	viewerID string,
+	view entity.BookView,
+) (*library.GetCollectionResponse, error) {
+	collection, err := l.collectionRepository.GetCollection(ctx, collectionID)
+
+	if err == nil && !collection.VisibleTo(viewerID) {
+		err = entity.ErrCollectionNotFound
+	}
+
+	if err != nil {
+		l.logger.Error("cannot get collection", zap.Error(err))
+		return nil, err
+	}
+
+	response := &library.GetCollectionResponse{
+		Collection: convertCollectionToResponse(collection),
+	}
+
+	if err = l.expandCollectionAuthors(ctx, view, response.GetCollection()); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// changeCollection locks the collection, checks the ownership and runs change in a transaction,
+// the collection is reread afterwards so that the result reflects the change.
+func (l *libraryImpl) changeCollection(
+	ctx context.Context,
+	collectionID string,
+	ownerID string,
+	change func(ctx context.Context, collection entity.Collection) error,
+) (entity.Collection, error) {
+	var result entity.Collection
+
+	err := l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		collection, err := l.collectionRepository.GetCollectionForUpdate(ctx, collectionID)
+
+		if err != nil {
+			return err
+		}
+
+		if collection.OwnerID != ownerID {
+			return entity.ErrCollectionAccessDenied
+		}
+
+		if err = change(ctx, collection); err != nil {
+			return err
+		}
+
+		result, err = l.collectionRepository.GetCollection(ctx, collectionID)
+
+		return err
+	})
+
+	return result, err
+}
+
+func (l *libraryImpl) AddCollectionBook(
+	ctx context.Context,
+	collectionID string,
+	ownerID string,
+	bookID string,
+	note string,
+) (*library.AddCollectionBookResponse, error) {
+	collection, err := l.changeCollection(ctx, collectionID, ownerID, func(ctx context.Context, collection entity.Collection) error {
+		if slices.Contains(collection.BookIDs(), bookID) {
+			return entity.ErrBookAlreadyInCollection
+		}
+
+		return l.collectionRepository.AddCollectionItem(ctx, collectionID, entity.CollectionItem{
+			Book: entity.Book{ID: bookID},
+			Note: note,
+		})
+	})
+
+	if err != nil {
+		l.logger.Error("cannot add book to collection", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.AddCollectionBookResponse{
+		Collection: convertCollectionToResponse(collection),
+	}, nil
+}
+
+func (l *libraryImpl) RemoveCollectionBook(
+	ctx context.Context,
+	collectionID string,
+	ownerID string,
+	bookID string,
+) (*library.RemoveCollectionBookResponse, error) {
+	collection, err := l.changeCollection(ctx, collectionID, ownerID, func(ctx context.Context, _ entity.Collection) error {
+		return l.collectionRepository.RemoveCollectionItem(ctx, collectionID, bookID)
+	})
+
+	if err != nil {
+		l.logger.Error("cannot remove book from collection", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.RemoveCollectionBookResponse{
+		Collection: convertCollectionToResponse(collection),
+	}, nil
+}
+
+// ReorderCollection expects bookIDs to be a permutation of the books already in the collection.
+func (l *libraryImpl) ReorderCollection(
+	ctx context.Context,
+	collectionID string,
+	ownerID string,
+	bookIDs []string,
+) (*library.ReorderCollectionResponse, error) {
+	collection, err := l.changeCollection(ctx, collectionID, ownerID, func(ctx context.Context, collection entity.Collection) error {
+		current := collection.BookIDs()
+		ordered := slices.Clone(bookIDs)
+		slices.Sort(current)
+		slices.Sort(ordered)
+
+		if !slices.Equal(current, ordered) {
+			return entity.ErrInvalidCollectionOrder
+		}
+
+		return l.collectionRepository.SetCollectionOrder(ctx, collectionID, bookIDs)
+	})
+
+	if err != nil {
+		l.logger.Error("cannot reorder collection", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.ReorderCollectionResponse{
+		Collection: convertCollectionToResponse(collection),
+	}, nil
+}
+
+func (l *libraryImpl) ShareCollection(
+	ctx context.Context,
+	collectionID string,
+	ownerID string,
+	patronID string,
+) (*library.ShareCollectionResponse, error) {
+	collection, err := l.changeCollection(ctx, collectionID, ownerID, func(ctx context.Context, _ entity.Collection) error {
+		return l.collectionRepository.AddCollectionShare(ctx, collectionID, patronID)
+	})
+
+	if err != nil {
+		l.logger.Error("cannot share collection", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.ShareCollectionResponse{
+		Collection: convertCollectionToResponse(collection),
+	}, nil
+}
+
+func (l *libraryImpl) ListCollections(
+	ctx context.Context,
+	filter entity.CollectionFilter,
+	pageSize int,
+	pageToken string,
+	view entity.BookView,
+) (*library.ListCollectionsResponse, error) {
+	cursor, err := decodePageToken(pageToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	limit := getPageSize(pageSize)
+	collections, err := l.collectionRepository.ListCollections(ctx, filter, cursor, limit+1)
+
+	if err != nil {
+		l.logger.Error("cannot list collections", zap.Error(err))
+		return nil, err
+	}
+
+	collections, nextPageToken, err := trimPage(collections, limit, func(collection entity.Collection) entity.PageCursor {
+		return entity.PageCursor{CreatedAt: collection.CreatedAt, ID: collection.ID}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*library.Collection, len(collections))
+	for i, collection := range collections {
+		res[i] = convertCollectionToResponse(collection)
+	}
+
+	if err = l.expandCollectionAuthors(ctx, view, res...); err != nil {
+		return nil, err
+	}
+
+	return &library.ListCollectionsResponse{
+		Collections:   res,
+		NextPageToken: nextPageToken,
+	}, nil
+}
@@ -0,0 +1,192 @@
+package library
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCaseGetCollection(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ownerID := uuid.New().String()
+	friendID := uuid.New().String()
+	collection := entity.Collection{
+		ID:         uuid.New().String(),
+		OwnerID:    ownerID,
+		Visibility: entity.CollectionVisibilityPrivate,
+		SharedWith: []string{friendID},
+		Items: []entity.CollectionItem{
+			{Book: entity.Book{ID: uuid.New().String(), Name: "book"}, Note: "note"},
+		},
+	}
+
+	tests := []struct {
+		testName string
+		viewerID string
+		wantErr  error
+	}{
+		{
+			testName: "owner sees private collection",
+			viewerID: ownerID,
+		},
+		{
+			testName: "patron it was shared with sees private collection",
+			viewerID: friendID,
+		},
+		{
+			testName: "stranger does not see private collection",
+			viewerID: uuid.New().String(),
+			wantErr:  entity.ErrCollectionNotFound,
+		},
+		{
+			testName: "anonymous reader does not see private collection",
+			wantErr:  entity.ErrCollectionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Parallel()
+			data := getUseCaseData(t)
+			data.collectionRepo.EXPECT().GetCollection(ctx, collection.ID).Return(collection, nil)
+
+			resp, err := data.impl.GetCollection(ctx, collection.ID, tt.viewerID, entity.BookViewBasic)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp.GetCollection().GetItems(), 1)
+			require.Equal(t, "book", resp.GetCollection().GetItems()[0].GetBook().GetName())
+			require.Equal(t, "note", resp.GetCollection().GetItems()[0].GetNote())
+		})
+	}
+}
+
+func TestUseCaseAddCollectionBook(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	collection := entity.Collection{
+		ID:      uuid.New().String(),
+		OwnerID: uuid.New().String(),
+	}
+
+	t.Run("book added successfully", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		updated := collection
+		updated.Items = []entity.CollectionItem{{Book: entity.Book{ID: bookID}, Note: "note"}}
+		data.collectionRepo.EXPECT().GetCollectionForUpdate(ctx, collection.ID).Return(collection, nil)
+		data.collectionRepo.EXPECT().AddCollectionItem(ctx, collection.ID, entity.CollectionItem{
+			Book: entity.Book{ID: bookID},
+			Note: "note",
+		}).Return(nil)
+		data.collectionRepo.EXPECT().GetCollection(ctx, collection.ID).Return(updated, nil)
+
+		resp, err := data.impl.AddCollectionBook(ctx, collection.ID, collection.OwnerID, bookID, "note")
+		require.NoError(t, err)
+		require.Equal(t, bookID, resp.GetCollection().GetItems()[0].GetBook().GetId())
+	})
+
+	t.Run("book already in collection", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		current := collection
+		current.Items = []entity.CollectionItem{{Book: entity.Book{ID: bookID}}}
+		data.collectionRepo.EXPECT().GetCollectionForUpdate(ctx, collection.ID).Return(current, nil)
+
+		_, err := data.impl.AddCollectionBook(ctx, collection.ID, collection.OwnerID, bookID, "note")
+		require.ErrorIs(t, err, entity.ErrBookAlreadyInCollection)
+	})
+
+	t.Run("collection of another patron", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		data.collectionRepo.EXPECT().GetCollectionForUpdate(ctx, collection.ID).Return(collection, nil)
+
+		_, err := data.impl.AddCollectionBook(ctx, collection.ID, uuid.New().String(), bookID, "note")
+		require.ErrorIs(t, err, entity.ErrCollectionAccessDenied)
+	})
+}
+
+func TestUseCaseReorderCollection(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	first := uuid.New().String()
+	second := uuid.New().String()
+	collection := entity.Collection{
+		ID:      uuid.New().String(),
+		OwnerID: uuid.New().String(),
+		Items: []entity.CollectionItem{
+			{Book: entity.Book{ID: first}},
+			{Book: entity.Book{ID: second}},
+		},
+	}
+
+	t.Run("collection reordered successfully", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		reordered := collection
+		reordered.Items = []entity.CollectionItem{collection.Items[1], collection.Items[0]}
+		data.collectionRepo.EXPECT().GetCollectionForUpdate(ctx, collection.ID).Return(collection, nil)
+		data.collectionRepo.EXPECT().SetCollectionOrder(ctx, collection.ID, []string{second, first}).Return(nil)
+		data.collectionRepo.EXPECT().GetCollection(ctx, collection.ID).Return(reordered, nil)
+
+		resp, err := data.impl.ReorderCollection(ctx, collection.ID, collection.OwnerID, []string{second, first})
+		require.NoError(t, err)
+		require.Equal(t, second, resp.GetCollection().GetItems()[0].GetBook().GetId())
+	})
+
+	t.Run("order misses a book", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		data.collectionRepo.EXPECT().GetCollectionForUpdate(ctx, collection.ID).Return(collection, nil)
+
+		_, err := data.impl.ReorderCollection(ctx, collection.ID, collection.OwnerID, []string{second})
+		require.ErrorIs(t, err, entity.ErrInvalidCollectionOrder)
+	})
+
+	t.Run("order contains unknown book", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		data.collectionRepo.EXPECT().GetCollectionForUpdate(ctx, collection.ID).Return(collection, nil)
+
+		_, err := data.impl.ReorderCollection(ctx, collection.ID, collection.OwnerID, []string{second, uuid.New().String()})
+		require.ErrorIs(t, err, entity.ErrInvalidCollectionOrder)
+	})
+}
+
+func TestUseCaseListCollections(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	filter := entity.CollectionFilter{ViewerID: uuid.New().String()}
+	now := time.Now().UTC()
+	collections := []entity.Collection{
+		{ID: uuid.New().String(), CreatedAt: now},
+		{ID: uuid.New().String(), CreatedAt: now.Add(time.Second)},
+	}
+
+	data := getUseCaseData(t)
+	data.collectionRepo.EXPECT().ListCollections(ctx, filter, (*entity.PageCursor)(nil), 2).Return(collections, nil)
+
+	resp, err := data.impl.ListCollections(ctx, filter, 1, "", entity.BookViewBasic)
+	require.NoError(t, err)
+	require.Len(t, resp.GetCollections(), 1)
+
+	cursor, err := decodePageToken(resp.GetNextPageToken())
+	require.NoError(t, err)
+	require.Equal(t, collections[0].ID, cursor.ID)
+}
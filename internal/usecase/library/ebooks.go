@@ -0,0 +1,159 @@
+package library
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/catalog"
+	"github.com/project/library/internal/usecase/media"
+	"go.uber.org/zap"
+)
+
+const ebookCoverName = "cover"
+
+var ebookExtensions = map[string]string{
+	catalog.EpubContentType: ".epub",
+	catalog.PdfContentType:  ".pdf",
+}
+
+// IngestEbook reads the metadata of an EPUB or PDF file and registers the book with its authors,
+// authors are matched by name and registered when missing. The file and the cover found in it are
+// stored as attachments of the new book. A dry run only returns what would be registered.
+//
+// Only the title and the authors are stored with the book, the rest of the metadata is returned
+// for reference. When storing the file fails the book is kept, the file can be uploaded again.
+func (l *libraryImpl) IngestEbook(
+	ctx context.Context,
+	name string,
+	dryRun bool,
+	data io.Reader,
+) (*library.IngestEbookResponse, error) {
+	upload, err := l.spool(data, maxAttachmentSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer upload.close()
+
+	if upload.contentType != catalog.EpubContentType && upload.contentType != catalog.PdfContentType {
+		return nil, fmt.Errorf("%s: %w", upload.contentType, entity.ErrUnsupportedContentType)
+	}
+
+	metadata, err := catalog.ReadEbook(upload.contentType, upload.file, upload.size)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Title == "" && name != "" {
+		base := path.Base(name)
+		metadata.Title = strings.TrimSpace(strings.TrimSuffix(base, path.Ext(base)))
+	}
+
+	if metadata.Title == "" {
+		return nil, fmt.Errorf("%w: no title in metadata or file name", entity.ErrInvalidEbook)
+	}
+
+	authorNames, skipped := catalog.EbookAuthors(metadata.Creators)
+	authorIDs, missing, err := l.resolveAuthors(ctx, []entity.CatalogRow{{AuthorNames: authorNames}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := &library.IngestEbookResponse{
+		Metadata:        convertEbookMetadataToResponse(metadata),
+		Authors:         make([]*library.EbookAuthor, len(authorNames)),
+		SkippedCreators: skipped,
+		DryRun:          dryRun,
+	}
+
+	if !dryRun {
+		for _, authorName := range missing {
+			author, err := l.RegisterAuthor(ctx, authorName, entity.IdempotencyKey{})
+
+			if err != nil {
+				return nil, err
+			}
+
+			authorIDs[authorName] = author.GetId()
+		}
+	}
+
+	ids := make([]string, len(authorNames))
+	for i, authorName := range authorNames {
+		ids[i] = authorIDs[authorName]
+		response.Authors[i] = &library.EbookAuthor{
+			Id:      authorIDs[authorName],
+			Name:    authorName,
+			Created: slices.Contains(missing, authorName),
+		}
+	}
+
+	if dryRun {
+		return response, nil
+	}
+
+	book, err := l.RegisterBook(ctx, metadata.Title, ids, nil, entity.IdempotencyKey{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response.Book = book.GetBook()
+
+	if name == "" {
+		name = metadata.Title + ebookExtensions[upload.contentType]
+	}
+
+	file, err := l.UploadAttachment(ctx, response.GetBook().GetId(), entity.AttachmentKindFile, name, upload.reader())
+
+	if err != nil {
+		return nil, err
+	}
+
+	response.File = file.GetAttachment()
+
+	// A cover in a format covers do not accept is not an error, the book is usable without it.
+	if metadata.Cover != nil && media.Allowed(entity.AttachmentKindCover, media.DetectContentType(metadata.Cover)) {
+		cover, err := l.UploadAttachment(ctx, response.GetBook().GetId(), entity.AttachmentKindCover, ebookCoverName, bytes.NewReader(metadata.Cover))
+
+		if err != nil {
+			l.logger.Error("cannot store e-book cover", zap.String("book_id", response.GetBook().GetId()), zap.Error(err))
+		} else {
+			response.Cover = cover.GetAttachment()
+		}
+	}
+
+	return response, nil
+}
+
+func convertEbookMetadataToResponse(metadata entity.EbookMetadata) *library.EbookMetadata {
+	creators := make([]*library.EbookCreator, len(metadata.Creators))
+	for i, creator := range metadata.Creators {
+		creators[i] = &library.EbookCreator{
+			Name: creator.Name,
+			Role: creator.Role,
+		}
+	}
+
+	return &library.EbookMetadata{
+		ContentType:     metadata.ContentType,
+		Title:           metadata.Title,
+		Creators:        creators,
+		Language:        metadata.Language,
+		Identifiers:     metadata.Identifiers,
+		Isbn:            metadata.ISBN,
+		Publisher:       metadata.Publisher,
+		PublicationYear: int32(metadata.PublicationYear),
+		HasCover:        metadata.Cover != nil,
+	}
+}
@@ -0,0 +1,136 @@
+package library
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/repository"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseIngestEbook(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	pdf, err := os.ReadFile("../catalog/testdata/book.pdf")
+	require.NoError(t, err)
+	epub, err := os.ReadFile("../catalog/testdata/book.epub")
+	require.NoError(t, err)
+
+	t.Run("dry run", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		author := entity.Author{ID: uuid.NewString(), Name: "Terry Pratchett"}
+
+		data.catalogRepo.EXPECT().GetAuthorsByNames(ctx, []string{"Terry Pratchett", "Neil Gaiman"}).
+			Return([]entity.Author{author}, nil)
+
+		resp, err := data.impl.IngestEbook(ctx, "good-omens.pdf", true, bytes.NewReader(pdf))
+		require.NoError(t, err)
+		require.True(t, resp.GetDryRun())
+		require.Equal(t, "Good Omens: the (nice) and accurate prophecies", resp.GetMetadata().GetTitle())
+		require.Equal(t, []*library.EbookAuthor{
+			{Id: author.ID, Name: "Terry Pratchett"},
+			{Name: "Neil Gaiman", Created: true},
+		}, resp.GetAuthors())
+		require.Nil(t, resp.GetBook())
+	})
+
+	t.Run("book with cover", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		authorID := uuid.NewString()
+		bookID := uuid.NewString()
+
+		data.catalogRepo.EXPECT().GetAuthorsByNames(ctx, []string{"Frank Herbert"}).Return(nil, nil)
+		data.transactor.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, x func(context.Context) error) error {
+			return x(ctx)
+		}).Times(4)
+		data.authorRepository.EXPECT().CreateAuthor(ctx, entity.Author{Name: "Frank Herbert"}).
+			Return(entity.Author{ID: authorID, Name: "Frank Herbert"}, nil)
+		data.bookRepository.EXPECT().CreateBook(ctx, entity.Book{
+			Name:         "Dune",
+			AuthorIDs:    []string{authorID},
+			Contributors: []entity.Contributor{{AuthorID: authorID, Role: entity.ContributorRoleAuthor}},
+		}).
+			Return(entity.Book{ID: bookID, Name: "Dune", AuthorIDs: []string{authorID}}, nil)
+		data.outboxRepository.EXPECT().SendMessage(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		data.bookRepository.EXPECT().GetBook(ctx, bookID).Return(entity.Book{ID: bookID}, nil).Times(2)
+		data.blobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), int64(len(epub)), "application/epub+zip").Return(nil)
+		data.blobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "image/png").Return(nil)
+		data.blobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "image/jpeg").Return(nil)
+		data.attachmentRepo.EXPECT().DeleteCover(ctx, bookID).Return(nil)
+		data.attachmentRepo.EXPECT().CreateAttachment(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, attachment entity.Attachment) (entity.Attachment, error) {
+				return attachment, nil
+			}).Times(2)
+
+		resp, err := data.impl.IngestEbook(ctx, "", false, bytes.NewReader(epub))
+		require.NoError(t, err)
+		require.Equal(t, []*library.EbookAuthor{{Id: authorID, Name: "Frank Herbert", Created: true}}, resp.GetAuthors())
+		require.Equal(t, []string{"John Smith", "Stanisław Lem"}, resp.GetSkippedCreators())
+		require.Equal(t, bookID, resp.GetBook().GetId())
+		require.Equal(t, "Dune.epub", resp.GetFile().GetName())
+		require.Equal(t, library.AttachmentKind_ATTACHMENT_KIND_COVER, resp.GetCover().GetKind())
+		require.True(t, resp.GetMetadata().GetHasCover())
+		require.Equal(t, "9780441172719", resp.GetMetadata().GetIsbn())
+	})
+
+	t.Run("author registration fails", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.catalogRepo.EXPECT().GetAuthorsByNames(ctx, []string{"Frank Herbert"}).Return(nil, nil)
+		prepareTransactor(ctx, data)
+		data.authorRepository.EXPECT().CreateAuthor(ctx, gomock.Any()).Return(entity.Author{}, nil)
+		data.outboxRepository.EXPECT().SendMessage(ctx, gomock.Any(), repository.OutboxKindAuthor, gomock.Any()).
+			Return(entity.ErrAuthorNotFound)
+
+		_, err := data.impl.IngestEbook(ctx, "dune.epub", false, bytes.NewReader(epub))
+		require.ErrorIs(t, err, entity.ErrAuthorNotFound)
+	})
+
+	tests := []struct {
+		name    string
+		file    string
+		data    string
+		wantErr error
+	}{
+		{
+			name:    "plain text",
+			file:    "notes.txt",
+			data:    "Dune by Frank Herbert",
+			wantErr: entity.ErrUnsupportedContentType,
+		},
+		{
+			name:    "broken epub",
+			file:    "dune.epub",
+			data:    string(epub[:200]),
+			wantErr: entity.ErrInvalidEbook,
+		},
+		{
+			name:    "no title",
+			file:    "",
+			data:    "%PDF-1.7\n%%EOF\n",
+			wantErr: entity.ErrInvalidEbook,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := getUseCaseData(t)
+
+			_, err := data.impl.IngestEbook(ctx, tt.file, true, strings.NewReader(tt.data))
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
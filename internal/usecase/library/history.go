@@ -0,0 +1,42 @@
+package library
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.uber.org/zap"
+)
+
+func (l *libraryImpl) GetBookHistory(ctx context.Context, bookID string, view entity.BookView) (*library.GetBookHistoryResponse, error) {
+	versions, err := l.historyRepository.GetBookHistory(ctx, bookID)
+
+	if err != nil {
+		l.logger.Error("cannot get book history", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]*library.BookVersion, len(versions))
+	books := make([]*library.Book, len(versions))
+	for i, version := range versions {
+		books[i] = convertBookToResponse(version.Book)
+		res[i] = &library.BookVersion{
+			Book:      books[i],
+			ValidFrom: timestamppb.New(version.ValidFrom),
+		}
+
+		if !version.ValidTo.IsZero() {
+			res[i].ValidTo = timestamppb.New(version.ValidTo)
+		}
+	}
+
+	if err = l.expandAuthors(ctx, view, books...); err != nil {
+		return nil, err
+	}
+
+	return &library.GetBookHistoryResponse{
+		Versions: res,
+	}, nil
+}
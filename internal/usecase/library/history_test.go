@@ -0,0 +1,102 @@
+package library
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCaseGetBookHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	author := entity.Author{ID: uuid.NewString(), Name: "Frank Herbert"}
+	created := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	renamed := created.Add(time.Hour)
+	book := entity.Book{
+		ID:           uuid.NewString(),
+		Name:         "Dune World",
+		AuthorIDs:    []string{author.ID},
+		Contributors: []entity.Contributor{{AuthorID: author.ID, Role: entity.ContributorRoleAuthor}},
+	}
+	current := book
+	current.Name = "Dune"
+	versions := []entity.BookVersion{
+		{Book: book, ValidFrom: created, ValidTo: renamed},
+		{Book: current, ValidFrom: renamed},
+	}
+
+	t.Run("lists versions with author names", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.historyRepo.EXPECT().GetBookHistory(ctx, book.ID).Return(versions, nil)
+		data.authorRepository.EXPECT().GetAuthors(ctx, []string{author.ID}).Return([]entity.Author{author}, nil)
+
+		resp, err := data.impl.GetBookHistory(ctx, book.ID, entity.BookViewFull)
+		require.NoError(t, err)
+		require.Len(t, resp.GetVersions(), 2)
+
+		require.Equal(t, "Dune World", resp.GetVersions()[0].GetBook().GetName())
+		require.True(t, created.Equal(resp.GetVersions()[0].GetValidFrom().AsTime()))
+		require.True(t, renamed.Equal(resp.GetVersions()[0].GetValidTo().AsTime()))
+		require.Equal(t, author.Name, resp.GetVersions()[0].GetBook().GetAuthors()[0].GetName())
+
+		require.Equal(t, "Dune", resp.GetVersions()[1].GetBook().GetName())
+		require.Nil(t, resp.GetVersions()[1].GetValidTo())
+		require.Equal(t, author.Name, resp.GetVersions()[1].GetBook().GetAuthors()[0].GetName())
+	})
+
+	t.Run("book not found", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.historyRepo.EXPECT().GetBookHistory(ctx, book.ID).Return(nil, entity.ErrBookNotFound)
+
+		_, err := data.impl.GetBookHistory(ctx, book.ID, entity.BookViewBasic)
+		require.ErrorIs(t, err, entity.ErrBookNotFound)
+	})
+}
+
+func TestUseCaseGetAsOf(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	asOf := time.Date(2026, time.January, 1, 0, 30, 0, 0, time.UTC)
+	author := entity.Author{ID: uuid.NewString(), Name: "Frank Herbert"}
+	book := entity.Book{
+		ID:     uuid.NewString(),
+		Name:   "Dune World",
+		WorkID: uuid.NewString(),
+	}
+
+	t.Run("book as of a moment", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.historyRepo.EXPECT().GetBookAsOf(ctx, book.ID, asOf).Return(book, nil)
+
+		resp, err := data.impl.GetBook(ctx, book.ID, true, entity.BookViewBasic, asOf, false)
+		require.NoError(t, err)
+		require.Equal(t, "Dune World", resp.GetBook().GetName())
+		require.Empty(t, resp.GetEditions())
+	})
+
+	t.Run("book did not exist yet", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.historyRepo.EXPECT().GetBookAsOf(ctx, book.ID, asOf).Return(entity.Book{}, entity.ErrBookNotFound)
+
+		_, err := data.impl.GetBook(ctx, book.ID, false, entity.BookViewBasic, asOf, false)
+		require.ErrorIs(t, err, entity.ErrBookNotFound)
+	})
+
+	t.Run("author as of a moment", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.historyRepo.EXPECT().GetAuthorAsOf(ctx, author.ID, asOf).Return(author, nil)
+
+		resp, err := data.impl.GetAuthor(ctx, author.ID, asOf, false)
+		require.NoError(t, err)
+		require.Equal(t, author.Name, resp.GetName())
+	})
+}
@@ -0,0 +1,63 @@
+package library
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/protobuf/proto"
+
+	"go.uber.org/zap"
+)
+
+// idempotent runs create in a transaction and stores the response it fills under the key.
+// A retry with the same key and request gets the stored response in place of running create again,
+// a request without a key is always created.
+func (l *libraryImpl) idempotent(
+	ctx context.Context,
+	key entity.IdempotencyKey,
+	response proto.Message,
+	create func(ctx context.Context) error,
+) error {
+	return l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if key.Key == "" {
+			return create(ctx)
+		}
+
+		record, err := l.idempotencyRepository.GetIdempotencyRecord(ctx, key)
+
+		switch {
+		case err == nil && record.RequestHash != key.RequestHash:
+			return fmt.Errorf("%w: %s", entity.ErrIdempotencyKeyReused, key.Key)
+		case err == nil:
+			return proto.Unmarshal(record.Response, response)
+		case !errors.Is(err, entity.ErrIdempotencyRecordNotFound):
+			l.logger.Error("cannot get idempotency record", zap.Error(err))
+			return err
+		}
+
+		if err = create(ctx); err != nil {
+			return err
+		}
+
+		serialized, err := proto.Marshal(response)
+
+		if err != nil {
+			l.logger.Error("cannot serialize response", zap.Error(err))
+			return err
+		}
+
+		err = l.idempotencyRepository.SaveIdempotencyRecord(ctx, entity.IdempotencyRecord{
+			IdempotencyKey: key,
+			Response:       serialized,
+		}, l.idempotencyTTL)
+
+		if err != nil {
+			l.logger.Error("cannot save idempotency record", zap.Error(err))
+			return err
+		}
+
+		return nil
+	})
+}
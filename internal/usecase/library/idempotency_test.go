@@ -0,0 +1,135 @@
+package library
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUseCaseRegisterAuthorIdempotency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	author := entity.Author{ID: uuid.NewString(), Name: "Terry Pratchett"}
+	key := entity.IdempotencyKey{Key: "retry-1", Method: "RegisterAuthor", RequestHash: "hash"}
+	stored, err := proto.Marshal(&library.RegisterAuthorResponse{Id: author.ID})
+	require.NoError(t, err)
+
+	withTx := func(data *useCaseData) {
+		data.transactor.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, x func(context.Context) error) error {
+			return x(ctx)
+		})
+	}
+
+	t.Run("first request stores the response", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		withTx(data)
+
+		data.idempotencyRepo.EXPECT().GetIdempotencyRecord(ctx, key).Return(entity.IdempotencyRecord{}, entity.ErrIdempotencyRecordNotFound)
+		data.authorRepository.EXPECT().CreateAuthor(ctx, entity.Author{Name: author.Name}).Return(author, nil)
+		data.outboxRepository.EXPECT().SendMessage(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		data.idempotencyRepo.EXPECT().SaveIdempotencyRecord(ctx, entity.IdempotencyRecord{
+			IdempotencyKey: key,
+			Response:       stored,
+		}, testIdempotencyTTL).Return(nil)
+
+		resp, err := data.impl.RegisterAuthor(ctx, author.Name, key)
+		require.NoError(t, err)
+		require.Equal(t, author.ID, resp.GetId())
+	})
+
+	t.Run("retry gets the stored response", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		withTx(data)
+
+		data.idempotencyRepo.EXPECT().GetIdempotencyRecord(ctx, key).Return(entity.IdempotencyRecord{
+			IdempotencyKey: key,
+			Response:       stored,
+		}, nil)
+
+		resp, err := data.impl.RegisterAuthor(ctx, author.Name, key)
+		require.NoError(t, err)
+		require.Equal(t, author.ID, resp.GetId())
+	})
+
+	t.Run("key reused for another request", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		withTx(data)
+
+		data.idempotencyRepo.EXPECT().GetIdempotencyRecord(ctx, key).Return(entity.IdempotencyRecord{
+			IdempotencyKey: entity.IdempotencyKey{Key: key.Key, Method: key.Method, RequestHash: "other"},
+			Response:       stored,
+		}, nil)
+
+		_, err := data.impl.RegisterAuthor(ctx, author.Name, key)
+		require.ErrorIs(t, err, entity.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("failed create stores nothing", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		withTx(data)
+
+		data.idempotencyRepo.EXPECT().GetIdempotencyRecord(ctx, key).Return(entity.IdempotencyRecord{}, entity.ErrIdempotencyRecordNotFound)
+		data.authorRepository.EXPECT().CreateAuthor(ctx, gomock.Any()).Return(entity.Author{}, errors.New("connection refused"))
+
+		_, err := data.impl.RegisterAuthor(ctx, author.Name, key)
+		require.Error(t, err)
+	})
+}
+
+func TestUseCaseRegisterBookIdempotency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	book := entity.Book{ID: uuid.NewString(), Name: "Mort"}
+	key := entity.IdempotencyKey{Key: "retry-1", Method: "AddBook", RequestHash: "hash"}
+	stored, err := proto.Marshal(&library.AddBookResponse{Book: convertBookToResponse(book)})
+	require.NoError(t, err)
+
+	data := getUseCaseData(t)
+	data.transactor.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, x func(context.Context) error) error {
+		return x(ctx)
+	})
+	data.idempotencyRepo.EXPECT().GetIdempotencyRecord(ctx, key).Return(entity.IdempotencyRecord{
+		IdempotencyKey: key,
+		Response:       stored,
+	}, nil)
+
+	resp, err := data.impl.RegisterBook(ctx, book.Name, nil, nil, key)
+	require.NoError(t, err)
+	require.Equal(t, book.ID, resp.GetBook().GetId())
+}
+
+func TestUseCaseAddReviewIdempotency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	review := entity.Review{ID: uuid.NewString(), BookID: uuid.NewString(), PatronID: uuid.NewString(), Rating: 4}
+	key := entity.IdempotencyKey{Key: "retry-1", Actor: "alice", Method: "AddReview", RequestHash: "hash"}
+	stored, err := proto.Marshal(&library.AddReviewResponse{Review: convertReviewToResponse(review)})
+	require.NoError(t, err)
+
+	data := getUseCaseData(t)
+	data.transactor.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, x func(context.Context) error) error {
+		return x(ctx)
+	})
+	data.idempotencyRepo.EXPECT().GetIdempotencyRecord(ctx, key).Return(entity.IdempotencyRecord{
+		IdempotencyKey: key,
+		Response:       stored,
+	}, nil)
+
+	resp, err := data.impl.AddReview(ctx, review.PatronID, review.BookID, review.Rating, "", key)
+	require.NoError(t, err)
+	require.Equal(t, review.ID, resp.GetReview().GetId())
+}
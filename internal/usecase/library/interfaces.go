@@ -2,8 +2,11 @@ package library
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
 
 	"github.com/project/library/internal/usecase/repository"
 	"go.uber.org/zap"
@@ -12,35 +15,236 @@ import (
 //go:generate ../../../bin/mockgen -source=interfaces.go -destination=mocks/usecase_mock.go -package=mocks
 
 type AuthorUseCase interface {
-	RegisterAuthor(ctx context.Context, authorName string) (*library.RegisterAuthorResponse, error)
-	GetAuthor(ctx context.Context, authorID string) (*library.GetAuthorInfoResponse, error)
+	RegisterAuthor(ctx context.Context, authorName string, idempotencyKey entity.IdempotencyKey) (*library.RegisterAuthorResponse, error)
+	GetAuthor(ctx context.Context, authorID string, asOf time.Time, includeDeleted bool) (*library.GetAuthorInfoResponse, error)
 	ChangeAuthorInfo(ctx context.Context, authorID string, newName string) error
+	RestoreAuthor(ctx context.Context, authorID string) error
+	GetCoAuthors(ctx context.Context, authorID string) (*library.GetCoAuthorsResponse, error)
+	GetCollaborationPath(ctx context.Context, fromAuthorID string, toAuthorID string, maxDepth int) (*library.GetCollaborationPathResponse, error)
+	BatchGetAuthors(ctx context.Context, ids []string) (*library.BatchGetAuthorsResponse, error)
 }
 
 type BookUseCase interface {
-	RegisterBook(ctx context.Context, name string, authorIDs []string) (*library.AddBookResponse, error)
-	GetBook(ctx context.Context, bookID string) (*library.GetBookInfoResponse, error)
-	ChangeBookInfo(ctx context.Context, bookID string, name string, authorIDs []string) error
-	GetBooksByAuthor(ctx context.Context, authorID string) ([]*library.Book, error)
+	RegisterBook(
+		ctx context.Context,
+		name string,
+		authorIDs []string,
+		contributors []entity.Contributor,
+		idempotencyKey entity.IdempotencyKey,
+	) (*library.AddBookResponse, error)
+	GetBook(
+		ctx context.Context,
+		bookID string,
+		includeEditions bool,
+		view entity.BookView,
+		asOf time.Time,
+		includeDeleted bool,
+	) (*library.GetBookInfoResponse, error)
+	RestoreBook(ctx context.Context, bookID string) error
+	GetBookHistory(ctx context.Context, bookID string, view entity.BookView) (*library.GetBookHistoryResponse, error)
+	ChangeBookInfo(
+		ctx context.Context,
+		bookID string,
+		name string,
+		authorIDs []string,
+		contributors []entity.Contributor,
+	) error
+	GetBooksByAuthor(
+		ctx context.Context,
+		authorID string,
+		role entity.ContributorRole,
+		view entity.BookView,
+	) ([]*library.Book, error)
+	CreateWork(
+		ctx context.Context,
+		name string,
+		bookIDs []string,
+		idempotencyKey entity.IdempotencyKey,
+	) (*library.CreateWorkResponse, error)
+	GetWork(ctx context.Context, workID string, view entity.BookView) (*library.GetWorkResponse, error)
+	SetBookEdition(ctx context.Context, bookID string, edition entity.Edition) (*library.SetBookEditionResponse, error)
+	BatchGetBooks(ctx context.Context, ids []string, view entity.BookView) (*library.BatchGetBooksResponse, error)
+	BatchWrite(ctx context.Context, operations []entity.BatchOperation) (*library.BatchWriteResponse, error)
+}
+
+type NotificationUseCase interface {
+	ScheduleDueDateReminders(ctx context.Context, patronID string, bookID string, dueAt time.Time) (*library.ScheduleDueDateRemindersResponse, error)
+	NotifyHoldAvailable(ctx context.Context, patronID string, bookID string) (*library.NotifyHoldAvailableResponse, error)
+	SetNotificationPreferences(ctx context.Context, preferences entity.NotificationPreferences) error
+	GetNotificationPreferences(ctx context.Context, patronID string) (*library.GetNotificationPreferencesResponse, error)
+}
+
+type BranchUseCase interface {
+	CreateBranch(ctx context.Context, name string, address string, idempotencyKey entity.IdempotencyKey) (*library.CreateBranchResponse, error)
+	GetBranch(ctx context.Context, branchID string) (*library.GetBranchInfoResponse, error)
+	RegisterPatron(
+		ctx context.Context,
+		name string,
+		homeBranchID string,
+		idempotencyKey entity.IdempotencyKey,
+	) (*library.RegisterPatronResponse, error)
+	GetPatron(ctx context.Context, patronID string) (*library.GetPatronInfoResponse, error)
+	AddBookCopy(ctx context.Context, bookID string, branchID string, idempotencyKey entity.IdempotencyKey) (*library.AddBookCopyResponse, error)
+	RequestTransfer(ctx context.Context, copyID string, toBranchID string) (*library.RequestTransferResponse, error)
+	ReceiveTransfer(ctx context.Context, transferID string) (*library.ReceiveTransferResponse, error)
+	GetBookAvailability(ctx context.Context, bookID string, branchID string) (*library.GetBookAvailabilityResponse, error)
+	CheckoutCopy(ctx context.Context, copyID string, patronID string) (*library.CheckoutCopyResponse, error)
+	ReturnCopy(ctx context.Context, copyID string) (*library.ReturnCopyResponse, error)
+}
+
+type ReviewUseCase interface {
+	AddReview(
+		ctx context.Context,
+		patronID string,
+		bookID string,
+		rating int,
+		text string,
+		idempotencyKey entity.IdempotencyKey,
+	) (*library.AddReviewResponse, error)
+	ModerateReview(ctx context.Context, reviewID string, status entity.ReviewStatus) (*library.ModerateReviewResponse, error)
+	ListReviews(ctx context.Context, filter entity.ReviewFilter, pageSize int, pageToken string) (*library.ListReviewsResponse, error)
+}
+
+type CollectionUseCase interface {
+	CreateCollection(
+		ctx context.Context,
+		collection entity.Collection,
+		idempotencyKey entity.IdempotencyKey,
+	) (*library.CreateCollectionResponse, error)
+	GetCollection(
+		ctx context.Context,
+		collectionID string,
+		viewerID string,
+		view entity.BookView,
+	) (*library.GetCollectionResponse, error)
+	AddCollectionBook(ctx context.Context, collectionID string, ownerID string, bookID string, note string) (*library.AddCollectionBookResponse, error)
+	RemoveCollectionBook(ctx context.Context, collectionID string, ownerID string, bookID string) (*library.RemoveCollectionBookResponse, error)
+	ReorderCollection(ctx context.Context, collectionID string, ownerID string, bookIDs []string) (*library.ReorderCollectionResponse, error)
+	ShareCollection(ctx context.Context, collectionID string, ownerID string, patronID string) (*library.ShareCollectionResponse, error)
+	ListCollections(
+		ctx context.Context,
+		filter entity.CollectionFilter,
+		pageSize int,
+		pageToken string,
+		view entity.BookView,
+	) (*library.ListCollectionsResponse, error)
+}
+
+type RecommendationUseCase interface {
+	SetBookSubjects(ctx context.Context, bookID string, subjects []string) error
+	RecommendBooks(ctx context.Context, bookID string, limit int, view entity.BookView) (*library.RecommendBooksResponse, error)
+}
+
+type CatalogUseCase interface {
+	ImportCatalog(ctx context.Context, format entity.CatalogFormat, dryRun bool, data io.Reader) (*library.ImportCatalogResponse, error)
+	ExportMarc(ctx context.Context, format entity.CatalogFormat, bookIDs []string, w io.Writer) error
+	ExportCatalog(ctx context.Context, format entity.CatalogFormat, filter entity.CatalogFilter, w io.Writer) error
+	WatchCatalog(
+		ctx context.Context,
+		afterSequence int64,
+		entities []entity.CatalogEntity,
+		view entity.BookView,
+		send func(*library.WatchCatalogResponse) error,
+	) error
+}
+
+type AttachmentUseCase interface {
+	UploadAttachment(ctx context.Context, bookID string, kind entity.AttachmentKind, name string, data io.Reader) (*library.UploadAttachmentResponse, error)
+	DownloadAttachment(ctx context.Context, attachmentID string, thumbnail bool) (*library.Attachment, io.ReadCloser, error)
+	ListAttachments(ctx context.Context, bookID string) (*library.ListAttachmentsResponse, error)
+	DeleteAttachment(ctx context.Context, attachmentID string) error
+	IngestEbook(ctx context.Context, name string, dryRun bool, data io.Reader) (*library.IngestEbookResponse, error)
+}
+
+type AuditUseCase interface {
+	ListAuditEvents(ctx context.Context, filter entity.AuditFilter, pageSize int, pageToken string) (*library.ListAuditEventsResponse, error)
 }
 
 var _ AuthorUseCase = (*libraryImpl)(nil)
 var _ BookUseCase = (*libraryImpl)(nil)
+var _ NotificationUseCase = (*libraryImpl)(nil)
+var _ BranchUseCase = (*libraryImpl)(nil)
+var _ ReviewUseCase = (*libraryImpl)(nil)
+var _ CollectionUseCase = (*libraryImpl)(nil)
+var _ RecommendationUseCase = (*libraryImpl)(nil)
+var _ CatalogUseCase = (*libraryImpl)(nil)
+var _ AttachmentUseCase = (*libraryImpl)(nil)
+var _ AuditUseCase = (*libraryImpl)(nil)
 
 type libraryImpl struct {
-	logger           *zap.Logger
-	authorRepository repository.AuthorRepository
-	bookRepository   repository.BookRepository
-	outboxRepository repository.OutboxRepository
-	transactor       repository.Transactor
+	logger                   *zap.Logger
+	authorRepository         repository.AuthorRepository
+	bookRepository           repository.BookRepository
+	outboxRepository         repository.OutboxRepository
+	notificationRepository   repository.NotificationRepository
+	branchRepository         repository.BranchRepository
+	reviewRepository         repository.ReviewRepository
+	collectionRepository     repository.CollectionRepository
+	recommendationRepository repository.RecommendationRepository
+	catalogRepository        repository.CatalogRepository
+	attachmentRepository     repository.AttachmentRepository
+	blobStore                repository.BlobStore
+	workRepository           repository.WorkRepository
+	idempotencyRepository    repository.IdempotencyRepository
+	changeRepository         repository.ChangeRepository
+	auditRepository          repository.AuditRepository
+	historyRepository        repository.HistoryRepository
+	trashRepository          repository.TrashRepository
+	transactor               repository.Transactor
+	daysBeforeDue            int
+	maxBatchIDs              int
+	idempotencyTTL           time.Duration
+	watchPollInterval        time.Duration
 }
 
-func New(logger *zap.Logger, authorRepository repository.AuthorRepository, bookRepository repository.BookRepository, outboxRepository repository.OutboxRepository, transactor repository.Transactor) *libraryImpl {
+func New(
+	logger *zap.Logger,
+	authorRepository repository.AuthorRepository,
+	bookRepository repository.BookRepository,
+	outboxRepository repository.OutboxRepository,
+	notificationRepository repository.NotificationRepository,
+	branchRepository repository.BranchRepository,
+	reviewRepository repository.ReviewRepository,
+	collectionRepository repository.CollectionRepository,
+	recommendationRepository repository.RecommendationRepository,
+	catalogRepository repository.CatalogRepository,
+	attachmentRepository repository.AttachmentRepository,
+	blobStore repository.BlobStore,
+	workRepository repository.WorkRepository,
+	idempotencyRepository repository.IdempotencyRepository,
+	changeRepository repository.ChangeRepository,
+	auditRepository repository.AuditRepository,
+	historyRepository repository.HistoryRepository,
+	trashRepository repository.TrashRepository,
+	transactor repository.Transactor,
+	daysBeforeDue int,
+	maxBatchIDs int,
+	idempotencyTTL time.Duration,
+	watchPollInterval time.Duration,
+) *libraryImpl {
 	return &libraryImpl{
-		logger:           logger,
-		authorRepository: authorRepository,
-		bookRepository:   bookRepository,
-		outboxRepository: outboxRepository,
-		transactor:       transactor,
+		logger:                   logger,
+		authorRepository:         authorRepository,
+		bookRepository:           bookRepository,
+		outboxRepository:         outboxRepository,
+		notificationRepository:   notificationRepository,
+		branchRepository:         branchRepository,
+		reviewRepository:         reviewRepository,
+		collectionRepository:     collectionRepository,
+		recommendationRepository: recommendationRepository,
+		catalogRepository:        catalogRepository,
+		attachmentRepository:     attachmentRepository,
+		blobStore:                blobStore,
+		workRepository:           workRepository,
+		idempotencyRepository:    idempotencyRepository,
+		changeRepository:         changeRepository,
+		auditRepository:          auditRepository,
+		historyRepository:        historyRepository,
+		trashRepository:          trashRepository,
+		transactor:               transactor,
+		daysBeforeDue:            daysBeforeDue,
+		maxBatchIDs:              maxBatchIDs,
+		idempotencyTTL:           idempotencyTTL,
+		watchPollInterval:        watchPollInterval,
 	}
 }
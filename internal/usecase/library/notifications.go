@@ -0,0 +1,137 @@
+package library
+
+import (
+	"context"
+	"time"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/project/library/internal/entity"
+
+	"go.uber.org/zap"
+)
+
+func convertNotificationToResponse(notification entity.Notification) *library.Notification {
+	return &library.Notification{
+		Id:       notification.ID,
+		PatronId: notification.PatronID,
+		BookId:   notification.BookID,
+		Kind:     library.NotificationKind(notification.Kind),
+		SendAt:   timestamppb.New(notification.SendAt),
+	}
+}
+
+func convertPreferencesToResponse(preferences entity.NotificationPreferences) *library.NotificationPreferences {
+	channels := make([]library.NotificationChannel, len(preferences.Channels))
+	for i, channel := range preferences.Channels {
+		channels[i] = library.NotificationChannel(channel)
+	}
+
+	return &library.NotificationPreferences{
+		PatronId:   preferences.PatronID,
+		Channels:   channels,
+		Email:      preferences.Email,
+		WebhookUrl: preferences.WebhookURL,
+	}
+}
+
+func (l *libraryImpl) ScheduleDueDateReminders(
+	ctx context.Context,
+	patronID string,
+	bookID string,
+	dueAt time.Time,
+) (*library.ScheduleDueDateRemindersResponse, error) {
+	notifications := make([]entity.Notification, 0, 2)
+
+	if l.daysBeforeDue > 0 {
+		sendAt := dueAt.AddDate(0, 0, -l.daysBeforeDue)
+
+		if now := time.Now(); sendAt.Before(now) {
+			sendAt = now
+		}
+
+		notifications = append(notifications, entity.Notification{
+			PatronID: patronID,
+			BookID:   bookID,
+			Kind:     entity.NotificationKindDueSoon,
+			DueAt:    dueAt,
+			SendAt:   sendAt,
+		})
+	}
+
+	notifications = append(notifications, entity.Notification{
+		PatronID: patronID,
+		BookID:   bookID,
+		Kind:     entity.NotificationKindOverdue,
+		DueAt:    dueAt,
+		SendAt:   dueAt,
+	})
+
+	scheduled, err := l.notificationRepository.ScheduleNotifications(ctx, notifications)
+
+	if err != nil {
+		l.logger.Error("cannot schedule due date reminders", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]*library.Notification, len(scheduled))
+	for i, notification := range scheduled {
+		res[i] = convertNotificationToResponse(notification)
+	}
+
+	return &library.ScheduleDueDateRemindersResponse{
+		Notifications: res,
+	}, nil
+}
+
+func (l *libraryImpl) NotifyHoldAvailable(
+	ctx context.Context,
+	patronID string,
+	bookID string,
+) (*library.NotifyHoldAvailableResponse, error) {
+	scheduled, err := l.notificationRepository.ScheduleNotifications(ctx, []entity.Notification{
+		{
+			PatronID: patronID,
+			BookID:   bookID,
+			Kind:     entity.NotificationKindHoldAvailable,
+			SendAt:   time.Now(),
+		},
+	})
+
+	if err != nil {
+		l.logger.Error("cannot schedule hold available notification", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.NotifyHoldAvailableResponse{
+		Notification: convertNotificationToResponse(scheduled[0]),
+	}, nil
+}
+
+func (l *libraryImpl) SetNotificationPreferences(ctx context.Context, preferences entity.NotificationPreferences) error {
+	err := l.notificationRepository.SetNotificationPreferences(ctx, preferences)
+
+	if err != nil {
+		l.logger.Error("cannot set notification preferences", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (l *libraryImpl) GetNotificationPreferences(
+	ctx context.Context,
+	patronID string,
+) (*library.GetNotificationPreferencesResponse, error) {
+	preferences, err := l.notificationRepository.GetNotificationPreferences(ctx, patronID)
+
+	if err != nil {
+		l.logger.Error("cannot get notification preferences", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.GetNotificationPreferencesResponse{
+		Preferences: convertPreferencesToResponse(preferences),
+	}, nil
+}
@@ -0,0 +1,135 @@
+package library
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseScheduleDueDateReminders(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	patronID := uuid.New().String()
+	bookID := uuid.New().String()
+
+	tests := []struct {
+		testName      string
+		dueAt         time.Time
+		repositoryErr error
+		wantErr       error
+	}{
+		{
+			testName: "reminders scheduled before due date",
+			dueAt:    time.Now().AddDate(0, 0, 14),
+		},
+		{
+			testName: "due date closer than reminder window",
+			dueAt:    time.Now().Add(time.Hour),
+		},
+		{
+			testName:      "book does not exist",
+			dueAt:         time.Now().AddDate(0, 0, 14),
+			repositoryErr: entity.ErrBookNotFound,
+			wantErr:       entity.ErrBookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Parallel()
+			data := getUseCaseData(t)
+
+			var scheduled []entity.Notification
+			data.notificationRepo.EXPECT().ScheduleNotifications(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, notifications []entity.Notification) ([]entity.Notification, error) {
+					scheduled = notifications
+					if tt.repositoryErr != nil {
+						return nil, tt.repositoryErr
+					}
+					return notifications, nil
+				})
+
+			resp, err := data.impl.ScheduleDueDateReminders(ctx, patronID, bookID, tt.dueAt)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp.GetNotifications(), 2)
+			require.Len(t, scheduled, 2)
+
+			dueSoon, overdue := scheduled[0], scheduled[1]
+			require.Equal(t, entity.NotificationKindDueSoon, dueSoon.Kind)
+			require.Equal(t, entity.NotificationKindOverdue, overdue.Kind)
+			require.True(t, overdue.SendAt.Equal(tt.dueAt))
+			require.False(t, dueSoon.SendAt.Before(tt.dueAt.AddDate(0, 0, -testDaysBeforeDue)))
+			require.False(t, dueSoon.SendAt.After(tt.dueAt))
+		})
+	}
+}
+
+func TestUseCaseNotifyHoldAvailable(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	patronID := uuid.New().String()
+	bookID := uuid.New().String()
+
+	data := getUseCaseData(t)
+	data.notificationRepo.EXPECT().ScheduleNotifications(ctx, gomock.Len(1)).
+		DoAndReturn(func(_ context.Context, notifications []entity.Notification) ([]entity.Notification, error) {
+			require.Equal(t, entity.NotificationKindHoldAvailable, notifications[0].Kind)
+			notifications[0].ID = uuid.New().String()
+			return notifications, nil
+		})
+
+	resp, err := data.impl.NotifyHoldAvailable(ctx, patronID, bookID)
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.GetNotification().GetId())
+	require.Equal(t, patronID, resp.GetNotification().GetPatronId())
+	require.Equal(t, bookID, resp.GetNotification().GetBookId())
+}
+
+func TestUseCaseNotificationPreferences(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	preferences := entity.NotificationPreferences{
+		PatronID:   uuid.New().String(),
+		Channels:   []entity.NotificationChannel{entity.NotificationChannelEmail, entity.NotificationChannelWebhook},
+		Email:      "patron@example.com",
+		WebhookURL: "https://example.com/hook",
+	}
+
+	t.Run("set preferences", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.notificationRepo.EXPECT().SetNotificationPreferences(ctx, preferences).Return(nil)
+
+		require.NoError(t, data.impl.SetNotificationPreferences(ctx, preferences))
+	})
+	t.Run("get preferences", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.notificationRepo.EXPECT().GetNotificationPreferences(ctx, preferences.PatronID).Return(preferences, nil)
+
+		resp, err := data.impl.GetNotificationPreferences(ctx, preferences.PatronID)
+		require.NoError(t, err)
+		require.Equal(t, preferences.Email, resp.GetPreferences().GetEmail())
+		require.Equal(t, preferences.WebhookURL, resp.GetPreferences().GetWebhookUrl())
+		require.Len(t, resp.GetPreferences().GetChannels(), 2)
+	})
+	t.Run("get missing preferences", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.notificationRepo.EXPECT().GetNotificationPreferences(ctx, preferences.PatronID).
+			Return(entity.NotificationPreferences{}, entity.ErrNotificationPreferencesNotFound)
+
+		_, err := data.impl.GetNotificationPreferences(ctx, preferences.PatronID)
+		require.ErrorIs(t, err, entity.ErrNotificationPreferencesNotFound)
+	})
+}
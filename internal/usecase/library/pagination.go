@@ -0,0 +1,69 @@
+package library
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/project/library/internal/entity"
+)
+
+const (
+	defaultPageSize = 20
+)
+
+func getPageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return defaultPageSize
+	}
+
+	return pageSize
+}
+
+// encodePageToken returns an opaque token pointing at the given cursor.
+func encodePageToken(cursor entity.PageCursor) (string, error) {
+	serialized, err := json.Marshal(cursor)
+
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(serialized), nil
+}
+
+// decodePageToken parses a token produced by encodePageToken,
+// an empty token means the first page and yields nil cursor.
+func decodePageToken(token string) (*entity.PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	serialized, err := base64.RawURLEncoding.DecodeString(token)
+
+	if err != nil {
+		return nil, entity.ErrInvalidPageToken
+	}
+
+	cursor := &entity.PageCursor{}
+	if err = json.Unmarshal(serialized, cursor); err != nil || cursor.ID == "" {
+		return nil, entity.ErrInvalidPageToken
+	}
+
+	return cursor, nil
+}
+
+// trimPage cuts a result fetched with limit+1 rows down to limit
+// and returns the token for the next page, empty on the last page.
+func trimPage[T any](items []T, limit int, cursor func(T) entity.PageCursor) ([]T, string, error) {
+	if len(items) <= limit {
+		return items, "", nil
+	}
+
+	items = items[:limit]
+	token, err := encodePageToken(cursor(items[limit-1]))
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, token, nil
+}
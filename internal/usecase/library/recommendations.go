@@ -0,0 +1,81 @@
+package library
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRecommendationLimit = 10
+)
+
+// normalizeSubjects lowercases and trims subjects so that "Fantasy" and " fantasy" match.
+func normalizeSubjects(subjects []string) []string {
+	result := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		subject = strings.ToLower(strings.TrimSpace(subject))
+
+		if subject != "" && !slices.Contains(result, subject) {
+			result = append(result, subject)
+		}
+	}
+
+	return result
+}
+
+func (l *libraryImpl) SetBookSubjects(ctx context.Context, bookID string, subjects []string) error {
+	err := l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		return l.recommendationRepository.SetBookSubjects(ctx, bookID, normalizeSubjects(subjects))
+	})
+
+	if err != nil {
+		l.logger.Error("cannot set book subjects", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// RecommendBooks reads the neighbours precomputed by the recommendation job,
+// a book added after the last run has no recommendations yet.
+func (l *libraryImpl) RecommendBooks(
+	ctx context.Context,
+	bookID string,
+	limit int,
+	view entity.BookView,
+) (*library.RecommendBooksResponse, error) {
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
+
+	recommendations, err := l.recommendationRepository.GetRecommendations(ctx, bookID, limit)
+
+	if err != nil {
+		l.logger.Error("cannot get recommendations", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]*library.RecommendedBook, len(recommendations))
+	books := make([]*library.Book, len(recommendations))
+	for i, recommendation := range recommendations {
+		books[i] = convertBookToResponse(recommendation.Book)
+		res[i] = &library.RecommendedBook{
+			Book:  books[i],
+			Score: recommendation.Score,
+		}
+	}
+
+	if err = l.expandAuthors(ctx, view, books...); err != nil {
+		return nil, err
+	}
+
+	return &library.RecommendBooksResponse{
+		Books: res,
+	}, nil
+}
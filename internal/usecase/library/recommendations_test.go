@@ -0,0 +1,64 @@
+package library
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCaseSetBookSubjects(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+
+	data := getUseCaseData(t)
+	prepareTransactor(ctx, data)
+	data.recommendRepo.EXPECT().SetBookSubjects(ctx, bookID, []string{"fantasy", "dragons"}).Return(nil)
+
+	err := data.impl.SetBookSubjects(ctx, bookID, []string{" Fantasy", "dragons", "fantasy ", "  "})
+	require.NoError(t, err)
+}
+
+func TestUseCaseRecommendBooks(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.New().String()
+	recommendations := []entity.Recommendation{
+		{Book: entity.Book{ID: uuid.New().String(), Name: "first"}, Score: 5},
+		{Book: entity.Book{ID: uuid.New().String(), Name: "second"}, Score: 2},
+	}
+
+	tests := []struct {
+		testName string
+		limit    int
+		want     int
+	}{
+		{
+			testName: "default limit",
+			limit:    0,
+			want:     defaultRecommendationLimit,
+		},
+		{
+			testName: "explicit limit",
+			limit:    2,
+			want:     2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Parallel()
+			data := getUseCaseData(t)
+			data.recommendRepo.EXPECT().GetRecommendations(ctx, bookID, tt.want).Return(recommendations, nil)
+
+			resp, err := data.impl.RecommendBooks(ctx, bookID, tt.limit, entity.BookViewBasic)
+			require.NoError(t, err)
+			require.Len(t, resp.GetBooks(), 2)
+			require.Equal(t, "first", resp.GetBooks()[0].GetBook().GetName())
+			require.InDelta(t, 5.0, resp.GetBooks()[0].GetScore(), 1e-9)
+		})
+	}
+}
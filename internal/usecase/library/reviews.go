@@ -0,0 +1,156 @@
+package library
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/project/library/generated/api/library"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/repository"
+
+	"go.uber.org/zap"
+)
+
+func convertReviewToResponse(review entity.Review) *library.Review {
+	return &library.Review{
+		Id:        review.ID,
+		BookId:    review.BookID,
+		PatronId:  review.PatronID,
+		Rating:    int32(review.Rating),
+		Text:      review.Text,
+		Status:    library.ReviewStatus(review.Status),
+		CreatedAt: timestamppb.New(review.CreatedAt),
+		UpdatedAt: timestamppb.New(review.UpdatedAt),
+	}
+}
+
+// AddReview stores a pending review, it counts in the book rating only once approved.
+func (l *libraryImpl) AddReview(
+	ctx context.Context,
+	patronID string,
+	bookID string,
+	rating int,
+	text string,
+	idempotencyKey entity.IdempotencyKey,
+) (*library.AddReviewResponse, error) {
+	response := &library.AddReviewResponse{}
+
+	err := l.idempotent(ctx, idempotencyKey, response, func(ctx context.Context) error {
+		review, err := l.reviewRepository.CreateReview(ctx, entity.Review{
+			BookID:   bookID,
+			PatronID: patronID,
+			Rating:   rating,
+			Text:     text,
+			Status:   entity.ReviewStatusPending,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		serialized, err := json.Marshal(review)
+
+		if err != nil {
+			return err
+		}
+
+		outboxKey := repository.OutboxKindReview.String() + "_" + review.ID
+
+		if err = l.outboxRepository.SendMessage(ctx, outboxKey, repository.OutboxKindReview, serialized); err != nil {
+			return err
+		}
+
+		response.Review = convertReviewToResponse(review)
+
+		return nil
+	})
+
+	if err != nil {
+		l.logger.Error("cannot add review", zap.Error(err))
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ModerateReview changes the review status, only approved reviews are accounted in the book rating.
+func (l *libraryImpl) ModerateReview(
+	ctx context.Context,
+	reviewID string,
+	status entity.ReviewStatus,
+) (*library.ModerateReviewResponse, error) {
+	var review entity.Review
+
+	err := l.transactor.WithTx(ctx, func(ctx context.Context) error {
+		current, err := l.reviewRepository.GetReviewForUpdate(ctx, reviewID)
+
+		if err != nil {
+			return err
+		}
+
+		review, err = l.reviewRepository.UpdateReviewStatus(ctx, reviewID, status)
+
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case current.CountsInRating() && !review.CountsInRating():
+			return l.reviewRepository.UpdateBookRating(ctx, review.BookID, -review.Rating, -1)
+		case !current.CountsInRating() && review.CountsInRating():
+			return l.reviewRepository.UpdateBookRating(ctx, review.BookID, review.Rating, 1)
+		default:
+			return nil
+		}
+	})
+
+	if err != nil {
+		l.logger.Error("cannot moderate review", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.ModerateReviewResponse{
+		Review: convertReviewToResponse(review),
+	}, nil
+}
+
+func (l *libraryImpl) ListReviews(
+	ctx context.Context,
+	filter entity.ReviewFilter,
+	pageSize int,
+	pageToken string,
+) (*library.ListReviewsResponse, error) {
+	cursor, err := decodePageToken(pageToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	limit := getPageSize(pageSize)
+	reviews, err := l.reviewRepository.ListReviews(ctx, filter, cursor, limit+1)
+
+	if err != nil {
+		l.logger.Error("cannot list reviews", zap.Error(err))
+		return nil, err
+	}
+
+	reviews, nextPageToken, err := trimPage(reviews, limit, func(review entity.Review) entity.PageCursor {
+		return entity.PageCursor{CreatedAt: review.CreatedAt, ID: review.ID}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*library.Review, len(reviews))
+	for i, review := range reviews {
+		res[i] = convertReviewToResponse(review)
+	}
+
+	return &library.ListReviewsResponse{
+		Reviews:       res,
+		NextPageToken: nextPageToken,
+	}, nil
+}
@@ -0,0 +1,187 @@
+package library
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/repository"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseAddReview(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	review := entity.Review{
+		BookID:   uuid.New().String(),
+		PatronID: uuid.New().String(),
+		Rating:   5,
+		Text:     "great",
+		Status:   entity.ReviewStatusPending,
+	}
+	created := review
+	created.ID = uuid.New().String()
+
+	t.Run("review added successfully", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		data.reviewRepository.EXPECT().CreateReview(ctx, review).Return(created, nil)
+		data.outboxRepository.EXPECT().SendMessage(
+			ctx,
+			repository.OutboxKindReview.String()+"_"+created.ID,
+			repository.OutboxKindReview,
+			gomock.Any(),
+		).Return(nil)
+
+		resp, err := data.impl.AddReview(ctx, review.PatronID, review.BookID, review.Rating, review.Text, entity.IdempotencyKey{})
+		require.NoError(t, err)
+		require.Equal(t, created.ID, resp.GetReview().GetId())
+	})
+
+	t.Run("review already exists", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		data.reviewRepository.EXPECT().CreateReview(ctx, review).Return(entity.Review{}, entity.ErrReviewAlreadyExists)
+
+		_, err := data.impl.AddReview(ctx, review.PatronID, review.BookID, review.Rating, review.Text, entity.IdempotencyKey{})
+		require.ErrorIs(t, err, entity.ErrReviewAlreadyExists)
+	})
+}
+
+func TestUseCaseModerateReview(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	review := entity.Review{
+		ID:     uuid.New().String(),
+		BookID: uuid.New().String(),
+		Rating: 3,
+		Status: entity.ReviewStatusPending,
+	}
+
+	withStatus := func(status entity.ReviewStatus) entity.Review {
+		result := review
+		result.Status = status
+		return result
+	}
+
+	tests := []struct {
+		testName string
+		current  entity.ReviewStatus
+		status   entity.ReviewStatus
+		prepare  func(*useCaseData)
+	}{
+		{
+			testName: "approve pending review adds it to rating",
+			current:  entity.ReviewStatusPending,
+			status:   entity.ReviewStatusApproved,
+			prepare: func(data *useCaseData) {
+				data.reviewRepository.EXPECT().UpdateBookRating(ctx, review.BookID, 3, 1).Return(nil)
+			},
+		},
+		{
+			testName: "reject pending review keeps rating",
+			current:  entity.ReviewStatusPending,
+			status:   entity.ReviewStatusRejected,
+			prepare:  func(_ *useCaseData) {},
+		},
+		{
+			testName: "reject review removes it from rating",
+			current:  entity.ReviewStatusApproved,
+			status:   entity.ReviewStatusRejected,
+			prepare: func(data *useCaseData) {
+				data.reviewRepository.EXPECT().UpdateBookRating(ctx, review.BookID, -3, -1).Return(nil)
+			},
+		},
+		{
+			testName: "approve rejected review returns it to rating",
+			current:  entity.ReviewStatusRejected,
+			status:   entity.ReviewStatusApproved,
+			prepare: func(data *useCaseData) {
+				data.reviewRepository.EXPECT().UpdateBookRating(ctx, review.BookID, 3, 1).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Parallel()
+			data := getUseCaseData(t)
+			prepareTransactor(ctx, data)
+			data.reviewRepository.EXPECT().GetReviewForUpdate(ctx, review.ID).Return(withStatus(tt.current), nil)
+			data.reviewRepository.EXPECT().UpdateReviewStatus(ctx, review.ID, tt.status).Return(withStatus(tt.status), nil)
+			tt.prepare(data)
+
+			resp, err := data.impl.ModerateReview(ctx, review.ID, tt.status)
+			require.NoError(t, err)
+			require.Equal(t, int32(tt.status), int32(resp.GetReview().GetStatus()))
+		})
+	}
+
+	t.Run("review not found", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		prepareTransactor(ctx, data)
+		data.reviewRepository.EXPECT().GetReviewForUpdate(ctx, review.ID).Return(entity.Review{}, entity.ErrReviewNotFound)
+
+		_, err := data.impl.ModerateReview(ctx, review.ID, entity.ReviewStatusApproved)
+		require.ErrorIs(t, err, entity.ErrReviewNotFound)
+	})
+}
+
+func TestUseCaseListReviews(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	filter := entity.ReviewFilter{BookID: uuid.New().String()}
+	now := time.Now().UTC()
+	reviews := []entity.Review{
+		{ID: uuid.New().String(), CreatedAt: now},
+		{ID: uuid.New().String(), CreatedAt: now.Add(time.Second)},
+		{ID: uuid.New().String(), CreatedAt: now.Add(2 * time.Second)},
+	}
+
+	t.Run("pages through reviews", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.reviewRepository.EXPECT().ListReviews(ctx, filter, (*entity.PageCursor)(nil), 3).Return(reviews, nil)
+
+		first, err := data.impl.ListReviews(ctx, filter, 2, "")
+		require.NoError(t, err)
+		require.Len(t, first.GetReviews(), 2)
+		require.NotEmpty(t, first.GetNextPageToken())
+
+		cursor, err := decodePageToken(first.GetNextPageToken())
+		require.NoError(t, err)
+		require.Equal(t, reviews[1].ID, cursor.ID)
+		require.True(t, reviews[1].CreatedAt.Equal(cursor.CreatedAt))
+
+		data.reviewRepository.EXPECT().ListReviews(ctx, filter, gomock.Any(), 3).Return(reviews[2:], nil)
+
+		second, err := data.impl.ListReviews(ctx, filter, 2, first.GetNextPageToken())
+		require.NoError(t, err)
+		require.Len(t, second.GetReviews(), 1)
+		require.Empty(t, second.GetNextPageToken())
+	})
+
+	t.Run("default page size", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.reviewRepository.EXPECT().ListReviews(ctx, filter, (*entity.PageCursor)(nil), defaultPageSize+1).Return(nil, nil)
+
+		resp, err := data.impl.ListReviews(ctx, filter, 0, "")
+		require.NoError(t, err)
+		require.Empty(t, resp.GetReviews())
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		_, err := data.impl.ListReviews(ctx, filter, 2, "not a token!")
+		require.ErrorIs(t, err, entity.ErrInvalidPageToken)
+	})
+}
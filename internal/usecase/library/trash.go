@@ -0,0 +1,25 @@
+package library
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+func (l *libraryImpl) RestoreBook(ctx context.Context, bookID string) error {
+	if err := l.trashRepository.RestoreBook(ctx, bookID); err != nil {
+		l.logger.Error("cannot restore book", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (l *libraryImpl) RestoreAuthor(ctx context.Context, authorID string) error {
+	if err := l.trashRepository.RestoreAuthor(ctx, authorID); err != nil {
+		l.logger.Error("cannot restore author", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
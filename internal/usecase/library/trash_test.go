@@ -0,0 +1,101 @@
+package library
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCaseGetDeleted(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	deletedAt := time.Date(2026, time.January, 1, 0, 30, 0, 0, time.UTC)
+	author := entity.Author{ID: uuid.NewString(), Name: "Frank Herbert", DeletedAt: deletedAt}
+	book := entity.Book{ID: uuid.NewString(), Name: "Dune", DeletedAt: deletedAt}
+
+	t.Run("book in the trash", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.trashRepository.EXPECT().GetBookIncludingDeleted(ctx, book.ID).Return(book, nil)
+
+		resp, err := data.impl.GetBook(ctx, book.ID, false, entity.BookViewBasic, time.Time{}, true)
+		require.NoError(t, err)
+		require.Equal(t, "Dune", resp.GetBook().GetName())
+		require.True(t, deletedAt.Equal(resp.GetBook().GetDeletedAt().AsTime()))
+	})
+
+	t.Run("live book has no deleted_at", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		live := book
+		live.DeletedAt = time.Time{}
+		data.bookRepository.EXPECT().GetBook(ctx, book.ID).Return(live, nil)
+
+		resp, err := data.impl.GetBook(ctx, book.ID, false, entity.BookViewBasic, time.Time{}, false)
+		require.NoError(t, err)
+		require.Nil(t, resp.GetBook().GetDeletedAt())
+	})
+
+	t.Run("author in the trash", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.trashRepository.EXPECT().GetAuthorIncludingDeleted(ctx, author.ID).Return(author, nil)
+
+		resp, err := data.impl.GetAuthor(ctx, author.ID, time.Time{}, true)
+		require.NoError(t, err)
+		require.Equal(t, author.Name, resp.GetName())
+		require.True(t, deletedAt.Equal(resp.GetDeletedAt().AsTime()))
+	})
+
+	t.Run("author not found", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.trashRepository.EXPECT().GetAuthorIncludingDeleted(ctx, author.ID).Return(entity.Author{}, entity.ErrAuthorNotFound)
+
+		_, err := data.impl.GetAuthor(ctx, author.ID, time.Time{}, true)
+		require.ErrorIs(t, err, entity.ErrAuthorNotFound)
+	})
+}
+
+func TestUseCaseRestore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.NewString()
+	authorID := uuid.NewString()
+
+	t.Run("restore book", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.trashRepository.EXPECT().RestoreBook(ctx, bookID).Return(nil)
+
+		require.NoError(t, data.impl.RestoreBook(ctx, bookID))
+	})
+
+	t.Run("book not in the trash", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.trashRepository.EXPECT().RestoreBook(ctx, bookID).Return(entity.ErrBookNotFound)
+
+		require.ErrorIs(t, data.impl.RestoreBook(ctx, bookID), entity.ErrBookNotFound)
+	})
+
+	t.Run("restore author", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.trashRepository.EXPECT().RestoreAuthor(ctx, authorID).Return(nil)
+
+		require.NoError(t, data.impl.RestoreAuthor(ctx, authorID))
+	})
+
+	t.Run("author not in the trash", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		data.trashRepository.EXPECT().RestoreAuthor(ctx, authorID).Return(entity.ErrAuthorNotFound)
+
+		require.ErrorIs(t, data.impl.RestoreAuthor(ctx, authorID), entity.ErrAuthorNotFound)
+	})
+}
@@ -2,6 +2,7 @@ package library
 
 import (
 	"testing"
+	"time"
 
 	"github.com/project/library/internal/usecase/repository/mocks"
 
@@ -14,9 +15,31 @@ type useCaseData struct {
 	authorRepository *mocks.MockAuthorRepository
 	bookRepository   *mocks.MockBookRepository
 	outboxRepository *mocks.MockOutboxRepository
+	notificationRepo *mocks.MockNotificationRepository
+	branchRepository *mocks.MockBranchRepository
+	reviewRepository *mocks.MockReviewRepository
+	collectionRepo   *mocks.MockCollectionRepository
+	recommendRepo    *mocks.MockRecommendationRepository
+	catalogRepo      *mocks.MockCatalogRepository
+	attachmentRepo   *mocks.MockAttachmentRepository
+	blobStore        *mocks.MockBlobStore
+	workRepository   *mocks.MockWorkRepository
+	idempotencyRepo  *mocks.MockIdempotencyRepository
+	changeRepository *mocks.MockChangeRepository
+	auditRepository  *mocks.MockAuditRepository
+	historyRepo      *mocks.MockHistoryRepository
+	trashRepository  *mocks.MockTrashRepository
 	transactor       *mocks.MockTransactor
 }
 
+const (
+	testDaysBeforeDue = 3
+	testMaxBatchIDs   = 3
+
+	testIdempotencyTTL    = time.Hour
+	testWatchPollInterval = time.Millisecond
+)
+
 func getUseCaseData(t *testing.T) *useCaseData {
 	t.Helper()
 	ctrl := gomock.NewController(t)
@@ -25,19 +48,71 @@ func getUseCaseData(t *testing.T) *useCaseData {
 	mockAuthorRepository := mocks.NewMockAuthorRepository(ctrl)
 	mockBookRepository := mocks.NewMockBookRepository(ctrl)
 	mockOutboxRepository := mocks.NewMockOutboxRepository(ctrl)
+	mockNotificationRepository := mocks.NewMockNotificationRepository(ctrl)
+	mockBranchRepository := mocks.NewMockBranchRepository(ctrl)
+	mockReviewRepository := mocks.NewMockReviewRepository(ctrl)
+	mockCollectionRepository := mocks.NewMockCollectionRepository(ctrl)
+	mockRecommendationRepository := mocks.NewMockRecommendationRepository(ctrl)
+	mockCatalogRepository := mocks.NewMockCatalogRepository(ctrl)
+	mockAttachmentRepository := mocks.NewMockAttachmentRepository(ctrl)
+	mockBlobStore := mocks.NewMockBlobStore(ctrl)
+	mockWorkRepository := mocks.NewMockWorkRepository(ctrl)
+	mockIdempotencyRepository := mocks.NewMockIdempotencyRepository(ctrl)
+	mockChangeRepository := mocks.NewMockChangeRepository(ctrl)
+	mockAuditRepository := mocks.NewMockAuditRepository(ctrl)
+	mockHistoryRepository := mocks.NewMockHistoryRepository(ctrl)
+	mockTrashRepository := mocks.NewMockTrashRepository(ctrl)
 	mockTransactor := mocks.NewMockTransactor(ctrl)
 
 	logger, err := zap.NewProduction()
 	if err != nil {
 		t.Fatal(err)
 	}
-	impl := New(logger, mockAuthorRepository, mockBookRepository, mockOutboxRepository, mockTransactor)
+	impl := New(
+		logger,
+		mockAuthorRepository,
+		mockBookRepository,
+		mockOutboxRepository,
+		mockNotificationRepository,
+		mockBranchRepository,
+		mockReviewRepository,
+		mockCollectionRepository,
+		mockRecommendationRepository,
+		mockCatalogRepository,
+		mockAttachmentRepository,
+		mockBlobStore,
+		mockWorkRepository,
+		mockIdempotencyRepository,
+		mockChangeRepository,
+		mockAuditRepository,
+		mockHistoryRepository,
+		mockTrashRepository,
+		mockTransactor,
+		testDaysBeforeDue,
+		testMaxBatchIDs,
+		testIdempotencyTTL,
+		testWatchPollInterval,
+	)
 
 	return &useCaseData{
 		impl:             impl,
 		authorRepository: mockAuthorRepository,
 		bookRepository:   mockBookRepository,
 		outboxRepository: mockOutboxRepository,
+		notificationRepo: mockNotificationRepository,
+		branchRepository: mockBranchRepository,
+		reviewRepository: mockReviewRepository,
+		collectionRepo:   mockCollectionRepository,
+		recommendRepo:    mockRecommendationRepository,
+		catalogRepo:      mockCatalogRepository,
+		attachmentRepo:   mockAttachmentRepository,
+		blobStore:        mockBlobStore,
+		workRepository:   mockWorkRepository,
+		idempotencyRepo:  mockIdempotencyRepository,
+		changeRepository: mockChangeRepository,
+		auditRepository:  mockAuditRepository,
+		historyRepo:      mockHistoryRepository,
+		trashRepository:  mockTrashRepository,
 		transactor:       mockTransactor,
 	}
 }
@@ -0,0 +1,138 @@
+package library
+
+import (
+	"context"
+	"time"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.uber.org/zap"
+)
+
+// watchBatchSize is how many changes a watch reads at once, a full batch is followed by the next one without waiting.
+const watchBatchSize = 100
+
+// WatchCatalog sends the changes of the entities after afterSequence and then polls for new ones until ctx is done.
+func (l *libraryImpl) WatchCatalog(
+	ctx context.Context,
+	afterSequence int64,
+	entities []entity.CatalogEntity,
+	view entity.BookView,
+	send func(*library.WatchCatalogResponse) error,
+) error {
+	ticker := time.NewTicker(l.watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		changes, err := l.changeRepository.GetCatalogChanges(ctx, afterSequence, entities, watchBatchSize)
+
+		if err != nil {
+			l.logger.Error("cannot get catalog changes", zap.Error(err))
+			return err
+		}
+
+		responses, err := l.convertCatalogChanges(ctx, changes, view)
+
+		if err != nil {
+			return err
+		}
+
+		for _, response := range responses {
+			if err = send(response); err != nil {
+				return err
+			}
+
+			afterSequence = response.GetSequence()
+		}
+
+		if len(changes) == watchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// convertCatalogChanges attaches the current state of the changed books and authors,
+// each kind is read with one query.
+func (l *libraryImpl) convertCatalogChanges(
+	ctx context.Context,
+	changes []entity.CatalogChange,
+	view entity.BookView,
+) ([]*library.WatchCatalogResponse, error) {
+	bookIDs := make([]string, 0)
+	authorIDs := make([]string, 0)
+	for _, change := range changes {
+		switch {
+		case change.Kind == entity.ChangeKindDeleted:
+		case change.Entity == entity.CatalogEntityBook:
+			bookIDs = append(bookIDs, change.EntityID)
+		case change.Entity == entity.CatalogEntityAuthor:
+			authorIDs = append(authorIDs, change.EntityID)
+		}
+	}
+
+	books := make(map[string]*library.Book)
+	if len(bookIDs) > 0 {
+		found, err := l.bookRepository.GetBooks(ctx, bookIDs)
+
+		if err != nil {
+			l.logger.Error("cannot get changed books", zap.Error(err))
+			return nil, err
+		}
+
+		converted := make([]*library.Book, len(found))
+		for i, book := range found {
+			converted[i] = convertBookToResponse(book)
+			books[book.ID] = converted[i]
+		}
+
+		if err = l.expandAuthors(ctx, view, converted...); err != nil {
+			return nil, err
+		}
+	}
+
+	authors := make(map[string]*library.AuthorSummary)
+	if len(authorIDs) > 0 {
+		found, err := l.authorRepository.GetAuthors(ctx, authorIDs)
+
+		if err != nil {
+			l.logger.Error("cannot get changed authors", zap.Error(err))
+			return nil, err
+		}
+
+		for _, author := range found {
+			authors[author.ID] = &library.AuthorSummary{Id: author.ID, Name: author.Name}
+		}
+	}
+
+	result := make([]*library.WatchCatalogResponse, len(changes))
+	for i, change := range changes {
+		result[i] = &library.WatchCatalogResponse{
+			Sequence:  change.Sequence,
+			Entity:    library.CatalogEntity(change.Entity),
+			Kind:      library.ChangeKind(change.Kind),
+			Id:        change.EntityID,
+			ChangedAt: timestamppb.New(change.ChangedAt),
+		}
+
+		if change.Kind == entity.ChangeKindDeleted {
+			continue
+		}
+
+		switch change.Entity {
+		case entity.CatalogEntityBook:
+			result[i].Book = books[change.EntityID]
+		case entity.CatalogEntityAuthor:
+			result[i].Author = authors[change.EntityID]
+		}
+	}
+
+	return result, nil
+}
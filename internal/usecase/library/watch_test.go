@@ -0,0 +1,89 @@
+package library
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCaseWatchCatalog(t *testing.T) {
+	t.Parallel()
+
+	author := entity.Author{ID: uuid.NewString(), Name: "Terry Pratchett"}
+	book := entity.Book{
+		ID:           uuid.NewString(),
+		Name:         "Mort",
+		AuthorIDs:    []string{author.ID},
+		Contributors: []entity.Contributor{{AuthorID: author.ID, Role: entity.ContributorRoleAuthor}},
+	}
+	deletedID := uuid.NewString()
+	changedAt := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	changes := []entity.CatalogChange{
+		{Sequence: 11, Entity: entity.CatalogEntityAuthor, Kind: entity.ChangeKindCreated, EntityID: author.ID, ChangedAt: changedAt},
+		{Sequence: 12, Entity: entity.CatalogEntityBook, Kind: entity.ChangeKindCreated, EntityID: book.ID, ChangedAt: changedAt},
+		{Sequence: 13, Entity: entity.CatalogEntityBook, Kind: entity.ChangeKindDeleted, EntityID: deletedID, ChangedAt: changedAt},
+	}
+
+	t.Run("changes are sent and watching resumes after the last one", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		data := getUseCaseData(t)
+
+		data.changeRepository.EXPECT().GetCatalogChanges(ctx, int64(10), nil, watchBatchSize).Return(changes, nil)
+		data.bookRepository.EXPECT().GetBooks(ctx, []string{book.ID}).Return([]entity.Book{book}, nil)
+		data.authorRepository.EXPECT().GetAuthors(ctx, []string{author.ID}).Return([]entity.Author{author}, nil).Times(2)
+		data.changeRepository.EXPECT().GetCatalogChanges(ctx, int64(13), nil, watchBatchSize).DoAndReturn(
+			func(context.Context, int64, []entity.CatalogEntity, int) ([]entity.CatalogChange, error) {
+				cancel()
+				return []entity.CatalogChange{}, nil
+			})
+
+		sent := make([]*library.WatchCatalogResponse, 0)
+		err := data.impl.WatchCatalog(ctx, 10, nil, entity.BookViewFull, func(resp *library.WatchCatalogResponse) error {
+			sent = append(sent, resp)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, sent, 3)
+		require.Equal(t, author.Name, sent[0].GetAuthor().GetName())
+		require.Equal(t, book.ID, sent[1].GetBook().GetId())
+		require.Equal(t, author.Name, sent[1].GetBook().GetAuthors()[0].GetName())
+		require.Equal(t, library.ChangeKind_CHANGE_KIND_DELETED, sent[2].GetKind())
+		require.Nil(t, sent[2].GetBook())
+		require.Equal(t, changedAt, sent[2].GetChangedAt().AsTime())
+	})
+
+	t.Run("send error stops watching", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		data := getUseCaseData(t)
+
+		data.changeRepository.EXPECT().GetCatalogChanges(ctx, int64(12), []entity.CatalogEntity{entity.CatalogEntityBook}, watchBatchSize).Return(changes[2:], nil)
+
+		err := data.impl.WatchCatalog(ctx, 12, []entity.CatalogEntity{entity.CatalogEntityBook}, entity.BookViewBasic, func(*library.WatchCatalogResponse) error {
+			return errors.New("stream closed")
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		data := getUseCaseData(t)
+
+		data.changeRepository.EXPECT().GetCatalogChanges(ctx, int64(0), nil, watchBatchSize).Return(nil, errors.New("connection refused"))
+
+		err := data.impl.WatchCatalog(ctx, 0, nil, entity.BookViewBasic, func(*library.WatchCatalogResponse) error {
+			return nil
+		})
+		require.Error(t, err)
+	})
+}
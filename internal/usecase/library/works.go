@@ -0,0 +1,109 @@
+package library
+
+import (
+	"context"
+
+	"github.com/project/library/generated/api/library"
+	"github.com/project/library/internal/entity"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func convertWorkToResponse(work entity.Work) *library.Work {
+	return &library.Work{
+		Id:        work.ID,
+		Name:      work.Name,
+		CreatedAt: timestamppb.New(work.CreatedAt),
+		UpdatedAt: timestamppb.New(work.UpdatedAt),
+	}
+}
+
+// CreateWork creates a work with the given books as its editions,
+// a book that belonged to another work is moved into the new one.
+func (l *libraryImpl) CreateWork(
+	ctx context.Context,
+	name string,
+	bookIDs []string,
+	idempotencyKey entity.IdempotencyKey,
+) (*library.CreateWorkResponse, error) {
+	response := &library.CreateWorkResponse{}
+
+	err := l.idempotent(ctx, idempotencyKey, response, func(ctx context.Context) error {
+		work, err := l.workRepository.CreateWork(ctx, entity.Work{
+			Name: name,
+		})
+
+		if err != nil {
+			l.logger.Error("cannot create work", zap.Error(err))
+			return err
+		}
+
+		if len(bookIDs) > 0 {
+			if err := l.workRepository.AddWorkEditions(ctx, work.ID, bookIDs); err != nil {
+				return err
+			}
+		}
+
+		response.Work = convertWorkToResponse(work)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (l *libraryImpl) GetWork(ctx context.Context, workID string, view entity.BookView) (*library.GetWorkResponse, error) {
+	work, err := l.workRepository.GetWork(ctx, workID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	editions, err := l.workRepository.GetEditions(ctx, workID)
+
+	if err != nil {
+		l.logger.Error("cannot get editions", zap.Error(err))
+		return nil, err
+	}
+
+	response := &library.GetWorkResponse{
+		Work:     convertWorkToResponse(work),
+		Editions: make([]*library.Book, len(editions)),
+	}
+
+	for i, edition := range editions {
+		response.Editions[i] = convertBookToResponse(edition)
+	}
+
+	if err = l.expandAuthors(ctx, view, response.GetEditions()...); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SetBookEdition replaces the edition attributes of a book, including the work it belongs to.
+func (l *libraryImpl) SetBookEdition(
+	ctx context.Context,
+	bookID string,
+	edition entity.Edition,
+) (*library.SetBookEditionResponse, error) {
+	if err := l.workRepository.SetBookEdition(ctx, bookID, edition); err != nil {
+		return nil, err
+	}
+
+	book, err := l.bookRepository.GetBook(ctx, bookID)
+
+	if err != nil {
+		l.logger.Error("cannot get book", zap.Error(err))
+		return nil, err
+	}
+
+	return &library.SetBookEditionResponse{
+		Book: convertBookToResponse(book),
+	}, nil
+}
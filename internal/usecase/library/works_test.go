@@ -0,0 +1,179 @@
+package library
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCaseCreateWork(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	work := entity.Work{ID: uuid.NewString(), Name: "Good Omens"}
+	bookIDs := []string{uuid.NewString(), uuid.NewString()}
+
+	t.Run("with editions", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		prepareTransactor(ctx, data)
+		data.workRepository.EXPECT().CreateWork(ctx, entity.Work{Name: work.Name}).Return(work, nil)
+		data.workRepository.EXPECT().AddWorkEditions(ctx, work.ID, bookIDs).Return(nil)
+
+		resp, err := data.impl.CreateWork(ctx, work.Name, bookIDs, entity.IdempotencyKey{})
+		require.NoError(t, err)
+		require.Equal(t, work.ID, resp.GetWork().GetId())
+		require.Equal(t, work.Name, resp.GetWork().GetName())
+	})
+
+	t.Run("without editions", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		prepareTransactor(ctx, data)
+		data.workRepository.EXPECT().CreateWork(ctx, entity.Work{Name: work.Name}).Return(work, nil)
+
+		_, err := data.impl.CreateWork(ctx, work.Name, nil, entity.IdempotencyKey{})
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown book", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		prepareTransactor(ctx, data)
+		data.workRepository.EXPECT().CreateWork(ctx, entity.Work{Name: work.Name}).Return(work, nil)
+		data.workRepository.EXPECT().AddWorkEditions(ctx, work.ID, bookIDs).Return(entity.ErrBookNotFound)
+
+		_, err := data.impl.CreateWork(ctx, work.Name, bookIDs, entity.IdempotencyKey{})
+		require.ErrorIs(t, err, entity.ErrBookNotFound)
+	})
+}
+
+func TestUseCaseGetWork(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	work := entity.Work{ID: uuid.NewString(), Name: "Good Omens"}
+	editions := []entity.Book{
+		{ID: uuid.NewString(), Name: "Good Omens", WorkID: work.ID, Language: "en", PublicationYear: 1990},
+		{ID: uuid.NewString(), Name: "Buone apocalissi", WorkID: work.ID, Language: "it", Translator: "Luca Fusari"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.workRepository.EXPECT().GetWork(ctx, work.ID).Return(work, nil)
+		data.workRepository.EXPECT().GetEditions(ctx, work.ID).Return(editions, nil)
+
+		resp, err := data.impl.GetWork(ctx, work.ID, entity.BookViewBasic)
+		require.NoError(t, err)
+		require.Equal(t, work.Name, resp.GetWork().GetName())
+		require.Len(t, resp.GetEditions(), 2)
+		require.Equal(t, "Luca Fusari", resp.GetEditions()[1].GetTranslator())
+		require.Equal(t, work.ID, resp.GetEditions()[1].GetWorkId())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.workRepository.EXPECT().GetWork(ctx, work.ID).Return(entity.Work{}, entity.ErrWorkNotFound)
+
+		_, err := data.impl.GetWork(ctx, work.ID, entity.BookViewBasic)
+		require.ErrorIs(t, err, entity.ErrWorkNotFound)
+	})
+}
+
+func TestUseCaseSetBookEdition(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bookID := uuid.NewString()
+	edition := entity.Edition{
+		WorkID:          uuid.NewString(),
+		ISBN:            "9788804492948",
+		Publisher:       "Mondadori",
+		PublicationYear: 2007,
+		Language:        "it",
+		Translator:      "Luca Fusari",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.workRepository.EXPECT().SetBookEdition(ctx, bookID, edition).Return(nil)
+		data.bookRepository.EXPECT().GetBook(ctx, bookID).Return(entity.Book{
+			ID:              bookID,
+			ISBN:            edition.ISBN,
+			Publisher:       edition.Publisher,
+			PublicationYear: edition.PublicationYear,
+			WorkID:          edition.WorkID,
+			Language:        edition.Language,
+			Translator:      edition.Translator,
+		}, nil)
+
+		resp, err := data.impl.SetBookEdition(ctx, bookID, edition)
+		require.NoError(t, err)
+		require.Equal(t, edition.WorkID, resp.GetBook().GetWorkId())
+		require.Equal(t, edition.Translator, resp.GetBook().GetTranslator())
+	})
+
+	t.Run("work not found", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.workRepository.EXPECT().SetBookEdition(ctx, bookID, edition).Return(entity.ErrWorkNotFound)
+
+		_, err := data.impl.SetBookEdition(ctx, bookID, edition)
+		require.ErrorIs(t, err, entity.ErrWorkNotFound)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.workRepository.EXPECT().SetBookEdition(ctx, bookID, edition).Return(errors.New("db is down"))
+
+		_, err := data.impl.SetBookEdition(ctx, bookID, edition)
+		require.Error(t, err)
+	})
+}
+
+func TestUseCaseGetBookEditions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	workID := uuid.NewString()
+	book := entity.Book{ID: uuid.NewString(), Name: "Good Omens", WorkID: workID}
+	sibling := entity.Book{ID: uuid.NewString(), Name: "Buone apocalissi", WorkID: workID}
+
+	t.Run("siblings without the book itself", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+
+		data.bookRepository.EXPECT().GetBook(ctx, book.ID).Return(book, nil)
+		data.workRepository.EXPECT().GetEditions(ctx, workID).Return([]entity.Book{book, sibling}, nil)
+
+		resp, err := data.impl.GetBook(ctx, book.ID, true, entity.BookViewBasic, time.Time{}, false)
+		require.NoError(t, err)
+		require.Len(t, resp.GetEditions(), 1)
+		require.Equal(t, sibling.ID, resp.GetEditions()[0].GetId())
+	})
+
+	t.Run("book without work", func(t *testing.T) {
+		t.Parallel()
+		data := getUseCaseData(t)
+		standalone := entity.Book{ID: uuid.NewString(), Name: "Dune"}
+
+		data.bookRepository.EXPECT().GetBook(ctx, standalone.ID).Return(standalone, nil)
+
+		resp, err := data.impl.GetBook(ctx, standalone.ID, true, entity.BookViewBasic, time.Time{}, false)
+		require.NoError(t, err)
+		require.Empty(t, resp.GetEditions())
+	})
+}
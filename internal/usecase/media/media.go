@@ -0,0 +1,141 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/gif" // registers the decoder for covers
+	"image/jpeg"
+	_ "image/png" // registers the decoder for covers
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/project/library/internal/entity"
+)
+
+const (
+	// SniffLength is the number of leading bytes DetectContentType looks at.
+	SniffLength = 512
+
+	ThumbnailContentType = "image/jpeg"
+	thumbnailQuality     = 85
+
+	epubMimetype = "mimetypeapplication/epub+zip"
+	epubOffset   = 30
+)
+
+var coverContentTypes = map[string]struct{}{
+	"image/jpeg": {},
+	"image/png":  {},
+	"image/gif":  {},
+}
+
+var fileContentTypes = map[string]struct{}{
+	"application/pdf":      {},
+	"application/epub+zip": {},
+	"text/plain":           {},
+	"image/jpeg":           {},
+	"image/png":            {},
+	"image/gif":            {},
+}
+
+// DetectContentType sniffs the media type of data by its leading bytes without parameters.
+// EPUB is told apart from other zip archives by its mimetype entry that must come first.
+func DetectContentType(head []byte) string {
+	if len(head) >= epubOffset+len(epubMimetype) && string(head[epubOffset:epubOffset+len(epubMimetype)]) == epubMimetype {
+		return "application/epub+zip"
+	}
+
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+
+	if err != nil {
+		return "application/octet-stream"
+	}
+
+	return mediaType
+}
+
+// Allowed reports whether a content type can be stored as an attachment of the given kind.
+func Allowed(kind entity.AttachmentKind, contentType string) bool {
+	var allowed map[string]struct{}
+
+	switch kind {
+	case entity.AttachmentKindCover:
+		allowed = coverContentTypes
+	case entity.AttachmentKindFile:
+		allowed = fileContentTypes
+	default:
+		return false
+	}
+
+	_, ok := allowed[contentType]
+
+	return ok
+}
+
+// Thumbnail decodes an image and scales it down to fit into a maxSide square, keeping the aspect ratio.
+// Smaller images keep their size. The result is always a JPEG.
+func Thumbnail(r io.Reader, maxSide int) ([]byte, error) {
+	src, _, err := image.Decode(r)
+
+	if err != nil {
+		return nil, entity.ErrInvalidAttachment
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			width, height = maxSide, max(1, height*maxSide/width)
+		} else {
+			width, height = max(1, width*maxSide/height), maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	scale(dst, src)
+
+	var buf bytes.Buffer
+	if err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// scale draws src over dst averaging every source pixel that falls into a destination pixel.
+func scale(dst *image.RGBA, src image.Image) {
+	sb := src.Bounds()
+	db := dst.Bounds()
+
+	for y := 0; y < db.Dy(); y++ {
+		y0 := sb.Min.Y + y*sb.Dy()/db.Dy()
+		y1 := max(y0+1, sb.Min.Y+(y+1)*sb.Dy()/db.Dy())
+
+		for x := 0; x < db.Dx(); x++ {
+			x0 := sb.Min.X + x*sb.Dx()/db.Dx()
+			x1 := max(x0+1, sb.Min.X+(x+1)*sb.Dx()/db.Dx())
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+
+			r, g, b, a = r/n, g/n, b/n, a/n
+			// composite the premultiplied average over a white background, JPEG has no transparency
+			background := 0xffff - a
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r + background) >> 8),
+				G: uint8((g + background) >> 8),
+				B: uint8((b + background) >> 8),
+				A: 0xff,
+			})
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectContentType(t *testing.T) {
+	t.Parallel()
+
+	epub := append([]byte("PK\x03\x04"), make([]byte, 26)...)
+	epub = append(epub, "mimetypeapplication/epub+zip"...)
+
+	tests := []struct {
+		name     string
+		head     []byte
+		expected string
+	}{
+		{name: "pdf", head: []byte("%PDF-1.7\n"), expected: "application/pdf"},
+		{name: "epub", head: epub, expected: "application/epub+zip"},
+		{name: "zip", head: []byte("PK\x03\x04" + strings.Repeat("\x00", 60)), expected: "application/zip"},
+		{name: "png", head: []byte("\x89PNG\r\n\x1a\n"), expected: "image/png"},
+		{name: "text", head: []byte("plain text"), expected: "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, DetectContentType(tt.head))
+		})
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, Allowed(entity.AttachmentKindCover, "image/png"))
+	require.False(t, Allowed(entity.AttachmentKindCover, "application/pdf"))
+	require.True(t, Allowed(entity.AttachmentKindFile, "application/epub+zip"))
+	require.False(t, Allowed(entity.AttachmentKindFile, "application/zip"))
+	require.False(t, Allowed(entity.AttachmentKindUndefined, "image/png"))
+}
+
+func TestThumbnail(t *testing.T) {
+	t.Parallel()
+
+	src := image.NewNRGBA(image.Rect(0, 0, 600, 300))
+	for y := range 300 {
+		for x := range 600 {
+			src.Set(x, y, color.NRGBA{R: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+
+	data, err := Thumbnail(&buf, 256)
+	require.NoError(t, err)
+
+	thumbnail, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 256, 128), thumbnail.Bounds())
+
+	r, g, _, _ := thumbnail.At(128, 64).RGBA()
+	require.InDelta(t, 200, r>>8, 8)
+	require.InDelta(t, 0, g>>8, 8)
+
+	_, err = Thumbnail(strings.NewReader("not an image"), 256)
+	require.ErrorIs(t, err, entity.ErrInvalidAttachment)
+}
+
+func TestThumbnailKeepsSmallImages(t *testing.T) {
+	t.Parallel()
+
+	// a transparent image turns white
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 40, 60))))
+
+	data, err := Thumbnail(&buf, 256)
+	require.NoError(t, err)
+
+	thumbnail, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 40, 60), thumbnail.Bounds())
+
+	r, _, _, _ := thumbnail.At(20, 30).RGBA()
+	require.InDelta(t, 255, r>>8, 8)
+}
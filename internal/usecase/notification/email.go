@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ Channel = (*emailChannel)(nil)
+
+type emailChannel struct {
+	address string
+	from    string
+	auth    smtp.Auth
+}
+
+func NewEmailChannel(host string, port string, user string, password string, from string) *emailChannel {
+	var auth smtp.Auth
+	if user != "" {
+		auth = smtp.PlainAuth("", user, password, host)
+	}
+
+	return &emailChannel{
+		address: net.JoinHostPort(host, port),
+		from:    from,
+		auth:    auth,
+	}
+}
+
+func (e *emailChannel) Send(_ context.Context, preferences entity.NotificationPreferences, message Message) error {
+	if preferences.Email == "" {
+		return errors.New("patron has no email address")
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", stripLineBreaks(e.from))
+	fmt.Fprintf(&body, "To: %s\r\n", stripLineBreaks(preferences.Email))
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", stripLineBreaks(message.Subject)))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	body.WriteString("\r\n")
+	body.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+
+	return smtp.SendMail(e.address, e.auth, e.from, []string{preferences.Email}, []byte(body.String()))
+}
+
+// stripLineBreaks keeps template data such as book names from starting a new header.
+func stripLineBreaks(value string) string {
+	return strings.Join(strings.FieldsFunc(value, func(r rune) bool {
+		return r == '\r' || r == '\n'
+	}), " ")
+}
@@ -0,0 +1,147 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSMTPMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// startFakeSMTPServer accepts a single SMTP session and reports the received mail.
+func startFakeSMTPServer(t *testing.T) (string, <-chan fakeSMTPMail) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = lis.Close()
+	})
+
+	mails := make(chan fakeSMTPMail, 1)
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) {
+			_, _ = conn.Write([]byte(line + "\r\n"))
+		}
+
+		var mail fakeSMTPMail
+		reply("220 localhost fake smtp")
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			command := strings.TrimRight(line, "\r\n")
+			upper := strings.ToUpper(command)
+
+			switch {
+			case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(upper, "MAIL FROM:"):
+				mail.from = strings.Trim(command[len("MAIL FROM:"):], "<> ")
+				reply("250 OK")
+			case strings.HasPrefix(upper, "RCPT TO:"):
+				mail.to = append(mail.to, strings.Trim(command[len("RCPT TO:"):], "<> "))
+				reply("250 OK")
+			case upper == "DATA":
+				reply("354 end data with <CR><LF>.<CR><LF>")
+
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+
+				mail.data = data.String()
+				reply("250 OK")
+			case upper == "QUIT":
+				reply("221 bye")
+				mails <- mail
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return lis.Addr().String(), mails
+}
+
+func TestEmailChannelSend(t *testing.T) {
+	t.Parallel()
+
+	address, mails := startFakeSMTPServer(t)
+	host, port, err := net.SplitHostPort(address)
+	require.NoError(t, err)
+
+	channel := NewEmailChannel(host, port, "", "", "library@example.com")
+
+	err = channel.Send(context.Background(), entity.NotificationPreferences{
+		Email: "patron@example.com",
+	}, Message{
+		Subject: "Reminder",
+		Body:    "Please return the book.\n",
+	})
+	require.NoError(t, err)
+
+	mail := <-mails
+	require.Equal(t, "library@example.com", mail.from)
+	require.Equal(t, []string{"patron@example.com"}, mail.to)
+	require.Contains(t, mail.data, "Subject: Reminder\r\n")
+	require.Contains(t, mail.data, "Please return the book.\r\n")
+}
+
+func TestEmailChannelSendHeaderInjection(t *testing.T) {
+	t.Parallel()
+
+	address, mails := startFakeSMTPServer(t)
+	host, port, err := net.SplitHostPort(address)
+	require.NoError(t, err)
+
+	channel := NewEmailChannel(host, port, "", "", "library@example.com")
+
+	err = channel.Send(context.Background(), entity.NotificationPreferences{
+		Email: "patron@example.com",
+	}, Message{
+		Subject: "Война и мир\r\nBcc: victim@example.com",
+		Body:    "Please return the book.\n",
+	})
+	require.NoError(t, err)
+
+	mail := <-mails
+	require.NotContains(t, mail.data, "\r\nBcc:")
+	require.Contains(t, mail.data, "Subject: =?utf-8?q?")
+}
+
+func TestEmailChannelNoAddress(t *testing.T) {
+	t.Parallel()
+
+	channel := NewEmailChannel("127.0.0.1", "25", "", "", "library@example.com")
+
+	err := channel.Send(context.Background(), entity.NotificationPreferences{}, Message{})
+	require.Error(t, err)
+}
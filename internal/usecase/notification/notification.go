@@ -0,0 +1,230 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/repository"
+	"go.uber.org/zap"
+)
+
+type Message struct {
+	Notification entity.Notification
+	Subject      string
+	Body         string
+}
+
+type Channel interface {
+	Send(ctx context.Context, preferences entity.NotificationPreferences, message Message) error
+}
+
+type Notifier interface {
+	Start(ctx context.Context, batchSize int, waitTime time.Duration) error
+	Handle(ctx context.Context, data []byte) error
+}
+
+var _ Notifier = (*notificationImpl)(nil)
+
+type notificationImpl struct {
+	logger                 *zap.Logger
+	notificationRepository repository.NotificationRepository
+	outboxRepository       repository.OutboxRepository
+	bookRepository         repository.BookRepository
+	transactor             repository.Transactor
+	templates              *Templates
+	channels               map[entity.NotificationChannel]Channel
+}
+
+func New(
+	logger *zap.Logger,
+	notificationRepository repository.NotificationRepository,
+	outboxRepository repository.OutboxRepository,
+	bookRepository repository.BookRepository,
+	transactor repository.Transactor,
+	templates *Templates,
+	channels map[entity.NotificationChannel]Channel,
+) *notificationImpl {
+	return &notificationImpl{
+		logger:                 logger,
+		notificationRepository: notificationRepository,
+		outboxRepository:       outboxRepository,
+		bookRepository:         bookRepository,
+		transactor:             transactor,
+		templates:              templates,
+		channels:               channels,
+	}
+}
+
+// Start moves notifications whose send time has come into the outbox,
+// from where they are delivered by Handle.
+func (n *notificationImpl) Start(ctx context.Context, batchSize int, waitTime time.Duration) error {
+	wg := new(sync.WaitGroup)
+
+	wg.Add(1)
+	go n.worker(ctx, wg, batchSize, waitTime)
+
+	go func() {
+		wg.Wait()
+	}()
+
+	return nil
+}
+
+func (n *notificationImpl) worker(ctx context.Context, wg *sync.WaitGroup, batchSize int, waitTime time.Duration) {
+	defer wg.Done()
+
+	for {
+		time.Sleep(waitTime)
+
+		if err := n.enqueueDue(ctx, batchSize); err != nil {
+			n.logger.Error("notification worker error", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			continue
+		}
+	}
+}
+
+func (n *notificationImpl) enqueueDue(ctx context.Context, batchSize int) error {
+	return n.transactor.WithTx(ctx, func(ctx context.Context) error {
+		notifications, err := n.notificationRepository.GetDueNotifications(ctx, batchSize)
+
+		if err != nil {
+			n.logger.Error("cannot fetch due notifications", zap.Error(err))
+			return err
+		}
+
+		for _, notification := range notifications {
+			serialized, err := json.Marshal(notification)
+
+			if err != nil {
+				n.logger.Error("cannot serialize notification", zap.Error(err))
+				return err
+			}
+
+			idempotencyKey := repository.OutboxKindNotification.String() + "_" + notification.ID
+			err = n.outboxRepository.SendMessage(ctx, idempotencyKey, repository.OutboxKindNotification, serialized)
+
+			if err != nil {
+				n.logger.Error("cannot send message to outbox", zap.Error(err))
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Handle is the outbox handler for repository.OutboxKindNotification.
+// A notification is first split into one message per channel of the patron,
+// so that a failed channel is retried alone and the others are not sent twice.
+func (n *notificationImpl) Handle(ctx context.Context, data []byte) error {
+	message := delivery{}
+	err := json.Unmarshal(data, &message)
+
+	if err != nil {
+		return fmt.Errorf("cannot deserialize data in notification outbox handler: %w", err)
+	}
+
+	preferences, err := n.notificationRepository.GetNotificationPreferences(ctx, message.PatronID)
+
+	if errors.Is(err, entity.ErrNotificationPreferencesNotFound) {
+		n.logger.Info("patron has no notification preferences", zap.String("patron_id", message.PatronID))
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot get notification preferences: %w", err)
+	}
+
+	if message.Channel == entity.NotificationChannelUndefined {
+		return n.splitByChannel(ctx, message.Notification, preferences)
+	}
+
+	return n.send(ctx, message, preferences)
+}
+
+// delivery is the outbox message of a notification, Channel is undefined
+// until Handle splits the notification by the channels of the patron.
+type delivery struct {
+	entity.Notification
+	Channel entity.NotificationChannel `json:",omitempty"`
+}
+
+func (n *notificationImpl) splitByChannel(
+	ctx context.Context,
+	notification entity.Notification,
+	preferences entity.NotificationPreferences,
+) error {
+	for _, kind := range preferences.Channels {
+		if _, ok := n.channels[kind]; !ok {
+			n.logger.Error("unsupported notification channel", zap.Stringer("channel", kind))
+			continue
+		}
+
+		serialized, err := json.Marshal(delivery{Notification: notification, Channel: kind})
+
+		if err != nil {
+			return fmt.Errorf("cannot serialize notification delivery: %w", err)
+		}
+
+		idempotencyKey := repository.OutboxKindNotification.String() + "_" + notification.ID + "_" + kind.String()
+		err = n.outboxRepository.SendMessage(ctx, idempotencyKey, repository.OutboxKindNotification, serialized)
+
+		if err != nil {
+			return fmt.Errorf("cannot send %s notification to outbox: %w", kind, err)
+		}
+	}
+
+	return nil
+}
+
+func (n *notificationImpl) send(ctx context.Context, message delivery, preferences entity.NotificationPreferences) error {
+	channel, ok := n.channels[message.Channel]
+
+	if !ok {
+		n.logger.Error("unsupported notification channel", zap.Stringer("channel", message.Channel))
+		return nil
+	}
+
+	book, err := n.bookRepository.GetBook(ctx, message.BookID)
+
+	// the book is trashed or purged, retrying would only fail again
+	if errors.Is(err, entity.ErrBookNotFound) {
+		n.logger.Info("notification book not found",
+			zap.String("notification_id", message.ID), zap.String("book_id", message.BookID))
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot get notification book: %w", err)
+	}
+
+	rendered, err := n.templates.Render(message.Kind, TemplateData{
+		PatronID: message.PatronID,
+		BookID:   book.ID,
+		BookName: book.Name,
+		DueAt:    message.DueAt,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	rendered.Notification = message.Notification
+
+	if err := channel.Send(ctx, preferences, rendered); err != nil {
+		return fmt.Errorf("cannot send %s notification: %w", message.Channel, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/repository/mocks"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+type notificationData struct {
+	notificationRepository *mocks.MockNotificationRepository
+	outboxRepository       *mocks.MockOutboxRepository
+	bookRepository         *mocks.MockBookRepository
+	transactor             *mocks.MockTransactor
+	channel                *recordingChannel
+	impl                   *notificationImpl
+}
+
+type recordingChannel struct {
+	mu       sync.Mutex
+	messages []Message
+	err      error
+}
+
+func (r *recordingChannel) Send(_ context.Context, _ entity.NotificationPreferences, message Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+
+	r.messages = append(r.messages, message)
+
+	return nil
+}
+
+func (n *notificationData) prepareDefaultTransactor() {
+	n.transactor.EXPECT().WithTx(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, x func(context.Context) error) error {
+			return x(ctx)
+		})
+}
+
+func getNotificationData(t *testing.T) *notificationData {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockNotificationRepository := mocks.NewMockNotificationRepository(ctrl)
+	mockOutboxRepository := mocks.NewMockOutboxRepository(ctrl)
+	mockBookRepository := mocks.NewMockBookRepository(ctrl)
+	mockTransactor := mocks.NewMockTransactor(ctrl)
+	channel := &recordingChannel{}
+
+	templates, err := LoadTemplates("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	impl := New(
+		logger,
+		mockNotificationRepository,
+		mockOutboxRepository,
+		mockBookRepository,
+		mockTransactor,
+		templates,
+		map[entity.NotificationChannel]Channel{
+			entity.NotificationChannelWebhook: channel,
+		},
+	)
+
+	return &notificationData{
+		notificationRepository: mockNotificationRepository,
+		outboxRepository:       mockOutboxRepository,
+		bookRepository:         mockBookRepository,
+		transactor:             mockTransactor,
+		channel:                channel,
+		impl:                   impl,
+	}
+}
@@ -0,0 +1,210 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/repository"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotificationEnqueueDue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	notifications := []entity.Notification{
+		{ID: uuid.New().String(), Kind: entity.NotificationKindDueSoon},
+		{ID: uuid.New().String(), Kind: entity.NotificationKindOverdue},
+	}
+
+	t.Run("due notifications are sent to outbox", func(t *testing.T) {
+		t.Parallel()
+		data := getNotificationData(t)
+		data.prepareDefaultTransactor()
+
+		data.notificationRepository.EXPECT().GetDueNotifications(gomock.Any(), 10).Return(notifications, nil)
+		for _, notification := range notifications {
+			data.outboxRepository.EXPECT().SendMessage(
+				gomock.Any(),
+				"notification_"+notification.ID,
+				repository.OutboxKindNotification,
+				gomock.Any(),
+			).Return(nil)
+		}
+
+		require.NoError(t, data.impl.enqueueDue(ctx, 10))
+	})
+	t.Run("outbox failure rolls back batch", func(t *testing.T) {
+		t.Parallel()
+		data := getNotificationData(t)
+		data.prepareDefaultTransactor()
+
+		data.notificationRepository.EXPECT().GetDueNotifications(gomock.Any(), 10).Return(notifications, nil)
+		data.outboxRepository.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New("error"))
+
+		require.Error(t, data.impl.enqueueDue(ctx, 10))
+	})
+}
+
+func TestNotificationHandle(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	book := entity.Book{
+		ID:   uuid.New().String(),
+		Name: "Book1",
+	}
+	notification := entity.Notification{
+		ID:       uuid.New().String(),
+		PatronID: uuid.New().String(),
+		BookID:   book.ID,
+		Kind:     entity.NotificationKindDueSoon,
+		DueAt:    time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	serialized, err := json.Marshal(notification)
+	require.NoError(t, err)
+
+	webhookDelivery, err := json.Marshal(delivery{Notification: notification, Channel: entity.NotificationChannelWebhook})
+	require.NoError(t, err)
+
+	emailDelivery, err := json.Marshal(delivery{Notification: notification, Channel: entity.NotificationChannelEmail})
+	require.NoError(t, err)
+
+	preferences := entity.NotificationPreferences{
+		PatronID:   notification.PatronID,
+		Channels:   []entity.NotificationChannel{entity.NotificationChannelEmail, entity.NotificationChannelWebhook},
+		WebhookURL: "https://example.com/hook",
+	}
+
+	tests := []struct {
+		testName     string
+		data         []byte
+		prepare      func(*notificationData)
+		wantErr      bool
+		wantMessages int
+	}{
+		{
+			testName: "notification is split by supported channels",
+			data:     serialized,
+			prepare: func(data *notificationData) {
+				data.notificationRepository.EXPECT().GetNotificationPreferences(ctx, notification.PatronID).Return(preferences, nil)
+				data.outboxRepository.EXPECT().SendMessage(
+					ctx,
+					"notification_"+notification.ID+"_webhook",
+					repository.OutboxKindNotification,
+					webhookDelivery,
+				).Return(nil)
+			},
+			wantMessages: 0,
+		},
+		{
+			testName: "split failure is retried by outbox",
+			data:     serialized,
+			prepare: func(data *notificationData) {
+				data.notificationRepository.EXPECT().GetNotificationPreferences(ctx, notification.PatronID).Return(preferences, nil)
+				data.outboxRepository.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			testName: "delivered through its channel",
+			data:     webhookDelivery,
+			prepare: func(data *notificationData) {
+				data.notificationRepository.EXPECT().GetNotificationPreferences(ctx, notification.PatronID).Return(preferences, nil)
+				data.bookRepository.EXPECT().GetBook(ctx, book.ID).Return(book, nil)
+			},
+			wantMessages: 1,
+		},
+		{
+			testName: "patron without preferences is skipped",
+			data:     webhookDelivery,
+			prepare: func(data *notificationData) {
+				data.notificationRepository.EXPECT().GetNotificationPreferences(ctx, notification.PatronID).
+					Return(entity.NotificationPreferences{}, entity.ErrNotificationPreferencesNotFound)
+			},
+			wantMessages: 0,
+		},
+		{
+			testName: "unsupported channel is skipped",
+			data:     emailDelivery,
+			prepare: func(data *notificationData) {
+				data.notificationRepository.EXPECT().GetNotificationPreferences(ctx, notification.PatronID).Return(preferences, nil)
+			},
+			wantMessages: 0,
+		},
+		{
+			testName: "missing book is dropped",
+			data:     webhookDelivery,
+			prepare: func(data *notificationData) {
+				data.notificationRepository.EXPECT().GetNotificationPreferences(ctx, notification.PatronID).Return(preferences, nil)
+				data.bookRepository.EXPECT().GetBook(ctx, book.ID).Return(entity.Book{}, entity.ErrBookNotFound)
+			},
+			wantMessages: 0,
+		},
+		{
+			testName: "channel failure is retried by outbox",
+			data:     webhookDelivery,
+			prepare: func(data *notificationData) {
+				data.channel.err = errors.New("error")
+				data.notificationRepository.EXPECT().GetNotificationPreferences(ctx, notification.PatronID).Return(preferences, nil)
+				data.bookRepository.EXPECT().GetBook(ctx, book.ID).Return(book, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Parallel()
+			data := getNotificationData(t)
+			tt.prepare(data)
+
+			err := data.impl.Handle(ctx, tt.data)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, data.channel.messages, tt.wantMessages)
+
+			for _, message := range data.channel.messages {
+				require.Equal(t, notification.ID, message.Notification.ID)
+				require.True(t, strings.Contains(message.Subject, book.Name))
+				require.True(t, strings.Contains(message.Body, "2026-05-01"))
+			}
+		})
+	}
+}
+
+func TestLoadTemplatesOverride(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	override := `{{define "subject"}}custom {{.BookName}}{{end}}{{define "body"}}custom body{{end}}`
+	err := os.WriteFile(filepath.Join(dir, "overdue.tmpl"), []byte(override), 0o600)
+	require.NoError(t, err)
+
+	templates, err := LoadTemplates(dir)
+	require.NoError(t, err)
+
+	message, err := templates.Render(entity.NotificationKindOverdue, TemplateData{BookName: "Book1"})
+	require.NoError(t, err)
+	require.Equal(t, "custom Book1", message.Subject)
+	require.Equal(t, "custom body", message.Body)
+
+	message, err = templates.Render(entity.NotificationKindHoldAvailable, TemplateData{BookName: "Book1"})
+	require.NoError(t, err)
+	require.Contains(t, message.Subject, "Book1")
+}
@@ -0,0 +1,95 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/project/library/internal/entity"
+)
+
+//go:embed templates/*.tmpl
+var embedTemplates embed.FS
+
+var templateKinds = []entity.NotificationKind{
+	entity.NotificationKindDueSoon,
+	entity.NotificationKindOverdue,
+	entity.NotificationKindHoldAvailable,
+}
+
+type TemplateData struct {
+	PatronID string
+	BookID   string
+	BookName string
+	DueAt    time.Time
+}
+
+type Templates struct {
+	byKind map[entity.NotificationKind]*template.Template
+}
+
+// LoadTemplates parses a "<kind>.tmpl" file for every notification kind.
+// Files found in dir override the embedded defaults; an empty dir means defaults only.
+func LoadTemplates(dir string) (*Templates, error) {
+	templates := &Templates{
+		byKind: make(map[entity.NotificationKind]*template.Template, len(templateKinds)),
+	}
+
+	for _, kind := range templateKinds {
+		name := kind.String() + ".tmpl"
+
+		var (
+			content []byte
+			err     error
+		)
+
+		if dir != "" {
+			content, err = os.ReadFile(filepath.Join(dir, name))
+		}
+
+		if dir == "" || os.IsNotExist(err) {
+			content, err = embedTemplates.ReadFile("templates/" + name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot read template %s: %w", name, err)
+		}
+
+		tmpl, err := template.New(name).Parse(string(content))
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse template %s: %w", name, err)
+		}
+
+		templates.byKind[kind] = tmpl
+	}
+
+	return templates, nil
+}
+
+func (t *Templates) Render(kind entity.NotificationKind, data TemplateData) (Message, error) {
+	tmpl, ok := t.byKind[kind]
+
+	if !ok {
+		return Message{}, fmt.Errorf("no template for notification kind: %s", kind)
+	}
+
+	var subject, body bytes.Buffer
+
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, fmt.Errorf("cannot render subject: %w", err)
+	}
+
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, fmt.Errorf("cannot render body: %w", err)
+	}
+
+	return Message{
+		Subject: subject.String(),
+		Body:    body.String(),
+	}, nil
+}
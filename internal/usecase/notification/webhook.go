@@ -0,0 +1,129 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ Channel = (*webhookChannel)(nil)
+
+type webhookChannel struct {
+	client *http.Client
+}
+
+type webhookPayload struct {
+	NotificationID string     `json:"notification_id"`
+	PatronID       string     `json:"patron_id"`
+	BookID         string     `json:"book_id"`
+	Kind           string     `json:"kind"`
+	DueAt          *time.Time `json:"due_at,omitempty"`
+	Subject        string     `json:"subject"`
+	Body           string     `json:"body"`
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not covered by netip.Addr.IsPrivate.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func NewWebhookChannel(client *http.Client) *webhookChannel {
+	return &webhookChannel{
+		client: client,
+	}
+}
+
+func (w *webhookChannel) Send(ctx context.Context, preferences entity.NotificationPreferences, message Message) error {
+	if preferences.WebhookURL == "" {
+		return errors.New("patron has no webhook url")
+	}
+
+	target, err := url.Parse(preferences.WebhookURL)
+
+	if err != nil {
+		return fmt.Errorf("cannot parse webhook url: %w", err)
+	}
+
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return fmt.Errorf("unsupported webhook url scheme %q", target.Scheme)
+	}
+
+	var dueAt *time.Time
+	if !message.Notification.DueAt.IsZero() {
+		dueAt = &message.Notification.DueAt
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		NotificationID: message.Notification.ID,
+		PatronID:       message.Notification.PatronID,
+		BookID:         message.Notification.BookID,
+		Kind:           message.Notification.Kind.String(),
+		DueAt:          dueAt,
+		Subject:        message.Subject,
+		Body:           message.Body,
+	})
+
+	if err != nil {
+		return fmt.Errorf("cannot serialize webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, preferences.WebhookURL, bytes.NewReader(payload))
+
+	if err != nil {
+		return fmt.Errorf("cannot create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+
+	if err != nil {
+		return fmt.Errorf("cannot send request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// RefusePrivateAddress is a net.Dialer Control hook for the webhook client.
+// It runs after name resolution, so a patron can not point a webhook at
+// loopback, private or link-local addresses, neither directly nor through DNS or redirects.
+func RefusePrivateAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return fmt.Errorf("cannot parse webhook address: %w", err)
+	}
+
+	ip, err := netip.ParseAddr(host)
+
+	if err != nil {
+		return fmt.Errorf("cannot parse webhook address: %w", err)
+	}
+
+	ip = ip.Unmap()
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("webhook address %s is not public", ip)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookChannelSend(t *testing.T) {
+	t.Parallel()
+
+	notification := entity.Notification{
+		ID:       uuid.New().String(),
+		PatronID: uuid.New().String(),
+		BookID:   uuid.New().String(),
+		Kind:     entity.NotificationKindHoldAvailable,
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "delivered",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "receiver failure",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var received map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+				w.WriteHeader(tt.statusCode)
+			}))
+			t.Cleanup(server.Close)
+
+			channel := NewWebhookChannel(server.Client())
+			err := channel.Send(context.Background(), entity.NotificationPreferences{
+				WebhookURL: server.URL,
+			}, Message{
+				Notification: notification,
+				Subject:      "Hold ready",
+				Body:         "Come pick it up",
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, notification.ID, received["notification_id"])
+			require.Equal(t, "hold_available", received["kind"])
+			require.Equal(t, "Hold ready", received["subject"])
+			require.NotContains(t, received, "due_at")
+		})
+	}
+}
+
+func TestWebhookChannelUnsupportedScheme(t *testing.T) {
+	t.Parallel()
+
+	channel := NewWebhookChannel(http.DefaultClient)
+	err := channel.Send(context.Background(), entity.NotificationPreferences{
+		WebhookURL: "file:///etc/passwd",
+	}, Message{})
+	require.Error(t, err)
+}
+
+func TestWebhookChannelRefusesPrivateAddress(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	dialer := &net.Dialer{Control: RefusePrivateAddress}
+	client := &http.Client{Transport: &http.Transport{DialContext: dialer.DialContext}}
+
+	channel := NewWebhookChannel(client)
+	err := channel.Send(context.Background(), entity.NotificationPreferences{
+		WebhookURL: server.URL,
+	}, Message{})
+	require.ErrorContains(t, err, "is not public")
+}
+
+func TestRefusePrivateAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address string
+		wantErr bool
+	}{
+		{address: "93.184.216.34:443"},
+		{address: "[2606:4700::6810:85e5]:443"},
+		{address: "127.0.0.1:80", wantErr: true},
+		{address: "10.1.2.3:80", wantErr: true},
+		{address: "192.168.0.10:80", wantErr: true},
+		{address: "169.254.169.254:80", wantErr: true},
+		{address: "100.64.0.1:80", wantErr: true},
+		{address: "0.0.0.0:80", wantErr: true},
+		{address: "[::1]:80", wantErr: true},
+		{address: "[::ffff:127.0.0.1]:80", wantErr: true},
+		{address: "[fd00::1]:80", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			t.Parallel()
+
+			err := RefusePrivateAddress("tcp", tt.address, nil)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
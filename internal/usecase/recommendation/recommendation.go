@@ -0,0 +1,104 @@
+package recommendation
+
+import (
+	"context"
+	"time"
+
+	"github.com/project/library/internal/entity"
+	"github.com/project/library/internal/usecase/repository"
+	"go.uber.org/zap"
+)
+
+// DefaultWeights favours books of the same authors over books on the same subjects,
+// with co-borrowing as the weakest signal.
+var DefaultWeights = entity.RecommendationWeights{
+	SharedAuthor:  3,
+	SharedSubject: 2,
+	CoBorrowed:    1,
+}
+
+type Refresher interface {
+	Start(ctx context.Context, runAt time.Duration) error
+	Refresh(ctx context.Context) error
+}
+
+var _ Refresher = (*refresherImpl)(nil)
+
+type refresherImpl struct {
+	logger                   *zap.Logger
+	recommendationRepository repository.RecommendationRepository
+	transactor               repository.Transactor
+	topN                     int
+	weights                  entity.RecommendationWeights
+}
+
+func New(
+	logger *zap.Logger,
+	recommendationRepository repository.RecommendationRepository,
+	transactor repository.Transactor,
+	topN int,
+	weights entity.RecommendationWeights,
+) *refresherImpl {
+	return &refresherImpl{
+		logger:                   logger,
+		recommendationRepository: recommendationRepository,
+		transactor:               transactor,
+		topN:                     topN,
+		weights:                  weights,
+	}
+}
+
+// Start refreshes recommendations once a day at runAt past midnight UTC.
+func (r *refresherImpl) Start(ctx context.Context, runAt time.Duration) error {
+	go r.worker(ctx, runAt)
+
+	return nil
+}
+
+func (r *refresherImpl) worker(ctx context.Context, runAt time.Duration) {
+	for {
+		timer := time.NewTimer(time.Until(nextRun(time.Now(), runAt)))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := r.Refresh(ctx); err != nil {
+			r.logger.Error("recommendation worker error", zap.Error(err))
+		}
+	}
+}
+
+// nextRun returns the first moment after now that is runAt past midnight UTC.
+func nextRun(now time.Time, runAt time.Duration) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Add(runAt)
+
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
+
+// Refresh replaces the precomputed neighbours of the books changed since the previous run
+// in a single transaction, so RecommendBooks keeps serving the previous result until the new one is committed.
+func (r *refresherImpl) Refresh(ctx context.Context) error {
+	start := time.Now()
+
+	err := r.transactor.WithTx(ctx, func(ctx context.Context) error {
+		return r.recommendationRepository.RefreshRecommendations(ctx, r.topN, r.weights)
+	})
+
+	if err != nil {
+		r.logger.Error("cannot refresh recommendations", zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("recommendations refreshed", zap.Duration("took", time.Since(start)))
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package recommendation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/usecase/repository/mocks"
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestNextRun(t *testing.T) {
+	t.Parallel()
+	runAt := 3 * time.Hour
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "later today",
+			now:  time.Date(2026, 7, 1, 1, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 7, 1, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "tomorrow",
+			now:  time.Date(2026, 7, 1, 5, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 7, 2, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "exactly at run time",
+			now:  time.Date(2026, 7, 1, 3, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 7, 2, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "end of month",
+			now:  time.Date(2026, 7, 31, 23, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 8, 1, 3, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, nextRun(tt.now, runAt))
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for _, wantErr := range []error{nil, errors.New("refresh failed")} {
+		ctrl := gomock.NewController(t)
+		recommendationRepository := mocks.NewMockRecommendationRepository(ctrl)
+		transactor := mocks.NewMockTransactor(ctrl)
+
+		transactor.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, x func(context.Context) error) error {
+			return x(ctx)
+		})
+		recommendationRepository.EXPECT().RefreshRecommendations(ctx, 5, DefaultWeights).Return(wantErr)
+
+		impl := New(zap.NewNop(), recommendationRepository, transactor, 5, DefaultWeights)
+		err := impl.Refresh(ctx)
+
+		if wantErr != nil {
+			require.ErrorIs(t, err, wantErr)
+		} else {
+			require.NoError(t, err)
+		}
+
+		ctrl.Finish()
+	}
+}
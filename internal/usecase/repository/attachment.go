@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ AttachmentRepository = (*attachmentRepository)(nil)
+
+type attachmentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAttachmentRepository(db *pgxpool.Pool) *attachmentRepository {
+	return &attachmentRepository{
+		db: db,
+	}
+}
+
+var attachmentKinds = map[entity.AttachmentKind]string{
+	entity.AttachmentKindCover: "COVER",
+	entity.AttachmentKindFile:  "FILE",
+}
+
+func parseAttachmentKind(kind string) entity.AttachmentKind {
+	for key, value := range attachmentKinds {
+		if value == kind {
+			return key
+		}
+	}
+
+	return entity.AttachmentKindUndefined
+}
+
+const attachmentColumns = `id, book_id, kind, name, content_type, size, blob_key, thumbnail_key, created_at`
+
+func scanAttachment(row pgx.Row) (entity.Attachment, error) {
+	var (
+		attachment entity.Attachment
+		kind       string
+	)
+
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.BookID,
+		&kind,
+		&attachment.Name,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.BlobKey,
+		&attachment.ThumbnailKey,
+		&attachment.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Attachment{}, entity.ErrAttachmentNotFound
+	}
+
+	if err != nil {
+		return entity.Attachment{}, err
+	}
+
+	attachment.Kind = parseAttachmentKind(kind)
+
+	return attachment, nil
+}
+
+func (a *attachmentRepository) CreateAttachment(ctx context.Context, attachment entity.Attachment) (entity.Attachment, error) {
+	const query = `INSERT INTO book_attachment (id, book_id, kind, name, content_type, size, blob_key, thumbnail_key)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+					RETURNING ` + attachmentColumns
+
+	result, err := scanAttachment(getQuerier(ctx, a.db).QueryRow(ctx, query,
+		attachment.ID,
+		attachment.BookID,
+		attachmentKinds[attachment.Kind],
+		attachment.Name,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.BlobKey,
+		attachment.ThumbnailKey,
+	))
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == errForeignKeyViolation {
+		return entity.Attachment{}, fmt.Errorf("book does not exist: %w", entity.ErrBookNotFound)
+	}
+
+	return result, err
+}
+
+func (a *attachmentRepository) GetAttachment(ctx context.Context, id string) (entity.Attachment, error) {
+	const query = `SELECT ` + attachmentColumns + ` FROM book_attachment WHERE id = $1`
+
+	return scanAttachment(getQuerier(ctx, a.db).QueryRow(ctx, query, id))
+}
+
+// ListAttachments returns the cover first, then the files in upload order.
+func (a *attachmentRepository) ListAttachments(ctx context.Context, bookID string) ([]entity.Attachment, error) {
+	const query = `SELECT ` + attachmentColumns + ` FROM book_attachment
+					WHERE book_id = $1
+					ORDER BY kind, created_at, id`
+
+	rows, err := getQuerier(ctx, a.db).Query(ctx, query, bookID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.Attachment, 0)
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, attachment)
+	}
+
+	return result, rows.Err()
+}
+
+func (a *attachmentRepository) DeleteAttachment(ctx context.Context, id string) error {
+	const query = `DELETE FROM book_attachment WHERE id = $1`
+
+	tag, err := getQuerier(ctx, a.db).Exec(ctx, query, id)
+
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+// DeleteCover removes the current cover of the book if there is one.
+func (a *attachmentRepository) DeleteCover(ctx context.Context, bookID string) error {
+	const query = `DELETE FROM book_attachment WHERE book_id = $1 AND kind = 'COVER'`
+
+	_, err := getQuerier(ctx, a.db).Exec(ctx, query, bookID)
+
+	return err
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project/library/internal/entity"
+)
+
+var _ AuditRepository = (*auditRepository)(nil)
+
+type auditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) *auditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+// actorSetting is the session setting the audit trigger reads the actor from.
+const actorSetting = "library.actor"
+
+// SetActor is a pgxpool BeforeAcquire hook that passes the actor of ctx to the connection,
+// so that the audit trigger records it with the changes of any query made under ctx.
+// The setting is only written when the actor differs from the one the connection already has.
+func SetActor(ctx context.Context, conn *pgx.Conn) bool {
+	actor := entity.ActorFromContext(ctx)
+	data := conn.PgConn().CustomData()
+
+	if current, _ := data[actorSetting].(string); current == actor {
+		return true
+	}
+
+	if _, err := conn.Exec(ctx, `SELECT set_config($1, $2, false)`, actorSetting, actor); err != nil {
+		return false
+	}
+
+	data[actorSetting] = actor
+
+	return true
+}
+
+const auditEventColumns = `id, actor, entity, entity_id, action, before, after, created_at`
+
+func (a *auditRepository) ListAuditEvents(
+	ctx context.Context,
+	filter entity.AuditFilter,
+	after *entity.PageCursor,
+	limit int,
+) ([]entity.AuditEvent, error) {
+	conditions := make([]string, 0, 6)
+	args := make([]any, 0, 8)
+
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if filter.Entity != "" {
+		conditions = append(conditions, "entity = "+arg(filter.Entity))
+	}
+
+	if filter.EntityID != "" {
+		conditions = append(conditions, "entity_id = "+arg(filter.EntityID))
+	}
+
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = "+arg(filter.Actor))
+	}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= "+arg(filter.From))
+	}
+
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < "+arg(filter.To))
+	}
+
+	if after != nil {
+		conditions = append(conditions, "(created_at, id) > ("+arg(after.CreatedAt)+", "+arg(after.ID)+")")
+	}
+
+	query := `SELECT ` + auditEventColumns + ` FROM audit_event`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	query += ` ORDER BY created_at, id LIMIT ` + arg(limit)
+
+	rows, err := getQuerier(ctx, a.db).Query(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.AuditEvent, 0, limit)
+	for rows.Next() {
+		var (
+			event  entity.AuditEvent
+			action string
+		)
+
+		err := rows.Scan(
+			&event.ID,
+			&event.Actor,
+			&event.Entity,
+			&event.EntityID,
+			&action,
+			&event.Before,
+			&event.After,
+			&event.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		event.Action = parseChangeKind(action)
+		result = append(result, event)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ BlobStore = (*localBlobStore)(nil)
+
+// localBlobStore keeps blobs as files under dir, keys are slash separated relative paths.
+type localBlobStore struct {
+	dir string
+}
+
+func NewLocalBlobStore(dir string) *localBlobStore {
+	return &localBlobStore{
+		dir: dir,
+	}
+}
+
+func (l *localBlobStore) path(key string) (string, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes the blob into a temporary file first, so that readers never see a partial blob.
+func (l *localBlobStore) Put(_ context.Context, key string, data io.Reader, size int64, _ string) error {
+	path, err := l.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".blob-*")
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	written, err := io.Copy(file, data)
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if size >= 0 && written != size {
+		return fmt.Errorf("blob %q: written %d bytes instead of %d", key, written, size)
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (l *localBlobStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, entity.ErrBlobNotFound
+	}
+
+	return file, err
+}
+
+func (l *localBlobStore) Delete(_ context.Context, key string) error {
+	path, err := l.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
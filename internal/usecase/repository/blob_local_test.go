@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := NewLocalBlobStore(t.TempDir())
+
+	require.NoError(t, store.Put(ctx, "books/1/cover", strings.NewReader("image"), 5, "image/png"))
+
+	data, err := store.Get(ctx, "books/1/cover")
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(data)
+	require.NoError(t, err)
+	require.NoError(t, data.Close())
+	require.Equal(t, "image", string(content))
+
+	require.Error(t, store.Put(ctx, "books/1/file", strings.NewReader("short"), 10, "text/plain"))
+	_, err = store.Get(ctx, "books/1/file")
+	require.ErrorIs(t, err, entity.ErrBlobNotFound)
+
+	require.NoError(t, store.Delete(ctx, "books/1/cover"))
+	require.NoError(t, store.Delete(ctx, "books/1/cover"))
+
+	_, err = store.Get(ctx, "books/1/cover")
+	require.ErrorIs(t, err, entity.ErrBlobNotFound)
+
+	for _, key := range []string{"", "../outside", "/absolute", "books//cover"} {
+		require.Error(t, store.Put(ctx, key, strings.NewReader(""), 0, "text/plain"), key)
+	}
+}
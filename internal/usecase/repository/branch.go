@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ BranchRepository = (*branchRepository)(nil)
+
+type branchRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBranchRepository(db *pgxpool.Pool) *branchRepository {
+	return &branchRepository{
+		db: db,
+	}
+}
+
+var (
+	copyStatuses = map[entity.CopyStatus]string{
+		entity.CopyStatusAvailable:  "AVAILABLE",
+		entity.CopyStatusInTransit:  "IN_TRANSIT",
+		entity.CopyStatusCheckedOut: "CHECKED_OUT",
+	}
+	transferStatuses = map[entity.TransferStatus]string{
+		entity.TransferStatusInTransit: "IN_TRANSIT",
+		entity.TransferStatusReceived:  "RECEIVED",
+	}
+)
+
+func parseCopyStatus(status string) entity.CopyStatus {
+	for key, value := range copyStatuses {
+		if value == status {
+			return key
+		}
+	}
+
+	return entity.CopyStatusUndefined
+}
+
+func parseTransferStatus(status string) entity.TransferStatus {
+	for key, value := range transferStatuses {
+		if value == status {
+			return key
+		}
+	}
+
+	return entity.TransferStatusUndefined
+}
+
+func getBranchError(err error) error {
+	var pgErr *pgconn.PgError
+
+	if !errors.As(err, &pgErr) || pgErr.Code != errForeignKeyViolation {
+		return err
+	}
+
+	switch pgErr.ConstraintName {
+	case "book_copy_book_id_fkey", "loan_book_id_fkey":
+		return fmt.Errorf("book does not exist: %w", entity.ErrBookNotFound)
+	case "loan_patron_id_fkey":
+		return fmt.Errorf("patron does not exist: %w", entity.ErrPatronNotFound)
+	case "transfer_copy_id_fkey", "loan_copy_id_fkey":
+		return fmt.Errorf("book copy does not exist: %w", entity.ErrCopyNotFound)
+	default:
+		return fmt.Errorf("branch does not exist: %w", entity.ErrBranchNotFound)
+	}
+}
+
+func (b *branchRepository) CreateBranch(ctx context.Context, branch entity.Branch) (entity.Branch, error) {
+	const query = `INSERT INTO branch (name, address) VALUES ($1, $2) RETURNING id`
+
+	result := branch
+	err := getQuerier(ctx, b.db).QueryRow(ctx, query, branch.Name, branch.Address).Scan(&result.ID)
+
+	if err != nil {
+		return entity.Branch{}, err
+	}
+
+	return result, nil
+}
+
+func (b *branchRepository) GetBranch(ctx context.Context, id string) (entity.Branch, error) {
+	const query = `SELECT id, name, address FROM branch WHERE id = $1`
+
+	var branch entity.Branch
+	err := getQuerier(ctx, b.db).QueryRow(ctx, query, id).Scan(&branch.ID, &branch.Name, &branch.Address)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Branch{}, entity.ErrBranchNotFound
+	}
+
+	if err != nil {
+		return entity.Branch{}, err
+	}
+
+	return branch, nil
+}
+
+func (b *branchRepository) CreatePatron(ctx context.Context, patron entity.Patron) (entity.Patron, error) {
+	const query = `INSERT INTO patron (name, home_branch_id) VALUES ($1, $2) RETURNING id`
+
+	result := patron
+	err := getQuerier(ctx, b.db).QueryRow(ctx, query, patron.Name, patron.HomeBranchID).Scan(&result.ID)
+
+	if err != nil {
+		return entity.Patron{}, getBranchError(err)
+	}
+
+	return result, nil
+}
+
+func (b *branchRepository) GetPatron(ctx context.Context, id string) (entity.Patron, error) {
+	const query = `SELECT id, name, home_branch_id FROM patron WHERE id = $1`
+
+	var patron entity.Patron
+	err := getQuerier(ctx, b.db).QueryRow(ctx, query, id).Scan(&patron.ID, &patron.Name, &patron.HomeBranchID)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Patron{}, entity.ErrPatronNotFound
+	}
+
+	if err != nil {
+		return entity.Patron{}, err
+	}
+
+	return patron, nil
+}
+
+func (b *branchRepository) CreateCopy(ctx context.Context, bookCopy entity.BookCopy) (entity.BookCopy, error) {
+	const query = `INSERT INTO book_copy (book_id, branch_id, status) VALUES ($1, $2, $3) RETURNING id`
+
+	result := bookCopy
+	err := getQuerier(ctx, b.db).QueryRow(ctx, query, bookCopy.BookID, bookCopy.BranchID, copyStatuses[bookCopy.Status]).Scan(&result.ID)
+
+	if err != nil {
+		return entity.BookCopy{}, getBranchError(err)
+	}
+
+	return result, nil
+}
+
+func (b *branchRepository) GetCopyForUpdate(ctx context.Context, id string) (entity.BookCopy, error) {
+	const query = `SELECT id, book_id, branch_id, status FROM book_copy WHERE id = $1 FOR UPDATE`
+
+	var (
+		bookCopy entity.BookCopy
+		status   string
+	)
+
+	err := getQuerier(ctx, b.db).QueryRow(ctx, query, id).Scan(&bookCopy.ID, &bookCopy.BookID, &bookCopy.BranchID, &status)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.BookCopy{}, entity.ErrCopyNotFound
+	}
+
+	if err != nil {
+		return entity.BookCopy{}, err
+	}
+
+	bookCopy.Status = parseCopyStatus(status)
+
+	return bookCopy, nil
+}
+
+func (b *branchRepository) UpdateCopy(ctx context.Context, bookCopy entity.BookCopy) error {
+	const query = `UPDATE book_copy SET branch_id = $2, status = $3 WHERE id = $1`
+
+	res, err := getQuerier(ctx, b.db).Exec(ctx, query, bookCopy.ID, bookCopy.BranchID, copyStatuses[bookCopy.Status])
+
+	if err != nil {
+		return getBranchError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return entity.ErrCopyNotFound
+	}
+
+	return nil
+}
+
+func (b *branchRepository) CreateTransfer(ctx context.Context, transfer entity.Transfer) (entity.Transfer, error) {
+	const query = `INSERT INTO transfer (copy_id, from_branch_id, to_branch_id, status)
+					VALUES ($1, $2, $3, $4)
+					RETURNING id, created_at`
+
+	result := transfer
+	err := getQuerier(ctx, b.db).QueryRow(
+		ctx,
+		query,
+		transfer.CopyID,
+		transfer.FromBranchID,
+		transfer.ToBranchID,
+		transferStatuses[transfer.Status],
+	).Scan(&result.ID, &result.CreatedAt)
+
+	if err != nil {
+		return entity.Transfer{}, getBranchError(err)
+	}
+
+	return result, nil
+}
+
+const transferColumns = `id, copy_id, from_branch_id, to_branch_id, status, created_at, received_at`
+
+func scanTransfer(row pgx.Row) (entity.Transfer, error) {
+	var (
+		transfer   entity.Transfer
+		status     string
+		receivedAt *time.Time
+	)
+
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.CopyID,
+		&transfer.FromBranchID,
+		&transfer.ToBranchID,
+		&status,
+		&transfer.CreatedAt,
+		&receivedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Transfer{}, entity.ErrTransferNotFound
+	}
+
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	transfer.Status = parseTransferStatus(status)
+
+	if receivedAt != nil {
+		transfer.ReceivedAt = *receivedAt
+	}
+
+	return transfer, nil
+}
+
+func (b *branchRepository) GetTransferForUpdate(ctx context.Context, id string) (entity.Transfer, error) {
+	const query = `SELECT ` + transferColumns + ` FROM transfer WHERE id = $1 FOR UPDATE`
+
+	return scanTransfer(getQuerier(ctx, b.db).QueryRow(ctx, query, id))
+}
+
+func (b *branchRepository) MarkTransferReceived(ctx context.Context, id string) (entity.Transfer, error) {
+	const query = `UPDATE transfer SET status = 'RECEIVED', received_at = now()
+					WHERE id = $1
+					RETURNING ` + transferColumns
+
+	return scanTransfer(getQuerier(ctx, b.db).QueryRow(ctx, query, id))
+}
+
+const loanColumns = `id, copy_id, book_id, patron_id, borrowed_at, returned_at`
+
+func scanLoan(row pgx.Row) (entity.Loan, error) {
+	var (
+		loan       entity.Loan
+		returnedAt *time.Time
+	)
+
+	err := row.Scan(&loan.ID, &loan.CopyID, &loan.BookID, &loan.PatronID, &loan.BorrowedAt, &returnedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Loan{}, entity.ErrCopyNotCheckedOut
+	}
+
+	if err != nil {
+		return entity.Loan{}, err
+	}
+
+	if returnedAt != nil {
+		loan.ReturnedAt = *returnedAt
+	}
+
+	return loan, nil
+}
+
+func (b *branchRepository) CreateLoan(ctx context.Context, loan entity.Loan) (entity.Loan, error) {
+	const query = `INSERT INTO loan (copy_id, book_id, patron_id) VALUES ($1, $2, $3) RETURNING ` + loanColumns
+
+	result, err := scanLoan(getQuerier(ctx, b.db).QueryRow(ctx, query, loan.CopyID, loan.BookID, loan.PatronID))
+
+	if err != nil {
+		return entity.Loan{}, getBranchError(err)
+	}
+
+	return result, nil
+}
+
+func (b *branchRepository) CloseLoan(ctx context.Context, copyID string) (entity.Loan, error) {
+	const query = `UPDATE loan SET returned_at = now()
+					WHERE copy_id = $1 AND returned_at IS NULL
+					RETURNING ` + loanColumns
+
+	return scanLoan(getQuerier(ctx, b.db).QueryRow(ctx, query, copyID))
+}
+
+func (b *branchRepository) GetBookAvailability(
+	ctx context.Context,
+	bookID string,
+	branchID string,
+) ([]entity.BranchAvailability, error) {
+	const query = `SELECT branch_id,
+						count(*) FILTER (WHERE status = 'AVAILABLE'),
+						count(*) FILTER (WHERE status = 'IN_TRANSIT')
+					FROM book_copy
+					WHERE book_id = $1 AND ($2 = '' OR branch_id::text = $2)
+					GROUP BY branch_id
+					ORDER BY branch_id`
+
+	rows, err := getQuerier(ctx, b.db).Query(ctx, query, bookID, branchID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.BranchAvailability, 0)
+	for rows.Next() {
+		var availability entity.BranchAvailability
+
+		if err := rows.Scan(&availability.BranchID, &availability.Available, &availability.InTransit); err != nil {
+			return nil, err
+		}
+
+		result = append(result, availability)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,326 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project/library/internal/entity"
+)
+
+var _ CatalogRepository = (*postgresRepository)(nil)
+
+type copier interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// getCopier returns the transaction stored in ctx, falling back to the pool.
+func getCopier(ctx context.Context, pool *pgxpool.Pool) copier {
+	if tx, err := extractTX(ctx); err == nil {
+		return tx
+	}
+
+	return pool
+}
+
+// GetAuthorsByNames returns one author per known name, the oldest id wins
+// because author names are not unique.
+func (p postgresRepository) GetAuthorsByNames(ctx context.Context, names []string) ([]entity.Author, error) {
+	const query = `SELECT DISTINCT ON (name) id, name FROM author
+					WHERE name = ANY($1) AND deleted_at IS NULL
+					ORDER BY name, id`
+
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, names)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.Author, 0, len(names))
+	for rows.Next() {
+		var author entity.Author
+
+		if err = rows.Scan(&author.ID, &author.Name); err != nil {
+			return nil, err
+		}
+
+		result = append(result, author)
+	}
+
+	return result, rows.Err()
+}
+
+func (p postgresRepository) CreateAuthors(ctx context.Context, names []string) ([]entity.Author, error) {
+	result := make([]entity.Author, len(names))
+	rows := make([][]any, len(names))
+	for i, name := range names {
+		result[i] = entity.Author{
+			ID:   uuid.NewString(),
+			Name: name,
+		}
+		rows[i] = []any{result[i].ID, result[i].Name}
+	}
+
+	_, err := getCopier(ctx, p.db).CopyFrom(ctx, pgx.Identifier{"author"}, []string{"id", "name"}, pgx.CopyFromRows(rows))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateBooks copies books and their author links, it must run in a transaction
+// so that a failed link does not leave books without authors.
+func (p postgresRepository) CreateBooks(ctx context.Context, books []entity.Book) ([]entity.Book, error) {
+	result := make([]entity.Book, len(books))
+	bookRows := make([][]any, len(books))
+	authorBookRows := make([][]any, 0, len(books))
+	for i, book := range books {
+		result[i] = book
+		result[i].ID = uuid.NewString()
+		bookRows[i] = []any{result[i].ID, book.Name, book.ISBN, book.Publisher, book.PublicationYear}
+
+		for position, authorID := range book.AuthorIDs {
+			authorBookRows = append(authorBookRows, []any{authorID, result[i].ID, position})
+		}
+	}
+
+	c := getCopier(ctx, p.db)
+
+	bookColumns := []string{"id", "name", "isbn", "publisher", "publication_year"}
+	_, err := c.CopyFrom(ctx, pgx.Identifier{"book"}, bookColumns, pgx.CopyFromRows(bookRows))
+
+	if err != nil {
+		return nil, err
+	}
+
+	authorBookColumns := []string{"author_id", "book_id", "position"}
+	_, err = c.CopyFrom(ctx, pgx.Identifier{"author_book"}, authorBookColumns, pgx.CopyFromRows(authorBookRows))
+
+	if err != nil {
+		return nil, getError(err)
+	}
+
+	return result, nil
+}
+
+const catalogBookColumns = `book.id, book.name, book.created_at, book.updated_at,
+						book.isbn, book.publisher, book.publication_year,
+						COALESCE(book.work_id::text, ''), book.language, book.translator,
+						array_remove(array_agg(author.id ORDER BY author_book.position), NULL),
+						array_remove(array_agg(author.name ORDER BY author_book.position), NULL)`
+
+const catalogBookAuthorsJoin = `LEFT JOIN live_author_book AS author_book ON author_book.book_id = book.id AND author_book.role = 'AUTHOR'
+						LEFT JOIN author ON author.id = author_book.author_id`
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func scanCatalogBooks(rows pgx.Rows, capacity int) ([]entity.CatalogBook, error) {
+	defer rows.Close()
+
+	result := make([]entity.CatalogBook, 0, capacity)
+	for rows.Next() {
+		var book entity.CatalogBook
+
+		if err := rows.Scan(
+			&book.Book.ID,
+			&book.Book.Name,
+			&book.Book.CreatedAt,
+			&book.Book.UpdatedAt,
+			&book.Book.ISBN,
+			&book.Book.Publisher,
+			&book.Book.PublicationYear,
+			&book.Book.WorkID,
+			&book.Book.Language,
+			&book.Book.Translator,
+			&book.Book.AuthorIDs,
+			&book.AuthorNames,
+		); err != nil {
+			return nil, err
+		}
+
+		result = append(result, book)
+	}
+
+	return result, rows.Err()
+}
+
+// GetCatalogBooks returns the books with their author names, in the order of bookIDs.
+// Unknown ids are skipped.
+func (p postgresRepository) GetCatalogBooks(ctx context.Context, bookIDs []string) ([]entity.CatalogBook, error) {
+	const query = `SELECT ` + catalogBookColumns + `
+					FROM unnest($1::uuid[]) WITH ORDINALITY AS requested(id, position)
+						JOIN book ON book.id = requested.id AND book.deleted_at IS NULL
+						` + catalogBookAuthorsJoin + `
+					GROUP BY requested.position, book.id
+					ORDER BY requested.position`
+
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, bookIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCatalogBooks(rows, len(bookIDs))
+}
+
+// ListCatalogBooks returns a batch of books matching the filter ordered by id,
+// export walks the catalog with afterID taken from the last book of the previous batch.
+func (p postgresRepository) ListCatalogBooks(
+	ctx context.Context,
+	filter entity.CatalogFilter,
+	afterID string,
+	limit int,
+) ([]entity.CatalogBook, error) {
+	conditions := []string{"book.deleted_at IS NULL"}
+	args := make([]any, 0, 7)
+
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if filter.AuthorID != "" {
+		conditions = append(conditions,
+			"book.id IN (SELECT book_id FROM live_author_book WHERE role = 'AUTHOR' AND author_id = "+arg(filter.AuthorID)+")")
+	}
+
+	if filter.NameQuery != "" {
+		conditions = append(conditions, "book.name ILIKE '%' || "+arg(likeEscaper.Replace(filter.NameQuery))+" || '%'")
+	}
+
+	if filter.Subject != "" {
+		conditions = append(conditions,
+			"book.id IN (SELECT book_subject.book_id FROM book_subject"+
+				" JOIN book AS subject_book ON subject_book.id = book_subject.book_id AND subject_book.deleted_at IS NULL"+
+				" WHERE book_subject.subject = "+arg(filter.Subject)+")")
+	}
+
+	if filter.PublicationYearFrom != 0 {
+		conditions = append(conditions, "book.publication_year >= "+arg(filter.PublicationYearFrom))
+	}
+
+	if filter.PublicationYearTo != 0 {
+		conditions = append(conditions, "book.publication_year <= "+arg(filter.PublicationYearTo))
+	}
+
+	if filter.CollapseWorks {
+		// The representative edition is picked before paging, so that a work
+		// does not show up again on a later page with another edition.
+		editions := `SELECT DISTINCT ON (COALESCE(book.work_id, book.id)) book.id FROM book`
+		editions += ` WHERE ` + strings.Join(conditions, " AND ")
+		editions += ` ORDER BY COALESCE(book.work_id, book.id), book.id`
+		conditions = []string{"book.id IN (" + editions + ")"}
+	}
+
+	if afterID != "" {
+		conditions = append(conditions, "book.id > "+arg(afterID))
+	}
+
+	query := `SELECT ` + catalogBookColumns + ` FROM book ` + catalogBookAuthorsJoin
+	query += ` WHERE ` + strings.Join(conditions, " AND ")
+	query += ` GROUP BY book.id ORDER BY book.id LIMIT ` + arg(limit)
+
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCatalogBooks(rows, limit)
+}
+
+// ListNewestBooks pages through books from the most recently added,
+// afterID is the last book of the previous page.
+func (p postgresRepository) ListNewestBooks(ctx context.Context, afterID string, limit int) ([]entity.CatalogBook, error) {
+	args := []any{limit}
+	query := `SELECT ` + catalogBookColumns + ` FROM book ` + catalogBookAuthorsJoin + ` WHERE book.deleted_at IS NULL`
+
+	if afterID != "" {
+		args = append(args, afterID)
+		query += ` AND (book.created_at, book.id) < (SELECT created_at, id FROM book WHERE id = $2)`
+	}
+
+	query += ` GROUP BY book.id ORDER BY book.created_at DESC, book.id DESC LIMIT $1`
+
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCatalogBooks(rows, limit)
+}
+
+// ListCatalogAuthors pages through authors in alphabetical order, afterID is the last author of the previous page.
+func (p postgresRepository) ListCatalogAuthors(ctx context.Context, afterID string, limit int) ([]entity.CatalogAuthor, error) {
+	args := []any{limit}
+	query := `SELECT author.id, author.name, count(author_book.book_id)
+				FROM author LEFT JOIN author_book ON author_book.author_id = author.id AND author_book.role = 'AUTHOR'
+					AND author_book.book_id IN (SELECT id FROM book WHERE deleted_at IS NULL)
+				WHERE author.deleted_at IS NULL`
+
+	if afterID != "" {
+		args = append(args, afterID)
+		query += ` AND (author.name, author.id) > (SELECT name, id FROM author WHERE id = $2)`
+	}
+
+	query += ` GROUP BY author.id ORDER BY author.name, author.id LIMIT $1`
+
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.CatalogAuthor, 0, limit)
+	for rows.Next() {
+		var author entity.CatalogAuthor
+
+		if err = rows.Scan(&author.Author.ID, &author.Author.Name, &author.Books); err != nil {
+			return nil, err
+		}
+
+		result = append(result, author)
+	}
+
+	return result, rows.Err()
+}
+
+func (p postgresRepository) ListCatalogSubjects(ctx context.Context, after string, limit int) ([]entity.CatalogSubject, error) {
+	const query = `SELECT book_subject.subject, count(*)
+					FROM book_subject JOIN book ON book.id = book_subject.book_id AND book.deleted_at IS NULL
+					WHERE book_subject.subject > $1
+					GROUP BY book_subject.subject
+					ORDER BY book_subject.subject
+					LIMIT $2`
+
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, after, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.CatalogSubject, 0, limit)
+	for rows.Next() {
+		var subject entity.CatalogSubject
+
+		if err = rows.Scan(&subject.Name, &subject.Books); err != nil {
+			return nil, err
+		}
+
+		result = append(result, subject)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project/library/internal/entity"
+)
+
+var _ ChangeRepository = (*changeRepository)(nil)
+
+type changeRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewChangeRepository(db *pgxpool.Pool) *changeRepository {
+	return &changeRepository{
+		db: db,
+	}
+}
+
+var catalogEntities = map[entity.CatalogEntity]string{
+	entity.CatalogEntityBook:   "BOOK",
+	entity.CatalogEntityAuthor: "AUTHOR",
+}
+
+func parseCatalogEntity(value string) entity.CatalogEntity {
+	for key, name := range catalogEntities {
+		if name == value {
+			return key
+		}
+	}
+
+	return entity.CatalogEntityUndefined
+}
+
+var changeKinds = map[entity.ChangeKind]string{
+	entity.ChangeKindCreated: "CREATED",
+	entity.ChangeKindUpdated: "UPDATED",
+	entity.ChangeKindDeleted: "DELETED",
+}
+
+func parseChangeKind(value string) entity.ChangeKind {
+	for key, name := range changeKinds {
+		if name == value {
+			return key
+		}
+	}
+
+	return entity.ChangeKindUndefined
+}
+
+// GetCatalogChanges sequences the changes of finished transactions and returns up to limit
+// changes of the given entities after the given sequence, no entities means all of them.
+func (c *changeRepository) GetCatalogChanges(
+	ctx context.Context,
+	afterSequence int64,
+	entities []entity.CatalogEntity,
+	limit int,
+) ([]entity.CatalogChange, error) {
+	const query = `SELECT sequence, entity, kind, entity_id, created_at
+					FROM catalog_change
+					WHERE sequence > $1 AND (cardinality($2::text[]) = 0 OR entity = ANY($2))
+					ORDER BY sequence
+					LIMIT $3`
+
+	if err := c.sequenceCatalogChanges(ctx); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(entities))
+	for i, changed := range entities {
+		names[i] = catalogEntities[changed]
+	}
+
+	rows, err := getQuerier(ctx, c.db).Query(ctx, query, afterSequence, names, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.CatalogChange, 0)
+	for rows.Next() {
+		var (
+			change  entity.CatalogChange
+			changed string
+			kind    string
+		)
+
+		if err := rows.Scan(&change.Sequence, &changed, &kind, &change.EntityID, &change.ChangedAt); err != nil {
+			return nil, err
+		}
+
+		change.Entity = parseCatalogEntity(changed)
+		change.Kind = parseChangeKind(kind)
+		result = append(result, change)
+	}
+
+	return result, rows.Err()
+}
+
+// sequenceCatalogChanges numbers the changes written by transactions older than every running one,
+// a transaction still running may yet commit a change with a smaller id. Readers take turns through
+// an advisory lock, the one that does not get it reads what is already numbered.
+func (c *changeRepository) sequenceCatalogChanges(ctx context.Context) error {
+	const (
+		lockQuery = `SELECT pg_try_advisory_xact_lock(hashtextextended('catalog_change', 0))`
+		query     = `UPDATE catalog_change
+					SET sequence = pending.sequence
+					FROM (
+						SELECT id, (SELECT coalesce(max(sequence), 0) FROM catalog_change) + row_number() OVER (ORDER BY id) AS sequence
+						FROM catalog_change
+						WHERE sequence IS NULL AND txid < pg_snapshot_xmin(pg_current_snapshot())
+					) AS pending
+					WHERE catalog_change.id = pending.id`
+	)
+
+	tx, err := c.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var locked bool
+	if err = tx.QueryRow(ctx, lockQuery).Scan(&locked); err != nil || !locked {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
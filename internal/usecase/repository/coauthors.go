@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/project/library/internal/entity"
+)
+
+func (p postgresRepository) GetCoAuthors(ctx context.Context, authorID string) ([]entity.CoAuthor, error) {
+	const query = `SELECT author.id, author.name, count(*)
+					FROM live_author_book origin
+						JOIN book ON book.id = origin.book_id AND book.deleted_at IS NULL
+						JOIN live_author_book other ON other.book_id = origin.book_id AND other.author_id <> origin.author_id
+							AND other.role = 'AUTHOR'
+						JOIN author ON author.id = other.author_id
+					WHERE origin.author_id = $1 AND origin.role = 'AUTHOR'
+					GROUP BY author.id, author.name
+					ORDER BY count(*) DESC, author.name, author.id`
+
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, authorID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.CoAuthor, 0)
+	for rows.Next() {
+		var coAuthor entity.CoAuthor
+
+		if err := rows.Scan(&coAuthor.Author.ID, &coAuthor.Author.Name, &coAuthor.SharedBooks); err != nil {
+			return nil, err
+		}
+
+		result = append(result, coAuthor)
+	}
+
+	return result, rows.Err()
+}
+
+// GetCollaborationPath returns one of the shortest paths between two authors no longer than maxDepth hops.
+// The recursive query is a breadth-first search: every step of walk is a whole level of the graph,
+// the authors reached for the first time, and visited is the cycle guard shared by all the paths,
+// so an author is expanded once. The search stops at maxDepth or at the level that reaches the target,
+// and the path is read back through the parent each author was reached from.
+func (p postgresRepository) GetCollaborationPath(
+	ctx context.Context,
+	fromAuthorID string,
+	toAuthorID string,
+	maxDepth int,
+) ([]entity.CollaborationStep, error) {
+	const query = `WITH RECURSIVE walk AS (
+						SELECT 0 AS depth, ARRAY[$1::uuid] AS frontier, ARRAY[$1::uuid] AS visited,
+							ARRAY[NULL::uuid] AS parents, ARRAY[NULL::uuid] AS books
+						UNION ALL
+						SELECT walk.depth + 1, level.authors, walk.visited || level.authors,
+							walk.parents || level.parents, walk.books || level.books
+						FROM walk CROSS JOIN LATERAL (
+							SELECT array_agg(reached.author_id) AS authors, array_agg(reached.parent_id) AS parents,
+								array_agg(reached.book_id) AS books
+							FROM (
+								SELECT DISTINCT ON (other.author_id) other.author_id, origin.author_id AS parent_id, origin.book_id
+								FROM live_author_book origin
+									JOIN book ON book.id = origin.book_id AND book.deleted_at IS NULL
+									JOIN live_author_book other ON other.book_id = origin.book_id
+										AND other.role = 'AUTHOR' AND other.author_id <> ALL(walk.visited)
+								WHERE origin.author_id = ANY(walk.frontier) AND origin.role = 'AUTHOR'
+								ORDER BY other.author_id, origin.author_id, origin.book_id
+							) reached
+						) level
+						WHERE walk.depth < $3
+							AND $2::uuid <> ALL(walk.visited)
+							AND level.authors IS NOT NULL
+					), found AS (
+						SELECT visited, parents, books FROM walk WHERE $2::uuid = ANY(visited)
+					), path AS (
+						SELECT $2::uuid AS author_id, 0 AS ord
+						UNION ALL
+						SELECT found.parents[array_position(found.visited, path.author_id)], path.ord + 1
+						FROM path CROSS JOIN found
+						WHERE path.author_id <> $1::uuid AND path.ord < $3
+					)
+					SELECT path.author_id, author.name,
+						COALESCE(found.books[array_position(found.visited, path.author_id)]::text, '')
+					FROM path
+						CROSS JOIN found
+						JOIN author ON author.id = path.author_id
+					ORDER BY path.ord DESC`
+
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, fromAuthorID, toAuthorID, maxDepth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.CollaborationStep, 0)
+	for rows.Next() {
+		var step entity.CollaborationStep
+
+		if err := rows.Scan(&step.Author.ID, &step.Author.Name, &step.BookID); err != nil {
+			return nil, err
+		}
+
+		result = append(result, step)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, entity.ErrCollaborationPathNotFound
+	}
+
+	return result, nil
+}
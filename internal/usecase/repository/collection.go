@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ CollectionRepository = (*collectionRepository)(nil)
+
+type collectionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCollectionRepository(db *pgxpool.Pool) *collectionRepository {
+	return &collectionRepository{
+		db: db,
+	}
+}
+
+var collectionVisibilities = map[entity.CollectionVisibility]string{
+	entity.CollectionVisibilityPrivate: "PRIVATE",
+	entity.CollectionVisibilityPublic:  "PUBLIC",
+}
+
+func parseCollectionVisibility(visibility string) entity.CollectionVisibility {
+	for key, value := range collectionVisibilities {
+		if value == visibility {
+			return key
+		}
+	}
+
+	return entity.CollectionVisibilityUndefined
+}
+
+func getCollectionError(err error) error {
+	var pgErr *pgconn.PgError
+
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch {
+	case pgErr.Code == errUniqueViolation && pgErr.ConstraintName == "collection_item_pkey":
+		return entity.ErrBookAlreadyInCollection
+	case pgErr.Code != errForeignKeyViolation:
+		return err
+	case pgErr.ConstraintName == "collection_item_book_id_fkey":
+		return fmt.Errorf("book does not exist: %w", entity.ErrBookNotFound)
+	case pgErr.ConstraintName == "collection_owner_id_fkey",
+		pgErr.ConstraintName == "collection_share_patron_id_fkey":
+		return fmt.Errorf("patron does not exist: %w", entity.ErrPatronNotFound)
+	default:
+		return fmt.Errorf("collection does not exist: %w", entity.ErrCollectionNotFound)
+	}
+}
+
+const collectionColumns = `id, owner_id, name, description, visibility, created_at, updated_at`
+
+func scanCollection(row pgx.Row) (entity.Collection, error) {
+	var (
+		collection entity.Collection
+		visibility string
+	)
+
+	err := row.Scan(
+		&collection.ID,
+		&collection.OwnerID,
+		&collection.Name,
+		&collection.Description,
+		&visibility,
+		&collection.CreatedAt,
+		&collection.UpdatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Collection{}, entity.ErrCollectionNotFound
+	}
+
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	collection.Visibility = parseCollectionVisibility(visibility)
+
+	return collection, nil
+}
+
+// fillCollections loads books and shares of the given collections in place.
+func fillCollections(ctx context.Context, q querier, collections []entity.Collection) error {
+	if len(collections) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int, len(collections))
+	ids := make([]string, len(collections))
+	for i, collection := range collections {
+		index[collection.ID] = i
+		ids[i] = collection.ID
+		collections[i].Items = make([]entity.CollectionItem, 0)
+		collections[i].SharedWith = make([]string, 0)
+	}
+
+	const queryItems = `SELECT collection_item.collection_id, collection_item.note,
+							book.id, book.name, book.created_at, book.updated_at, book.rating_sum, book.rating_count,
+							book.isbn, book.publisher, book.publication_year,
+							array_agg(author_book.author_id ORDER BY author_book.position),
+							array_agg(author_book.role ORDER BY author_book.position)
+						FROM collection_item
+							JOIN book ON book.id = collection_item.book_id AND book.deleted_at IS NULL
+							LEFT JOIN live_author_book AS author_book ON book.id = author_book.book_id
+						WHERE collection_item.collection_id = ANY($1)
+						GROUP BY collection_item.collection_id, collection_item.position, collection_item.note, book.id
+						ORDER BY collection_item.collection_id, collection_item.position`
+
+	rows, err := q.Query(ctx, queryItems, ids)
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			collectionID     string
+			item             entity.CollectionItem
+			authorIDs, roles []sql.NullString
+			ratingSum        int
+		)
+
+		if err := rows.Scan(
+			&collectionID,
+			&item.Note,
+			&item.Book.ID,
+			&item.Book.Name,
+			&item.Book.CreatedAt,
+			&item.Book.UpdatedAt,
+			&ratingSum,
+			&item.Book.RatingCount,
+			&item.Book.ISBN,
+			&item.Book.Publisher,
+			&item.Book.PublicationYear,
+			&authorIDs,
+			&roles,
+		); err != nil {
+			return err
+		}
+
+		item.Book.AuthorIDs, item.Book.Contributors = getContributors(authorIDs, roles)
+		item.Book.RatingAverage = getRatingAverage(ratingSum, item.Book.RatingCount)
+
+		i := index[collectionID]
+		collections[i].Items = append(collections[i].Items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	rows.Close()
+
+	const queryShares = `SELECT collection_id, patron_id FROM collection_share WHERE collection_id = ANY($1) ORDER BY patron_id`
+
+	shares, err := q.Query(ctx, queryShares, ids)
+
+	if err != nil {
+		return err
+	}
+
+	defer shares.Close()
+
+	for shares.Next() {
+		var collectionID, patronID string
+
+		if err := shares.Scan(&collectionID, &patronID); err != nil {
+			return err
+		}
+
+		i := index[collectionID]
+		collections[i].SharedWith = append(collections[i].SharedWith, patronID)
+	}
+
+	return shares.Err()
+}
+
+func (r *collectionRepository) getCollection(ctx context.Context, query string, collectionID string) (entity.Collection, error) {
+	q := getQuerier(ctx, r.db)
+	collection, err := scanCollection(q.QueryRow(ctx, query, collectionID))
+
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	collections := []entity.Collection{collection}
+	if err = fillCollections(ctx, q, collections); err != nil {
+		return entity.Collection{}, err
+	}
+
+	return collections[0], nil
+}
+
+func (r *collectionRepository) CreateCollection(ctx context.Context, collection entity.Collection) (entity.Collection, error) {
+	const query = `INSERT INTO collection (owner_id, name, description, visibility)
+					VALUES ($1, $2, $3, $4)
+					RETURNING ` + collectionColumns
+
+	result, err := scanCollection(getQuerier(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		collection.OwnerID,
+		collection.Name,
+		collection.Description,
+		collectionVisibilities[collection.Visibility],
+	))
+
+	if err != nil {
+		return entity.Collection{}, getCollectionError(err)
+	}
+
+	result.Items = make([]entity.CollectionItem, 0)
+	result.SharedWith = make([]string, 0)
+
+	return result, nil
+}
+
+func (r *collectionRepository) GetCollection(ctx context.Context, collectionID string) (entity.Collection, error) {
+	const query = `SELECT ` + collectionColumns + ` FROM collection WHERE id = $1`
+
+	return r.getCollection(ctx, query, collectionID)
+}
+
+func (r *collectionRepository) GetCollectionForUpdate(ctx context.Context, collectionID string) (entity.Collection, error) {
+	const query = `SELECT ` + collectionColumns + ` FROM collection WHERE id = $1 FOR UPDATE`
+
+	return r.getCollection(ctx, query, collectionID)
+}
+
+func (r *collectionRepository) AddCollectionItem(ctx context.Context, collectionID string, item entity.CollectionItem) error {
+	// A book in the trash can not be added, an unknown one is caught by the foreign key.
+	const query = `INSERT INTO collection_item (collection_id, book_id, note, position)
+					SELECT $1, $2, $3, (SELECT COALESCE(MAX(position) + 1, 0) FROM collection_item WHERE collection_id = $1)
+					WHERE NOT EXISTS (SELECT 1 FROM book WHERE id = $2 AND deleted_at IS NOT NULL)`
+
+	res, err := getQuerier(ctx, r.db).Exec(ctx, query, collectionID, item.Book.ID, item.Note)
+
+	if err != nil {
+		return getCollectionError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("book is deleted: %w", entity.ErrBookNotFound)
+	}
+
+	return nil
+}
+
+func (r *collectionRepository) RemoveCollectionItem(ctx context.Context, collectionID string, bookID string) error {
+	const query = `DELETE FROM collection_item WHERE collection_id = $1 AND book_id = $2`
+
+	res, err := getQuerier(ctx, r.db).Exec(ctx, query, collectionID, bookID)
+
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return entity.ErrBookNotInCollection
+	}
+
+	return nil
+}
+
+func (r *collectionRepository) SetCollectionOrder(ctx context.Context, collectionID string, bookIDs []string) error {
+	const query = `UPDATE collection_item SET position = ordered.position - 1
+					FROM unnest($2::uuid[]) WITH ORDINALITY AS ordered(book_id, position)
+					WHERE collection_item.collection_id = $1 AND collection_item.book_id = ordered.book_id`
+
+	_, err := getQuerier(ctx, r.db).Exec(ctx, query, collectionID, bookIDs)
+
+	return err
+}
+
+func (r *collectionRepository) AddCollectionShare(ctx context.Context, collectionID string, patronID string) error {
+	const query = `INSERT INTO collection_share (collection_id, patron_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	_, err := getQuerier(ctx, r.db).Exec(ctx, query, collectionID, patronID)
+
+	return getCollectionError(err)
+}
+
+func (r *collectionRepository) ListCollections(
+	ctx context.Context,
+	filter entity.CollectionFilter,
+	after *entity.PageCursor,
+	limit int,
+) ([]entity.Collection, error) {
+	conditions := make([]string, 0, 3)
+	args := make([]any, 0, 5)
+
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if filter.ViewerID == "" {
+		conditions = append(conditions, "visibility = 'PUBLIC'")
+	} else {
+		viewerID := arg(filter.ViewerID)
+		conditions = append(conditions, `(visibility = 'PUBLIC' OR owner_id = `+viewerID+` OR EXISTS (
+			SELECT 1 FROM collection_share WHERE collection_share.collection_id = collection.id AND patron_id = `+viewerID+`))`)
+	}
+
+	if filter.OwnerID != "" {
+		conditions = append(conditions, "owner_id = "+arg(filter.OwnerID))
+	}
+
+	if after != nil {
+		conditions = append(conditions, "(created_at, id) > ("+arg(after.CreatedAt)+", "+arg(after.ID)+")")
+	}
+
+	query := `SELECT ` + collectionColumns + ` FROM collection WHERE ` + strings.Join(conditions, " AND ") +
+		` ORDER BY created_at, id LIMIT ` + arg(limit)
+
+	q := getQuerier(ctx, r.db)
+	rows, err := q.Query(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.Collection, 0, limit)
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, collection)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows.Close()
+
+	if err = fillCollections(ctx, q, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ HistoryRepository = (*historyRepository)(nil)
+
+type historyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewHistoryRepository(db *pgxpool.Pool) *historyRepository {
+	return &historyRepository{
+		db: db,
+	}
+}
+
+// GetBookAsOf reconstructs the book with its contributors as it was at asOf. UpdatedAt is the last change
+// of the book or its contributors before asOf, the ratings are not versioned and stay empty.
+func (h *historyRepository) GetBookAsOf(ctx context.Context, bookID string, asOf time.Time) (entity.Book, error) {
+	const query = `
+SELECT book_history.book_id, book_history.name,
+       (SELECT min(valid_from) FROM book_history WHERE book_id = $1),
+       greatest(book_history.valid_from, changes.changed_at),
+       book_history.isbn, book_history.publisher, book_history.publication_year,
+       COALESCE(book_history.work_id::text, ''), book_history.language, book_history.translator,
+       links.author_ids, links.roles
+FROM book_history
+         CROSS JOIN LATERAL (SELECT array_agg(author_id ORDER BY position) AS author_ids,
+                                    array_agg(role ORDER BY position)      AS roles
+                             FROM author_book_history
+                             WHERE book_id = $1
+                               AND valid_from <= $2
+                               AND (valid_to IS NULL OR valid_to > $2)) links
+         CROSS JOIN LATERAL (SELECT max(changed_at) AS changed_at
+                             FROM (SELECT valid_from AS changed_at FROM author_book_history WHERE book_id = $1
+                                   UNION ALL
+                                   SELECT valid_to FROM author_book_history WHERE book_id = $1) link_changes
+                             WHERE changed_at <= $2) changes
+WHERE book_history.book_id = $1
+  AND book_history.valid_from <= $2
+  AND (book_history.valid_to IS NULL OR book_history.valid_to > $2)`
+
+	var (
+		result           entity.Book
+		authorIDs, roles []sql.NullString
+	)
+
+	err := getQuerier(ctx, h.db).QueryRow(ctx, query, bookID, asOf).Scan(
+		&result.ID,
+		&result.Name,
+		&result.CreatedAt,
+		&result.UpdatedAt,
+		&result.ISBN,
+		&result.Publisher,
+		&result.PublicationYear,
+		&result.WorkID,
+		&result.Language,
+		&result.Translator,
+		&authorIDs,
+		&roles,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Book{}, entity.ErrBookNotFound
+	}
+
+	if err != nil {
+		return entity.Book{}, err
+	}
+
+	result.AuthorIDs, result.Contributors = getContributors(authorIDs, roles)
+
+	return result, nil
+}
+
+func (h *historyRepository) GetAuthorAsOf(ctx context.Context, authorID string, asOf time.Time) (entity.Author, error) {
+	const query = `
+SELECT author_id, name
+FROM author_history
+WHERE author_id = $1
+  AND valid_from <= $2
+  AND (valid_to IS NULL OR valid_to > $2)`
+
+	var author entity.Author
+	err := getQuerier(ctx, h.db).QueryRow(ctx, query, authorID, asOf).Scan(&author.ID, &author.Name)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Author{}, entity.ErrAuthorNotFound
+	}
+
+	if err != nil {
+		return entity.Author{}, err
+	}
+
+	return author, nil
+}
+
+// GetBookHistory lists the states of the book from the oldest one. A new state starts at every change
+// of the book or its contributors, consecutive states that turned out the same are merged.
+func (h *historyRepository) GetBookHistory(ctx context.Context, bookID string) ([]entity.BookVersion, error) {
+	const query = `
+WITH changes AS (SELECT valid_from AS changed_at FROM book_history WHERE book_id = $1
+                 UNION
+                 SELECT valid_from FROM author_book_history WHERE book_id = $1
+                 UNION
+                 SELECT valid_to FROM author_book_history WHERE book_id = $1 AND valid_to IS NOT NULL)
+SELECT book_history.book_id, book_history.name,
+       book_history.isbn, book_history.publisher, book_history.publication_year,
+       COALESCE(book_history.work_id::text, ''), book_history.language, book_history.translator,
+       links.author_ids, links.roles,
+       changes.changed_at,
+       least(lead(changes.changed_at) OVER (ORDER BY changes.changed_at), book_history.valid_to)
+FROM changes
+         JOIN book_history ON book_history.book_id = $1
+    AND book_history.valid_from <= changes.changed_at
+    AND (book_history.valid_to IS NULL OR book_history.valid_to > changes.changed_at)
+         CROSS JOIN LATERAL (SELECT array_agg(author_id ORDER BY position) AS author_ids,
+                                    array_agg(role ORDER BY position)      AS roles
+                             FROM author_book_history
+                             WHERE book_id = $1
+                               AND valid_from <= changes.changed_at
+                               AND (valid_to IS NULL OR valid_to > changes.changed_at)) links
+ORDER BY changes.changed_at`
+
+	rows, err := getQuerier(ctx, h.db).Query(ctx, query, bookID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.BookVersion, 0)
+	for rows.Next() {
+		var (
+			version          entity.BookVersion
+			authorIDs, roles []sql.NullString
+			validTo          *time.Time
+		)
+
+		err := rows.Scan(
+			&version.Book.ID,
+			&version.Book.Name,
+			&version.Book.ISBN,
+			&version.Book.Publisher,
+			&version.Book.PublicationYear,
+			&version.Book.WorkID,
+			&version.Book.Language,
+			&version.Book.Translator,
+			&authorIDs,
+			&roles,
+			&version.ValidFrom,
+			&validTo,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		version.Book.AuthorIDs, version.Book.Contributors = getContributors(authorIDs, roles)
+		if validTo != nil {
+			version.ValidTo = *validTo
+		}
+
+		result = appendBookVersion(result, version)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, entity.ErrBookNotFound
+	}
+
+	for i := range result {
+		result[i].Book.CreatedAt = result[0].ValidFrom
+		result[i].Book.UpdatedAt = result[i].ValidFrom
+	}
+
+	return result, nil
+}
+
+// appendBookVersion extends the last version instead of adding a new one when nothing changed,
+// e.g. when the contributors of a book were replaced by the same ones.
+func appendBookVersion(versions []entity.BookVersion, version entity.BookVersion) []entity.BookVersion {
+	if len(versions) == 0 {
+		return append(versions, version)
+	}
+
+	last := &versions[len(versions)-1]
+	if !last.ValidTo.Equal(version.ValidFrom) || !sameBookState(last.Book, version.Book) {
+		return append(versions, version)
+	}
+
+	last.ValidTo = version.ValidTo
+
+	return versions
+}
+
+func sameBookState(a entity.Book, b entity.Book) bool {
+	return a.Name == b.Name &&
+		a.ISBN == b.ISBN &&
+		a.Publisher == b.Publisher &&
+		a.PublicationYear == b.PublicationYear &&
+		a.WorkID == b.WorkID &&
+		a.Language == b.Language &&
+		a.Translator == b.Translator &&
+		slices.Equal(a.Contributors, b.Contributors)
+}
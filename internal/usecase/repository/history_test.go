@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/project/library/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendBookVersion(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	author := entity.Contributor{AuthorID: "author", Role: entity.ContributorRoleAuthor}
+	editor := entity.Contributor{AuthorID: "editor", Role: entity.ContributorRoleEditor}
+	book := entity.Book{ID: "book", Name: "Dune", Contributors: []entity.Contributor{author}}
+
+	withEditor := book
+	withEditor.Contributors = []entity.Contributor{author, editor}
+
+	var versions []entity.BookVersion
+	versions = appendBookVersion(versions, entity.BookVersion{Book: book, ValidFrom: start, ValidTo: start.Add(time.Hour)})
+	// The contributors were replaced by the same ones.
+	versions = appendBookVersion(versions, entity.BookVersion{Book: book, ValidFrom: start.Add(time.Hour), ValidTo: start.Add(2 * time.Hour)})
+	versions = appendBookVersion(versions, entity.BookVersion{Book: withEditor, ValidFrom: start.Add(2 * time.Hour)})
+
+	require.Len(t, versions, 2)
+	require.True(t, start.Equal(versions[0].ValidFrom))
+	require.True(t, start.Add(2*time.Hour).Equal(versions[0].ValidTo))
+	require.Len(t, versions[1].Book.Contributors, 2)
+	require.True(t, versions[1].ValidTo.IsZero())
+
+	// A book deleted and created again under the same id starts a new version after the gap.
+	versions = appendBookVersion(versions[:1], entity.BookVersion{Book: book, ValidFrom: start.Add(3 * time.Hour)})
+	require.Len(t, versions, 2)
+}
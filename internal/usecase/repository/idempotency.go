@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ IdempotencyRepository = (*idempotencyRepository)(nil)
+
+type idempotencyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyRepository(db *pgxpool.Pool) *idempotencyRepository {
+	return &idempotencyRepository{
+		db: db,
+	}
+}
+
+// GetIdempotencyRecord locks the key until the end of the transaction, so that a concurrent retry
+// waits for the first request and then reads its response instead of creating a duplicate.
+func (i *idempotencyRepository) GetIdempotencyRecord(ctx context.Context, key entity.IdempotencyKey) (entity.IdempotencyRecord, error) {
+	const (
+		lockQuery = `SELECT pg_advisory_xact_lock(hashtextextended($1 || ':' || $2 || ':' || $3, 0))`
+		query     = `SELECT request_hash, response
+					FROM idempotency_key
+					WHERE actor = $1 AND method = $2 AND key = $3 AND expires_at > now()`
+	)
+
+	q := getQuerier(ctx, i.db)
+
+	if _, err := q.Exec(ctx, lockQuery, key.Actor, key.Method, key.Key); err != nil {
+		return entity.IdempotencyRecord{}, err
+	}
+
+	record := entity.IdempotencyRecord{
+		IdempotencyKey: entity.IdempotencyKey{Key: key.Key, Actor: key.Actor, Method: key.Method},
+	}
+	err := q.QueryRow(ctx, query, key.Actor, key.Method, key.Key).Scan(&record.RequestHash, &record.Response)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.IdempotencyRecord{}, entity.ErrIdempotencyRecordNotFound
+	}
+
+	if err != nil {
+		return entity.IdempotencyRecord{}, err
+	}
+
+	return record, nil
+}
+
+// SaveIdempotencyRecord stores the response of a key, replacing an expired record of the same key.
+func (i *idempotencyRepository) SaveIdempotencyRecord(ctx context.Context, record entity.IdempotencyRecord, ttl time.Duration) error {
+	const query = `INSERT INTO idempotency_key (actor, method, key, request_hash, response, expires_at)
+					VALUES ($1, $2, $3, $4, $5, now() + $6::interval)
+					ON CONFLICT (actor, method, key) DO UPDATE
+					SET request_hash = excluded.request_hash,
+						response = excluded.response,
+						created_at = now(),
+						expires_at = excluded.expires_at`
+
+	interval := fmt.Sprintf("%d ms", ttl.Milliseconds())
+	_, err := getQuerier(ctx, i.db).Exec(ctx, query,
+		record.Actor, record.Method, record.Key, record.RequestHash, record.Response, interval)
+
+	return err
+}
+
+func (i *idempotencyRepository) DeleteExpiredIdempotencyRecords(ctx context.Context) (int, error) {
+	const query = `DELETE FROM idempotency_key WHERE expires_at <= now()`
+
+	tag, err := getQuerier(ctx, i.db).Exec(ctx, query)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
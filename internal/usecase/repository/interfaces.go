@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/project/library/internal/entity"
@@ -12,22 +13,140 @@ import (
 type AuthorRepository interface {
 	CreateAuthor(ctx context.Context, author entity.Author) (entity.Author, error)
 	GetAuthor(ctx context.Context, id string) (entity.Author, error)
+	GetAuthors(ctx context.Context, ids []string) ([]entity.Author, error)
 	ChangeAuthorInfo(ctx context.Context, id string, newAuthor entity.Author) (entity.Author, error)
+	DeleteAuthor(ctx context.Context, id string) error
+	GetCoAuthors(ctx context.Context, authorID string) ([]entity.CoAuthor, error)
+	GetCollaborationPath(ctx context.Context, fromAuthorID string, toAuthorID string, maxDepth int) ([]entity.CollaborationStep, error)
 }
 
 type BookRepository interface {
 	CreateBook(ctx context.Context, book entity.Book) (entity.Book, error)
 	GetBook(ctx context.Context, id string) (entity.Book, error)
+	GetBooks(ctx context.Context, ids []string) ([]entity.Book, error)
 	ChangeBookInfo(ctx context.Context, id string, newBook entity.Book) (entity.Book, error)
-	GetBooksByAuthor(ctx context.Context, authorID string) ([]entity.Book, error)
+	DeleteBook(ctx context.Context, id string) error
+	GetBooksByAuthor(ctx context.Context, authorID string, role entity.ContributorRole) ([]entity.Book, error)
+}
+
+type TrashRepository interface {
+	GetBookIncludingDeleted(ctx context.Context, id string) (entity.Book, error)
+	GetAuthorIncludingDeleted(ctx context.Context, id string) (entity.Author, error)
+	RestoreBook(ctx context.Context, id string) error
+	RestoreAuthor(ctx context.Context, id string) error
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int, error)
 }
 
 type OutboxRepository interface {
 	SendMessage(ctx context.Context, idempotencyKey string, kind OutboxKind, message []byte) error
+	SendMessages(ctx context.Context, messages []OutboxData) error
 	GetMessages(ctx context.Context, batchSize int, inProgressTTL time.Duration) ([]OutboxData, error)
 	MarkAsProcessed(ctx context.Context, idempotencyKeys []string) error
 }
 
+type HistoryRepository interface {
+	GetBookAsOf(ctx context.Context, bookID string, asOf time.Time) (entity.Book, error)
+	GetAuthorAsOf(ctx context.Context, authorID string, asOf time.Time) (entity.Author, error)
+	GetBookHistory(ctx context.Context, bookID string) ([]entity.BookVersion, error)
+}
+
+type AuditRepository interface {
+	ListAuditEvents(ctx context.Context, filter entity.AuditFilter, after *entity.PageCursor, limit int) ([]entity.AuditEvent, error)
+}
+
+type ChangeRepository interface {
+	GetCatalogChanges(ctx context.Context, afterSequence int64, entities []entity.CatalogEntity, limit int) ([]entity.CatalogChange, error)
+}
+
+type IdempotencyRepository interface {
+	GetIdempotencyRecord(ctx context.Context, key entity.IdempotencyKey) (entity.IdempotencyRecord, error)
+	SaveIdempotencyRecord(ctx context.Context, record entity.IdempotencyRecord, ttl time.Duration) error
+	DeleteExpiredIdempotencyRecords(ctx context.Context) (int, error)
+}
+
+type NotificationRepository interface {
+	ScheduleNotifications(ctx context.Context, notifications []entity.Notification) ([]entity.Notification, error)
+	GetDueNotifications(ctx context.Context, batchSize int) ([]entity.Notification, error)
+	GetNotificationPreferences(ctx context.Context, patronID string) (entity.NotificationPreferences, error)
+	SetNotificationPreferences(ctx context.Context, preferences entity.NotificationPreferences) error
+}
+
+type BranchRepository interface {
+	CreateBranch(ctx context.Context, branch entity.Branch) (entity.Branch, error)
+	GetBranch(ctx context.Context, id string) (entity.Branch, error)
+	CreatePatron(ctx context.Context, patron entity.Patron) (entity.Patron, error)
+	GetPatron(ctx context.Context, id string) (entity.Patron, error)
+	CreateCopy(ctx context.Context, bookCopy entity.BookCopy) (entity.BookCopy, error)
+	GetCopyForUpdate(ctx context.Context, id string) (entity.BookCopy, error)
+	UpdateCopy(ctx context.Context, bookCopy entity.BookCopy) error
+	CreateTransfer(ctx context.Context, transfer entity.Transfer) (entity.Transfer, error)
+	GetTransferForUpdate(ctx context.Context, id string) (entity.Transfer, error)
+	MarkTransferReceived(ctx context.Context, id string) (entity.Transfer, error)
+	CreateLoan(ctx context.Context, loan entity.Loan) (entity.Loan, error)
+	CloseLoan(ctx context.Context, copyID string) (entity.Loan, error)
+	GetBookAvailability(ctx context.Context, bookID string, branchID string) ([]entity.BranchAvailability, error)
+}
+
+type ReviewRepository interface {
+	CreateReview(ctx context.Context, review entity.Review) (entity.Review, error)
+	GetReviewForUpdate(ctx context.Context, id string) (entity.Review, error)
+	UpdateReviewStatus(ctx context.Context, id string, status entity.ReviewStatus) (entity.Review, error)
+	ListReviews(ctx context.Context, filter entity.ReviewFilter, after *entity.PageCursor, limit int) ([]entity.Review, error)
+	UpdateBookRating(ctx context.Context, bookID string, sumDelta int, countDelta int) error
+}
+
+type CollectionRepository interface {
+	CreateCollection(ctx context.Context, collection entity.Collection) (entity.Collection, error)
+	GetCollection(ctx context.Context, collectionID string) (entity.Collection, error)
+	GetCollectionForUpdate(ctx context.Context, collectionID string) (entity.Collection, error)
+	AddCollectionItem(ctx context.Context, collectionID string, item entity.CollectionItem) error
+	RemoveCollectionItem(ctx context.Context, collectionID string, bookID string) error
+	SetCollectionOrder(ctx context.Context, collectionID string, bookIDs []string) error
+	AddCollectionShare(ctx context.Context, collectionID string, patronID string) error
+	ListCollections(ctx context.Context, filter entity.CollectionFilter, after *entity.PageCursor, limit int) ([]entity.Collection, error)
+}
+
+type CatalogRepository interface {
+	GetAuthorsByNames(ctx context.Context, names []string) ([]entity.Author, error)
+	CreateAuthors(ctx context.Context, names []string) ([]entity.Author, error)
+	CreateBooks(ctx context.Context, books []entity.Book) ([]entity.Book, error)
+	GetCatalogBooks(ctx context.Context, bookIDs []string) ([]entity.CatalogBook, error)
+	ListCatalogBooks(ctx context.Context, filter entity.CatalogFilter, afterID string, limit int) ([]entity.CatalogBook, error)
+	ListNewestBooks(ctx context.Context, afterID string, limit int) ([]entity.CatalogBook, error)
+	ListCatalogAuthors(ctx context.Context, afterID string, limit int) ([]entity.CatalogAuthor, error)
+	ListCatalogSubjects(ctx context.Context, after string, limit int) ([]entity.CatalogSubject, error)
+}
+
+type WorkRepository interface {
+	CreateWork(ctx context.Context, work entity.Work) (entity.Work, error)
+	GetWork(ctx context.Context, workID string) (entity.Work, error)
+	AddWorkEditions(ctx context.Context, workID string, bookIDs []string) error
+	SetBookEdition(ctx context.Context, bookID string, edition entity.Edition) error
+	GetEditions(ctx context.Context, workID string) ([]entity.Book, error)
+}
+
+type RecommendationRepository interface {
+	SetBookSubjects(ctx context.Context, bookID string, subjects []string) error
+	RefreshRecommendations(ctx context.Context, topN int, weights entity.RecommendationWeights) error
+	GetRecommendations(ctx context.Context, bookID string, limit int) ([]entity.Recommendation, error)
+}
+
+type AttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment entity.Attachment) (entity.Attachment, error)
+	GetAttachment(ctx context.Context, id string) (entity.Attachment, error)
+	ListAttachments(ctx context.Context, bookID string) ([]entity.Attachment, error)
+	DeleteAttachment(ctx context.Context, id string) error
+	DeleteCover(ctx context.Context, bookID string) error
+}
+
+// BlobStore keeps attachment contents by key. Get returns entity.ErrBlobNotFound
+// for a missing key, Delete of a missing key is not an error.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
 type OutboxKind int
 
 type OutboxData struct {
@@ -40,6 +159,9 @@ const (
 	OutboxKindUndefined OutboxKind = iota
 	OutboxKindBook
 	OutboxKindAuthor
+	OutboxKindNotification
+	OutboxKindReview
+	OutboxKindBlob
 )
 
 func (o OutboxKind) String() string {
@@ -48,6 +170,12 @@ func (o OutboxKind) String() string {
 		return "book"
 	case OutboxKindAuthor:
 		return "author"
+	case OutboxKindNotification:
+		return "notification"
+	case OutboxKindReview:
+		return "review"
+	case OutboxKindBlob:
+		return "blob"
 	default:
 		return "undefined"
 	}
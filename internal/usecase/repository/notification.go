@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ NotificationRepository = (*notificationRepository)(nil)
+
+type notificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) *notificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+func (n *notificationRepository) ScheduleNotifications(
+	ctx context.Context,
+	notifications []entity.Notification,
+) ([]entity.Notification, error) {
+	const query = `INSERT INTO notification (patron_id, book_id, kind, due_at, send_at)
+					VALUES ($1, $2, $3, $4, $5)
+					RETURNING id`
+
+	batch := &pgx.Batch{}
+	for _, notification := range notifications {
+		var dueAt *time.Time
+		if !notification.DueAt.IsZero() {
+			dueAt = &notification.DueAt
+		}
+
+		batch.Queue(query, notification.PatronID, notification.BookID, notification.Kind, dueAt, notification.SendAt)
+	}
+
+	var results pgx.BatchResults
+	if tx, txErr := extractTX(ctx); txErr == nil {
+		results = tx.SendBatch(ctx, batch)
+	} else {
+		results = n.db.SendBatch(ctx, batch)
+	}
+
+	defer func() {
+		_ = results.Close()
+	}()
+
+	result := make([]entity.Notification, len(notifications))
+	for i, notification := range notifications {
+		result[i] = notification
+
+		if err := results.QueryRow().Scan(&result[i].ID); err != nil {
+			return nil, getNotificationError(err)
+		}
+	}
+
+	return result, nil
+}
+
+func (n *notificationRepository) GetDueNotifications(ctx context.Context, batchSize int) ([]entity.Notification, error) {
+	const query = `UPDATE notification
+					SET status = 'ENQUEUED'
+					WHERE id IN (
+						SELECT id
+						FROM notification
+						WHERE status = 'SCHEDULED' AND send_at <= now()
+						ORDER BY send_at
+						LIMIT $1
+						FOR UPDATE SKIP LOCKED
+					)
+					RETURNING id, patron_id, book_id, kind, due_at, send_at`
+
+	var (
+		rows pgx.Rows
+		err  error
+	)
+	if tx, txErr := extractTX(ctx); txErr == nil {
+		rows, err = tx.Query(ctx, query, batchSize)
+	} else {
+		rows, err = n.db.Query(ctx, query, batchSize)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.Notification, 0)
+	for rows.Next() {
+		var (
+			notification entity.Notification
+			dueAt        *time.Time
+		)
+
+		err := rows.Scan(
+			&notification.ID,
+			&notification.PatronID,
+			&notification.BookID,
+			&notification.Kind,
+			&dueAt,
+			&notification.SendAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if dueAt != nil {
+			notification.DueAt = *dueAt
+		}
+
+		result = append(result, notification)
+	}
+
+	return result, rows.Err()
+}
+
+func (n *notificationRepository) GetNotificationPreferences(
+	ctx context.Context,
+	patronID string,
+) (entity.NotificationPreferences, error) {
+	const query = `SELECT patron_id, channels, email, webhook_url FROM notification_preferences WHERE patron_id = $1`
+
+	var (
+		preferences entity.NotificationPreferences
+		channels    []int32
+	)
+
+	err := n.db.QueryRow(ctx, query, patronID).Scan(
+		&preferences.PatronID,
+		&channels,
+		&preferences.Email,
+		&preferences.WebhookURL,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.NotificationPreferences{}, entity.ErrNotificationPreferencesNotFound
+	}
+
+	if err != nil {
+		return entity.NotificationPreferences{}, err
+	}
+
+	preferences.Channels = make([]entity.NotificationChannel, len(channels))
+	for i, channel := range channels {
+		preferences.Channels[i] = entity.NotificationChannel(channel)
+	}
+
+	return preferences, nil
+}
+
+func (n *notificationRepository) SetNotificationPreferences(
+	ctx context.Context,
+	preferences entity.NotificationPreferences,
+) error {
+	const query = `INSERT INTO notification_preferences (patron_id, channels, email, webhook_url)
+					VALUES ($1, $2, $3, $4)
+					ON CONFLICT (patron_id) DO UPDATE
+					SET channels = EXCLUDED.channels, email = EXCLUDED.email, webhook_url = EXCLUDED.webhook_url`
+
+	channels := make([]int32, len(preferences.Channels))
+	for i, channel := range preferences.Channels {
+		channels[i] = int32(channel)
+	}
+
+	_, err := n.db.Exec(ctx, query, preferences.PatronID, channels, preferences.Email, preferences.WebhookURL)
+
+	return err
+}
+
+func getNotificationError(err error) error {
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) && pgErr.Code == errForeignKeyViolation {
+		return fmt.Errorf("book does not exist: %w", entity.ErrBookNotFound)
+	}
+
+	return err
+}
@@ -36,6 +36,31 @@ func (o *outboxRepository) SendMessage(ctx context.Context, idempotencyKey strin
 	return err
 }
 
+// SendMessages stores a batch of messages with a single statement, used by bulk operations.
+func (o *outboxRepository) SendMessages(ctx context.Context, messages []OutboxData) error {
+	const query = `INSERT INTO outbox (idempotency_key, data, status, kind)
+					SELECT message.idempotency_key, message.data::jsonb, 'CREATED'::outbox_status, message.kind
+					FROM unnest($1::text[], $2::text[], $3::int[]) AS message(idempotency_key, data, kind)
+					ON CONFLICT (idempotency_key) DO NOTHING`
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(messages))
+	data := make([]string, len(messages))
+	kinds := make([]int, len(messages))
+	for i, message := range messages {
+		keys[i] = message.IdempotencyKey
+		data[i] = string(message.RawData)
+		kinds[i] = int(message.Kind)
+	}
+
+	_, err := getQuerier(ctx, o.db).Exec(ctx, query, keys, data, kinds)
+
+	return err
+}
+
 func (o *outboxRepository) GetMessages(ctx context.Context, batchSize int, inProgressTTL time.Duration) ([]OutboxData, error) {
 	const query = `UPDATE outbox
 					SET status = 'IN_PROGRESS'
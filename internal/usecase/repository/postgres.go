@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -17,6 +18,7 @@ var _ AuthorRepository = (*postgresRepository)(nil)
 
 const (
 	errForeignKeyViolation = "23503"
+	errUniqueViolation     = "23505"
 )
 
 type postgresRepository struct {
@@ -39,25 +41,186 @@ func getError(err error) error {
 	return err
 }
 
-func addAuthorBooks(ctx context.Context, tx pgx.Tx, bookID string, authorIDs []string) error {
-	rows := make([][]any, len(authorIDs))
-	for i, authorID := range authorIDs {
-		rows[i] = []any{authorID, bookID}
+var contributorRoles = map[entity.ContributorRole]string{
+	entity.ContributorRoleAuthor:      "AUTHOR",
+	entity.ContributorRoleTranslator:  "TRANSLATOR",
+	entity.ContributorRoleEditor:      "EDITOR",
+	entity.ContributorRoleIllustrator: "ILLUSTRATOR",
+	entity.ContributorRoleNarrator:    "NARRATOR",
+}
+
+func parseContributorRole(role string) entity.ContributorRole {
+	for key, value := range contributorRoles {
+		if value == role {
+			return key
+		}
+	}
+
+	return entity.ContributorRoleUndefined
+}
+
+func addAuthorBooks(ctx context.Context, tx pgx.Tx, bookID string, contributors []entity.Contributor) error {
+	rows := make([][]any, len(contributors))
+	for i, contributor := range contributors {
+		rows[i] = []any{contributor.AuthorID, bookID, contributorRoles[contributor.Role], i}
+	}
+
+	if err := checkAuthorsLive(ctx, tx, contributors); err != nil {
+		return err
+	}
+
+	return copyAuthorBooks(ctx, tx, rows)
+}
+
+// checkAuthorsLive fails for the authors in the trash, the foreign key only catches unknown ones.
+func checkAuthorsLive(ctx context.Context, tx pgx.Tx, contributors []entity.Contributor) error {
+	const queryDeleted = `SELECT EXISTS (SELECT 1 FROM author WHERE id = ANY($1) AND deleted_at IS NOT NULL)`
+
+	authorIDs := make([]string, len(contributors))
+	for i, contributor := range contributors {
+		authorIDs[i] = contributor.AuthorID
+	}
+
+	var deleted bool
+	if err := tx.QueryRow(ctx, queryDeleted, authorIDs).Scan(&deleted); err != nil {
+		return err
+	}
+
+	if deleted {
+		return fmt.Errorf("some authors are deleted: %w", entity.ErrAuthorNotFound)
+	}
+
+	return nil
+}
+
+func copyAuthorBooks(ctx context.Context, tx pgx.Tx, rows [][]any) error {
+	if len(rows) == 0 {
+		return nil
 	}
 
-	_, err := tx.Conn().CopyFrom(ctx, pgx.Identifier{"author_book"}, []string{"author_id", "book_id"}, pgx.CopyFromRows(rows))
+	columns := []string{"author_id", "book_id", "role", "position"}
+	_, err := tx.Conn().CopyFrom(ctx, pgx.Identifier{"author_book"}, columns, pgx.CopyFromRows(rows))
 
 	return getError(err)
 }
 
-func getAuthorsList(authorIDs []sql.NullString) []string {
-	result := make([]string, 0)
-	if authorIDs[0].Valid {
-		for elem := range authorIDs {
-			result = append(result, authorIDs[elem].String)
+type authorBookKey struct {
+	authorID string
+	role     string
+}
+
+// changeAuthorBooks turns the links of the book into the given contributors touching only the rows
+// that differ, so that the change feed, the audit log and the history get no spurious entries.
+// The hidden links to deleted authors are kept for their restore and the contributors are placed after them.
+func changeAuthorBooks(ctx context.Context, tx pgx.Tx, bookID string, contributors []entity.Contributor) error {
+	const queryLinks = `SELECT author_book.author_id, author_book.role, author_book.position, author.deleted_at IS NOT NULL
+						FROM author_book JOIN author ON author.id = author_book.author_id
+						WHERE author_book.book_id = $1`
+
+	if err := checkAuthorsLive(ctx, tx, contributors); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, queryLinks, bookID)
+
+	if err != nil {
+		return err
+	}
+
+	current := make(map[authorBookKey]int)
+	offset := 0
+	for rows.Next() {
+		var (
+			key      authorBookKey
+			position int
+			hidden   bool
+		)
+
+		if err := rows.Scan(&key.authorID, &key.role, &position, &hidden); err != nil {
+			rows.Close()
+			return err
+		}
+
+		if hidden {
+			offset = max(offset, position+1)
+			continue
+		}
+
+		current[key] = position
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	const (
+		queryMove   = `UPDATE author_book SET position = $4 WHERE author_id = $1 AND book_id = $2 AND role = $3`
+		queryRemove = `DELETE FROM author_book WHERE author_id = $1 AND book_id = $2 AND role = $3`
+	)
+
+	batch := &pgx.Batch{}
+	added := make([][]any, 0)
+	for i, contributor := range contributors {
+		key := authorBookKey{authorID: contributor.AuthorID, role: contributorRoles[contributor.Role]}
+		position := offset + i
+
+		old, ok := current[key]
+		delete(current, key)
+
+		switch {
+		case !ok:
+			added = append(added, []any{key.authorID, bookID, key.role, position})
+		case old != position:
+			batch.Queue(queryMove, key.authorID, bookID, key.role, position)
+		}
+	}
+
+	for key := range current {
+		batch.Queue(queryRemove, key.authorID, bookID, key.role)
+	}
+
+	if batch.Len() > 0 {
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			return err
+		}
+	}
+
+	return copyAuthorBooks(ctx, tx, added)
+}
+
+// getContributors converts array_agg(author_book.author_id), array_agg(author_book.role) ordered by
+// author_book.position into the authors of a book and all its contributors in the order they were given.
+func getContributors(authorIDs []sql.NullString, roles []sql.NullString) ([]string, []entity.Contributor) {
+	authors := make([]string, 0)
+	contributors := make([]entity.Contributor, 0)
+
+	for i := range authorIDs {
+		if !authorIDs[i].Valid {
+			continue
+		}
+
+		role := parseContributorRole(roles[i].String)
+		if role == entity.ContributorRoleAuthor {
+			authors = append(authors, authorIDs[i].String)
 		}
+
+		contributors = append(contributors, entity.Contributor{
+			AuthorID: authorIDs[i].String,
+			Role:     role,
+		})
+	}
+
+	return authors, contributors
+}
+
+func getRatingAverage(sum int, count int) float64 {
+	if count == 0 {
+		return 0
 	}
-	return result
+
+	return float64(sum) / float64(count)
 }
 
 func (p postgresRepository) CreateBook(ctx context.Context, book entity.Book) (resBook entity.Book, txErr error) {
@@ -88,15 +251,16 @@ func (p postgresRepository) CreateBook(ctx context.Context, book entity.Book) (r
 	const queryBook = `INSERT INTO book (name) VALUES ($1) RETURNING id, created_at, updated_at`
 
 	result := entity.Book{
-		Name:      book.Name,
-		AuthorIDs: book.AuthorIDs,
+		Name:         book.Name,
+		AuthorIDs:    book.AuthorIDs,
+		Contributors: book.Contributors,
 	}
 
 	if err := tx.QueryRow(ctx, queryBook, result.Name).Scan(&result.ID, &result.CreatedAt, &result.UpdatedAt); err != nil {
 		return entity.Book{}, err
 	}
 
-	if err := addAuthorBooks(ctx, tx, result.ID, result.AuthorIDs); err != nil {
+	if err := addAuthorBooks(ctx, tx, result.ID, result.Contributors); err != nil {
 		return entity.Book{}, err
 	}
 
@@ -104,14 +268,41 @@ func (p postgresRepository) CreateBook(ctx context.Context, book entity.Book) (r
 }
 
 func (p postgresRepository) GetBook(ctx context.Context, bookID string) (entity.Book, error) {
-	const query = `SELECT book.id, book.name, book.created_at, book.updated_at, array_agg(author_book.author_id) 
-					FROM book LEFT JOIN author_book ON book.id = author_book.book_id 
-					WHERE book.id = $1
+	return p.getBook(ctx, bookID, false)
+}
+
+// getBook reads a book, one in the trash only with includeDeleted.
+func (p postgresRepository) getBook(ctx context.Context, bookID string, includeDeleted bool) (entity.Book, error) {
+	const query = `SELECT book.id, book.name, book.created_at, book.updated_at, book.rating_sum, book.rating_count,
+						book.isbn, book.publisher, book.publication_year,
+						COALESCE(book.work_id::text, ''), book.language, book.translator, book.deleted_at,
+						array_agg(author_book.author_id ORDER BY author_book.position),
+						array_agg(author_book.role ORDER BY author_book.position)
+					FROM book LEFT JOIN live_author_book AS author_book ON book.id = author_book.book_id 
+					WHERE book.id = $1 AND ($2 OR book.deleted_at IS NULL)
 					GROUP BY book.id, book.name, book.created_at, book.updated_at`
 
 	var result entity.Book
-	var authorIDs []sql.NullString
-	err := p.db.QueryRow(ctx, query, bookID).Scan(&result.ID, &result.Name, &result.CreatedAt, &result.UpdatedAt, &authorIDs)
+	var authorIDs, roles []sql.NullString
+	var ratingSum int
+	var deletedAt *time.Time
+	err := p.db.QueryRow(ctx, query, bookID, includeDeleted).Scan(
+		&result.ID,
+		&result.Name,
+		&result.CreatedAt,
+		&result.UpdatedAt,
+		&ratingSum,
+		&result.RatingCount,
+		&result.ISBN,
+		&result.Publisher,
+		&result.PublicationYear,
+		&result.WorkID,
+		&result.Language,
+		&result.Translator,
+		&deletedAt,
+		&authorIDs,
+		&roles,
+	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return entity.Book{}, entity.ErrBookNotFound
@@ -119,96 +310,157 @@ func (p postgresRepository) GetBook(ctx context.Context, bookID string) (entity.
 	if err != nil {
 		return entity.Book{}, err
 	}
-	result.AuthorIDs = getAuthorsList(authorIDs)
+	result.AuthorIDs, result.Contributors = getContributors(authorIDs, roles)
+	result.RatingAverage = getRatingAverage(ratingSum, result.RatingCount)
+	if deletedAt != nil {
+		result.DeletedAt = *deletedAt
+	}
 
 	return result, nil
 }
 
-func (p postgresRepository) ChangeBookInfo(ctx context.Context, bookID string, newBook entity.Book) (entity.Book, error) {
-	tx, err := p.db.Begin(ctx)
-	if err != nil {
-		return entity.Book{}, err
-	}
+// GetBooks reads the books with the given ids in one query, unknown ids are skipped.
+func (p postgresRepository) GetBooks(ctx context.Context, bookIDs []string) ([]entity.Book, error) {
+	const query = `SELECT book.id, book.name, book.created_at, book.updated_at, book.rating_sum, book.rating_count,
+						book.isbn, book.publisher, book.publication_year,
+						COALESCE(book.work_id::text, ''), book.language, book.translator,
+						array_agg(author_book.author_id ORDER BY author_book.position),
+						array_agg(author_book.role ORDER BY author_book.position)
+					FROM book LEFT JOIN live_author_book AS author_book ON book.id = author_book.book_id
+					WHERE book.id = ANY($1) AND book.deleted_at IS NULL
+					GROUP BY book.id`
 
-	defer func() {
-		_ = tx.Rollback(ctx)
-	}()
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, bookIDs)
 
-	result := entity.Book{
-		ID:        bookID,
-		Name:      newBook.Name,
-		AuthorIDs: newBook.AuthorIDs,
-	}
-
-	const query = `UPDATE book SET name = $2 WHERE id = $1`
-	_, err = tx.Exec(ctx, query, bookID, result.Name)
 	if err != nil {
-		return entity.Book{}, err
+		return nil, err
 	}
 
-	const queryCurrentAuthors = `SELECT author_id FROM author_book WHERE book_id = $1`
-	rows, err := tx.Query(ctx, queryCurrentAuthors, bookID)
-	if err != nil {
-		return entity.Book{}, err
-	}
 	defer rows.Close()
-	authorsToDelete := make([]string, 0)
-	currentAuthors := make([]string, 0)
+
+	books := make([]entity.Book, 0, len(bookIDs))
 	for rows.Next() {
-		var authorID string
-		if err = rows.Scan(&authorID); err != nil {
-			return entity.Book{}, err
-		}
-		currentAuthors = append(currentAuthors, authorID)
-		fl := false
-		for _, newAuthorID := range result.AuthorIDs {
-			if newAuthorID == authorID {
-				fl = true
-			}
-		}
-		if !fl {
-			authorsToDelete = append(authorsToDelete, authorID)
+		var (
+			book             entity.Book
+			authorIDs, roles []sql.NullString
+			ratingSum        int
+		)
+
+		if err = rows.Scan(
+			&book.ID,
+			&book.Name,
+			&book.CreatedAt,
+			&book.UpdatedAt,
+			&ratingSum,
+			&book.RatingCount,
+			&book.ISBN,
+			&book.Publisher,
+			&book.PublicationYear,
+			&book.WorkID,
+			&book.Language,
+			&book.Translator,
+			&authorIDs,
+			&roles,
+		); err != nil {
+			return nil, err
 		}
+
+		book.AuthorIDs, book.Contributors = getContributors(authorIDs, roles)
+		book.RatingAverage = getRatingAverage(ratingSum, book.RatingCount)
+
+		books = append(books, book)
 	}
 
-	authorsToInsert := make([]string, 0)
-	for _, newAuthorID := range result.AuthorIDs {
-		fl := true
-		for _, authorID := range currentAuthors {
-			if newAuthorID == authorID {
-				fl = false
-				break
-			}
-		}
-		if fl {
-			authorsToInsert = append(authorsToInsert, newAuthorID)
+	return books, rows.Err()
+}
+
+func (p postgresRepository) ChangeBookInfo(
+	ctx context.Context,
+	bookID string,
+	newBook entity.Book,
+) (resBook entity.Book, txErr error) {
+	var (
+		tx  pgx.Tx
+		err error
+	)
+
+	if tx, err = extractTX(ctx); err != nil {
+		tx, err = p.db.Begin(ctx)
+
+		if err == nil {
+			defer func(cxt context.Context, tx pgx.Tx) {
+				if txErr != nil {
+					_ = tx.Rollback(ctx)
+					return
+				}
+
+				_ = tx.Commit(cxt)
+			}(ctx, tx)
 		}
 	}
 
-	const queryRemoveAuthors = `DELETE FROM author_book WHERE author_id = Any($1)`
-	_, err = tx.Exec(ctx, queryRemoveAuthors, authorsToDelete)
 	if err != nil {
 		return entity.Book{}, err
 	}
 
-	if err := addAuthorBooks(ctx, tx, result.ID, authorsToInsert); err != nil {
+	result := entity.Book{
+		ID:           bookID,
+		Name:         newBook.Name,
+		AuthorIDs:    newBook.AuthorIDs,
+		Contributors: newBook.Contributors,
+	}
+
+	const query = `UPDATE book SET name = $2 WHERE id = $1 AND deleted_at IS NULL`
+	res, err := tx.Exec(ctx, query, bookID, result.Name)
+	if err != nil {
 		return entity.Book{}, err
 	}
 
-	if err := tx.Commit(ctx); err != nil {
+	if res.RowsAffected() == 0 {
+		return entity.Book{}, entity.ErrBookNotFound
+	}
+
+	if err := changeAuthorBooks(ctx, tx, result.ID, result.Contributors); err != nil {
 		return entity.Book{}, err
 	}
 
 	return result, nil
 }
 
-func (p postgresRepository) GetBooksByAuthor(ctx context.Context, authorIDs string) ([]entity.Book, error) {
-	const query = `SELECT book.id, book.name, book.created_at, book.updated_at, array_agg(author_book.author_id) 
-					FROM book LEFT JOIN author_book ON book.id = author_book.book_id 
-					WHERE book.id = ANY(SELECT book_id FROM author_book WHERE author_book.author_id = $1)
+// DeleteBook moves the book to the trash, its author links, copies, reviews and attachments
+// stay until it is purged.
+func (p postgresRepository) DeleteBook(ctx context.Context, bookID string) error {
+	const query = `UPDATE book SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
+
+	res, err := getQuerier(ctx, p.db).Exec(ctx, query, bookID)
+
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return entity.ErrBookNotFound
+	}
+
+	return nil
+}
+
+func (p postgresRepository) GetBooksByAuthor(
+	ctx context.Context,
+	authorID string,
+	role entity.ContributorRole,
+) ([]entity.Book, error) {
+	const query = `SELECT book.id, book.name, book.created_at, book.updated_at, book.rating_sum, book.rating_count,
+						book.isbn, book.publisher, book.publication_year,
+						COALESCE(book.work_id::text, ''), book.language, book.translator,
+						array_agg(author_book.author_id ORDER BY author_book.position),
+						array_agg(author_book.role ORDER BY author_book.position)
+					FROM book LEFT JOIN live_author_book AS author_book ON book.id = author_book.book_id 
+					WHERE book.deleted_at IS NULL AND book.id = ANY(SELECT book_id FROM live_author_book
+						WHERE live_author_book.author_id = $1 AND ($2 = '' OR live_author_book.role = $2))
 					GROUP BY book.id, book.name, book.created_at, book.updated_at`
 
-	rows, err := p.db.Query(ctx, query, authorIDs)
+	rows, err := p.db.Query(ctx, query, authorID, contributorRoles[role])
 	if err != nil {
 		return []entity.Book{}, err
 	}
@@ -217,12 +469,29 @@ func (p postgresRepository) GetBooksByAuthor(ctx context.Context, authorIDs stri
 	var books []entity.Book
 	for rows.Next() {
 		var book entity.Book
-		var authorIDs []sql.NullString
-		if err := rows.Scan(&book.ID, &book.Name, &book.CreatedAt, &book.UpdatedAt, &authorIDs); err != nil {
+		var authorIDs, roles []sql.NullString
+		var ratingSum int
+		if err := rows.Scan(
+			&book.ID,
+			&book.Name,
+			&book.CreatedAt,
+			&book.UpdatedAt,
+			&ratingSum,
+			&book.RatingCount,
+			&book.ISBN,
+			&book.Publisher,
+			&book.PublicationYear,
+			&book.WorkID,
+			&book.Language,
+			&book.Translator,
+			&authorIDs,
+			&roles,
+		); err != nil {
 			return []entity.Book{}, err
 		}
 
-		book.AuthorIDs = getAuthorsList(authorIDs)
+		book.AuthorIDs, book.Contributors = getContributors(authorIDs, roles)
+		book.RatingAverage = getRatingAverage(ratingSum, book.RatingCount)
 
 		books = append(books, book)
 	}
@@ -270,10 +539,16 @@ func (p postgresRepository) CreateAuthor(ctx context.Context, author entity.Auth
 }
 
 func (p postgresRepository) GetAuthor(ctx context.Context, authorID string) (entity.Author, error) {
-	const query = `SELECT id, name FROM author WHERE id = ($1)`
+	return p.getAuthor(ctx, authorID, false)
+}
+
+// getAuthor reads an author, one in the trash only with includeDeleted.
+func (p postgresRepository) getAuthor(ctx context.Context, authorID string, includeDeleted bool) (entity.Author, error) {
+	const query = `SELECT id, name, deleted_at FROM author WHERE id = ($1) AND ($2 OR deleted_at IS NULL)`
 
 	var author entity.Author
-	err := p.db.QueryRow(ctx, query, authorID).Scan(&author.ID, &author.Name)
+	var deletedAt *time.Time
+	err := p.db.QueryRow(ctx, query, authorID, includeDeleted).Scan(&author.ID, &author.Name, &deletedAt)
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return entity.Author{}, entity.ErrAuthorNotFound
@@ -283,17 +558,47 @@ func (p postgresRepository) GetAuthor(ctx context.Context, authorID string) (ent
 		return entity.Author{}, err
 	}
 
+	if deletedAt != nil {
+		author.DeletedAt = *deletedAt
+	}
+
 	return author, nil
 }
 
+// GetAuthors reads the authors with the given ids in one query, unknown ids are skipped.
+func (p postgresRepository) GetAuthors(ctx context.Context, authorIDs []string) ([]entity.Author, error) {
+	const query = `SELECT id, name FROM author WHERE id = ANY($1) AND deleted_at IS NULL`
+
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, authorIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.Author, 0, len(authorIDs))
+	for rows.Next() {
+		var author entity.Author
+
+		if err = rows.Scan(&author.ID, &author.Name); err != nil {
+			return nil, err
+		}
+
+		result = append(result, author)
+	}
+
+	return result, rows.Err()
+}
+
 func (p postgresRepository) ChangeAuthorInfo(ctx context.Context, id string, newAuthor entity.Author) (entity.Author, error) {
-	const query = `UPDATE author SET name = $2 WHERE id = $1`
+	const query = `UPDATE author SET name = $2 WHERE id = $1 AND deleted_at IS NULL`
 
 	result := entity.Author{
 		ID:   id,
 		Name: newAuthor.Name,
 	}
-	res, err := p.db.Exec(ctx, query, id, result.Name)
+	res, err := getQuerier(ctx, p.db).Exec(ctx, query, id, result.Name)
 
 	if err != nil {
 		return entity.Author{}, err
@@ -305,3 +610,20 @@ func (p postgresRepository) ChangeAuthorInfo(ctx context.Context, id string, new
 
 	return result, nil
 }
+
+// DeleteAuthor moves the author to the trash, the books do not show the author until it is restored.
+func (p postgresRepository) DeleteAuthor(ctx context.Context, authorID string) error {
+	const query = `UPDATE author SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`
+
+	res, err := getQuerier(ctx, p.db).Exec(ctx, query, authorID)
+
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return entity.ErrAuthorNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ RecommendationRepository = (*recommendationRepository)(nil)
+
+type recommendationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRecommendationRepository(db *pgxpool.Pool) *recommendationRepository {
+	return &recommendationRepository{
+		db: db,
+	}
+}
+
+func (r *recommendationRepository) SetBookSubjects(ctx context.Context, bookID string, subjects []string) error {
+	q := getQuerier(ctx, r.db)
+
+	const queryDelete = `DELETE FROM book_subject WHERE book_id = $1`
+	if _, err := q.Exec(ctx, queryDelete, bookID); err != nil {
+		return err
+	}
+
+	const queryInsert = `INSERT INTO book_subject (book_id, subject) SELECT $1, unnest($2::text[])`
+	_, err := q.Exec(ctx, queryInsert, bookID, subjects)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == errForeignKeyViolation {
+		return fmt.Errorf("book does not exist: %w", entity.ErrBookNotFound)
+	}
+
+	return err
+}
+
+// RefreshRecommendations recomputes the top topN neighbours of the books marked stale
+// by the triggers of migration 025 since the previous refresh, the others keep theirs.
+// Candidates are scored by the number of shared authors, shared subjects
+// and patrons who borrowed both books, each multiplied by its weight.
+func (r *recommendationRepository) RefreshRecommendations(
+	ctx context.Context,
+	topN int,
+	weights entity.RecommendationWeights,
+) error {
+	q := getQuerier(ctx, r.db)
+
+	const queryStale = `DELETE FROM recommendation_stale RETURNING book_id`
+
+	rows, err := q.Query(ctx, queryStale)
+
+	if err != nil {
+		return err
+	}
+
+	stale := make([]string, 0)
+	for rows.Next() {
+		var bookID string
+
+		if err = rows.Scan(&bookID); err != nil {
+			rows.Close()
+			return err
+		}
+
+		stale = append(stale, bookID)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	const queryDelete = `DELETE FROM book_recommendation WHERE book_id = ANY($1)`
+	if _, err = q.Exec(ctx, queryDelete, stale); err != nil {
+		return err
+	}
+
+	const queryInsert = `WITH candidate AS (
+							SELECT origin.book_id, other.book_id AS recommended_book_id, $2::float8 AS score
+							FROM live_author_book origin
+								JOIN live_author_book other ON other.author_id = origin.author_id AND other.book_id <> origin.book_id
+									AND other.role = 'AUTHOR'
+							WHERE origin.role = 'AUTHOR' AND origin.book_id = ANY($5)
+							UNION ALL
+							SELECT origin.book_id, other.book_id, $3::float8
+							FROM book_subject origin
+								JOIN book_subject other ON other.subject = origin.subject AND other.book_id <> origin.book_id
+							WHERE origin.book_id = ANY($5)
+							UNION ALL
+							SELECT origin.book_id, other.book_id, $4::float8
+							FROM (SELECT DISTINCT patron_id, book_id FROM loan WHERE book_id = ANY($5)) origin
+								JOIN LATERAL (SELECT DISTINCT book_id FROM loan WHERE loan.patron_id = origin.patron_id) other
+									ON other.book_id <> origin.book_id
+						), scored AS (
+							SELECT book_id, recommended_book_id, sum(score) AS score,
+								row_number() OVER (PARTITION BY book_id ORDER BY sum(score) DESC, recommended_book_id) AS rank
+							FROM candidate
+							WHERE recommended_book_id IN (SELECT id FROM book WHERE deleted_at IS NULL)
+							GROUP BY book_id, recommended_book_id
+						)
+						INSERT INTO book_recommendation (book_id, rank, recommended_book_id, score)
+						SELECT book_id, rank, recommended_book_id, score FROM scored WHERE rank <= $1 AND score > 0`
+
+	_, err = q.Exec(ctx, queryInsert, topN, weights.SharedAuthor, weights.SharedSubject, weights.CoBorrowed, stale)
+
+	return err
+}
+
+func (r *recommendationRepository) GetRecommendations(
+	ctx context.Context,
+	bookID string,
+	limit int,
+) ([]entity.Recommendation, error) {
+	const query = `SELECT book.id, book.name, book.created_at, book.updated_at, book.rating_sum, book.rating_count,
+						book.isbn, book.publisher, book.publication_year,
+						COALESCE(book.work_id::text, ''), book.language, book.translator,
+						array_agg(author_book.author_id ORDER BY author_book.position),
+						array_agg(author_book.role ORDER BY author_book.position), book_recommendation.score
+					FROM book_recommendation
+						JOIN book ON book.id = book_recommendation.recommended_book_id AND book.deleted_at IS NULL
+						LEFT JOIN live_author_book AS author_book ON book.id = author_book.book_id
+					WHERE book_recommendation.book_id = $1 AND book_recommendation.rank <= $2
+					GROUP BY book_recommendation.rank, book_recommendation.score, book.id
+					ORDER BY book_recommendation.rank`
+
+	rows, err := getQuerier(ctx, r.db).Query(ctx, query, bookID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.Recommendation, 0, limit)
+	for rows.Next() {
+		var (
+			recommendation   entity.Recommendation
+			authorIDs, roles []sql.NullString
+			ratingSum        int
+		)
+
+		if err := rows.Scan(
+			&recommendation.Book.ID,
+			&recommendation.Book.Name,
+			&recommendation.Book.CreatedAt,
+			&recommendation.Book.UpdatedAt,
+			&ratingSum,
+			&recommendation.Book.RatingCount,
+			&recommendation.Book.ISBN,
+			&recommendation.Book.Publisher,
+			&recommendation.Book.PublicationYear,
+			&recommendation.Book.WorkID,
+			&recommendation.Book.Language,
+			&recommendation.Book.Translator,
+			&authorIDs,
+			&roles,
+			&recommendation.Score,
+		); err != nil {
+			return nil, err
+		}
+
+		recommendation.Book.AuthorIDs, recommendation.Book.Contributors = getContributors(authorIDs, roles)
+		recommendation.Book.RatingAverage = getRatingAverage(ratingSum, recommendation.Book.RatingCount)
+
+		result = append(result, recommendation)
+	}
+
+	return result, rows.Err()
+}
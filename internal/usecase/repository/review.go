@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ ReviewRepository = (*reviewRepository)(nil)
+
+type reviewRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReviewRepository(db *pgxpool.Pool) *reviewRepository {
+	return &reviewRepository{
+		db: db,
+	}
+}
+
+var reviewStatuses = map[entity.ReviewStatus]string{
+	entity.ReviewStatusPending:  "PENDING",
+	entity.ReviewStatusApproved: "APPROVED",
+	entity.ReviewStatusRejected: "REJECTED",
+}
+
+func parseReviewStatus(status string) entity.ReviewStatus {
+	for key, value := range reviewStatuses {
+		if value == status {
+			return key
+		}
+	}
+
+	return entity.ReviewStatusUndefined
+}
+
+func getReviewError(err error) error {
+	var pgErr *pgconn.PgError
+
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch {
+	case pgErr.Code == errUniqueViolation:
+		return entity.ErrReviewAlreadyExists
+	case pgErr.Code == errForeignKeyViolation && pgErr.ConstraintName == "review_patron_id_fkey":
+		return fmt.Errorf("patron does not exist: %w", entity.ErrPatronNotFound)
+	case pgErr.Code == errForeignKeyViolation:
+		return fmt.Errorf("book does not exist: %w", entity.ErrBookNotFound)
+	default:
+		return err
+	}
+}
+
+const reviewColumns = `id, book_id, patron_id, rating, text, status, created_at, updated_at`
+
+func scanReview(row pgx.Row) (entity.Review, error) {
+	var (
+		review entity.Review
+		status string
+	)
+
+	err := row.Scan(
+		&review.ID,
+		&review.BookID,
+		&review.PatronID,
+		&review.Rating,
+		&review.Text,
+		&status,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Review{}, entity.ErrReviewNotFound
+	}
+
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	review.Status = parseReviewStatus(status)
+
+	return review, nil
+}
+
+func (r *reviewRepository) CreateReview(ctx context.Context, review entity.Review) (entity.Review, error) {
+	// A book in the trash can not be reviewed, an unknown one is caught by the foreign key.
+	const query = `INSERT INTO review (book_id, patron_id, rating, text, status)
+					SELECT $1, $2, $3, $4, $5
+					WHERE NOT EXISTS (SELECT 1 FROM book WHERE id = $1 AND deleted_at IS NOT NULL)
+					RETURNING ` + reviewColumns
+
+	result, err := scanReview(getQuerier(ctx, r.db).QueryRow(
+		ctx,
+		query,
+		review.BookID,
+		review.PatronID,
+		review.Rating,
+		review.Text,
+		reviewStatuses[review.Status],
+	))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Review{}, fmt.Errorf("book is deleted: %w", entity.ErrBookNotFound)
+	}
+
+	if err != nil {
+		return entity.Review{}, getReviewError(err)
+	}
+
+	return result, nil
+}
+
+func (r *reviewRepository) GetReviewForUpdate(ctx context.Context, id string) (entity.Review, error) {
+	const query = `SELECT ` + reviewColumns + ` FROM review WHERE id = $1 FOR UPDATE`
+
+	return scanReview(getQuerier(ctx, r.db).QueryRow(ctx, query, id))
+}
+
+func (r *reviewRepository) UpdateReviewStatus(
+	ctx context.Context,
+	id string,
+	status entity.ReviewStatus,
+) (entity.Review, error) {
+	const query = `UPDATE review SET status = $2 WHERE id = $1 RETURNING ` + reviewColumns
+
+	return scanReview(getQuerier(ctx, r.db).QueryRow(ctx, query, id, reviewStatuses[status]))
+}
+
+func (r *reviewRepository) ListReviews(
+	ctx context.Context,
+	filter entity.ReviewFilter,
+	after *entity.PageCursor,
+	limit int,
+) ([]entity.Review, error) {
+	conditions := make([]string, 0, 4)
+	args := make([]any, 0, 6)
+
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if filter.BookID != "" {
+		conditions = append(conditions, "book_id = "+arg(filter.BookID))
+	}
+
+	if filter.PatronID != "" {
+		conditions = append(conditions, "patron_id = "+arg(filter.PatronID))
+	}
+
+	if filter.Status != entity.ReviewStatusUndefined {
+		conditions = append(conditions, "status = "+arg(reviewStatuses[filter.Status]))
+	}
+
+	if after != nil {
+		conditions = append(conditions, "(created_at, id) > ("+arg(after.CreatedAt)+", "+arg(after.ID)+")")
+	}
+
+	query := `SELECT ` + reviewColumns + ` FROM review`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	query += ` ORDER BY created_at, id LIMIT ` + arg(limit)
+
+	rows, err := getQuerier(ctx, r.db).Query(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]entity.Review, 0, limit)
+	for rows.Next() {
+		review, err := scanReview(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, review)
+	}
+
+	return result, rows.Err()
+}
+
+// UpdateBookRating shifts the rating aggregates, the book triggers skip rating-only updates (migration 024).
+func (r *reviewRepository) UpdateBookRating(ctx context.Context, bookID string, sumDelta int, countDelta int) error {
+	const query = `UPDATE book SET rating_sum = rating_sum + $2, rating_count = rating_count + $3 WHERE id = $1`
+
+	res, err := getQuerier(ctx, r.db).Exec(ctx, query, bookID, sumDelta, countDelta)
+
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return entity.ErrBookNotFound
+	}
+
+	return nil
+}
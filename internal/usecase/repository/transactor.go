@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 )
@@ -65,6 +66,22 @@ func extractTX(ctx context.Context) (pgx.Tx, error) {
 	return tx, nil
 }
 
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+// getQuerier returns the transaction stored in ctx, falling back to the pool.
+func getQuerier(ctx context.Context, pool *pgxpool.Pool) querier {
+	if tx, err := extractTX(ctx); err == nil {
+		return tx
+	}
+
+	return pool
+}
+
 func injectTx(ctx context.Context, pool *pgxpool.Pool) (context.Context, pgx.Tx, error) {
 	if tx, err := extractTX(ctx); err == nil {
 		return ctx, tx, nil
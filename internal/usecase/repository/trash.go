@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/project/library/internal/entity"
+)
+
+var _ TrashRepository = (*postgresRepository)(nil)
+
+func (p postgresRepository) GetBookIncludingDeleted(ctx context.Context, bookID string) (entity.Book, error) {
+	return p.getBook(ctx, bookID, true)
+}
+
+func (p postgresRepository) GetAuthorIncludingDeleted(ctx context.Context, authorID string) (entity.Author, error) {
+	return p.getAuthor(ctx, authorID, true)
+}
+
+// RestoreBook takes the book out of the trash, a book that is not in the trash is reported as not found.
+func (p postgresRepository) RestoreBook(ctx context.Context, bookID string) error {
+	const query = `UPDATE book SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	res, err := getQuerier(ctx, p.db).Exec(ctx, query, bookID)
+
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return entity.ErrBookNotFound
+	}
+
+	return nil
+}
+
+// RestoreAuthor takes the author out of the trash, its books show it again.
+func (p postgresRepository) RestoreAuthor(ctx context.Context, authorID string) error {
+	const query = `UPDATE author SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	res, err := getQuerier(ctx, p.db).Exec(ctx, query, authorID)
+
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return entity.ErrAuthorNotFound
+	}
+
+	return nil
+}
+
+// PurgeDeleted removes the books and authors that stayed in the trash longer than retention for good,
+// together with everything that references them. Returns the number of purged rows.
+func (p postgresRepository) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	const (
+		queryBooks   = `DELETE FROM book WHERE deleted_at < now() - make_interval(secs => $1)`
+		queryAuthors = `DELETE FROM author WHERE deleted_at < now() - make_interval(secs => $1)`
+	)
+
+	books, err := getQuerier(ctx, p.db).Exec(ctx, queryBooks, retention.Seconds())
+
+	if err != nil {
+		return 0, err
+	}
+
+	authors, err := getQuerier(ctx, p.db).Exec(ctx, queryAuthors, retention.Seconds())
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int(books.RowsAffected() + authors.RowsAffected()), nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/project/library/internal/entity"
+)
+
+var _ WorkRepository = (*postgresRepository)(nil)
+
+func (p postgresRepository) CreateWork(ctx context.Context, work entity.Work) (entity.Work, error) {
+	const query = `INSERT INTO work (name) VALUES ($1) RETURNING id, created_at, updated_at`
+
+	result := entity.Work{
+		Name: work.Name,
+	}
+
+	err := getQuerier(ctx, p.db).QueryRow(ctx, query, result.Name).Scan(&result.ID, &result.CreatedAt, &result.UpdatedAt)
+
+	if err != nil {
+		return entity.Work{}, err
+	}
+
+	return result, nil
+}
+
+func (p postgresRepository) GetWork(ctx context.Context, workID string) (entity.Work, error) {
+	const query = `SELECT id, name, created_at, updated_at FROM work WHERE id = $1`
+
+	var work entity.Work
+	err := getQuerier(ctx, p.db).QueryRow(ctx, query, workID).Scan(&work.ID, &work.Name, &work.CreatedAt, &work.UpdatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Work{}, entity.ErrWorkNotFound
+	}
+
+	if err != nil {
+		return entity.Work{}, err
+	}
+
+	return work, nil
+}
+
+// AddWorkEditions moves the books into the work, a book leaves its previous work.
+func (p postgresRepository) AddWorkEditions(ctx context.Context, workID string, bookIDs []string) error {
+	const query = `UPDATE book SET work_id = $1 WHERE id = ANY($2) AND deleted_at IS NULL`
+
+	res, err := getQuerier(ctx, p.db).Exec(ctx, query, workID, bookIDs)
+
+	if err != nil {
+		return getWorkError(err)
+	}
+
+	if res.RowsAffected() != int64(len(bookIDs)) {
+		return entity.ErrBookNotFound
+	}
+
+	return nil
+}
+
+func (p postgresRepository) SetBookEdition(ctx context.Context, bookID string, edition entity.Edition) error {
+	const query = `UPDATE book
+					SET work_id = NULLIF($2, '')::uuid, isbn = $3, publisher = $4, publication_year = $5,
+						language = $6, translator = $7
+					WHERE id = $1 AND deleted_at IS NULL`
+
+	res, err := getQuerier(ctx, p.db).Exec(ctx, query,
+		bookID,
+		edition.WorkID,
+		edition.ISBN,
+		edition.Publisher,
+		edition.PublicationYear,
+		edition.Language,
+		edition.Translator,
+	)
+
+	if err != nil {
+		return getWorkError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return entity.ErrBookNotFound
+	}
+
+	return nil
+}
+
+// GetEditions returns the editions of a work from the earliest publication.
+func (p postgresRepository) GetEditions(ctx context.Context, workID string) ([]entity.Book, error) {
+	const query = `SELECT book.id, book.name, book.created_at, book.updated_at, book.rating_sum, book.rating_count,
+						book.isbn, book.publisher, book.publication_year,
+						COALESCE(book.work_id::text, ''), book.language, book.translator,
+						array_agg(author_book.author_id ORDER BY author_book.position),
+						array_agg(author_book.role ORDER BY author_book.position)
+					FROM book LEFT JOIN live_author_book AS author_book ON book.id = author_book.book_id
+					WHERE book.work_id = $1 AND book.deleted_at IS NULL
+					GROUP BY book.id
+					ORDER BY book.publication_year, book.id`
+
+	rows, err := getQuerier(ctx, p.db).Query(ctx, query, workID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	books := make([]entity.Book, 0)
+	for rows.Next() {
+		var (
+			book             entity.Book
+			authorIDs, roles []sql.NullString
+			ratingSum        int
+		)
+
+		if err = rows.Scan(
+			&book.ID,
+			&book.Name,
+			&book.CreatedAt,
+			&book.UpdatedAt,
+			&ratingSum,
+			&book.RatingCount,
+			&book.ISBN,
+			&book.Publisher,
+			&book.PublicationYear,
+			&book.WorkID,
+			&book.Language,
+			&book.Translator,
+			&authorIDs,
+			&roles,
+		); err != nil {
+			return nil, err
+		}
+
+		book.AuthorIDs, book.Contributors = getContributors(authorIDs, roles)
+		book.RatingAverage = getRatingAverage(ratingSum, book.RatingCount)
+
+		books = append(books, book)
+	}
+
+	return books, rows.Err()
+}
+
+func getWorkError(err error) error {
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) && pgErr.Code == errForeignKeyViolation {
+		return entity.ErrWorkNotFound
+	}
+
+	return err
+}